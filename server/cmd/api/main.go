@@ -3,13 +3,24 @@ package main
 import (
 	"context"
 	"log"
+	"net/http"
 	"os"
 	"time"
 	"veritas/config"
 	"veritas/core/usecases"
+	"veritas/internal/adapters/breach"
 	"veritas/internal/adapters/db"
+	"veritas/internal/adapters/events"
+	"veritas/internal/adapters/mailer"
+	"veritas/internal/adapters/memory"
+	"veritas/internal/adapters/siem"
+	"veritas/internal/adapters/sms"
 	"veritas/internal/handlers"
+	"veritas/internal/middleware"
+	"veritas/internal/ports/output"
 	"veritas/internal/routes"
+	"veritas/internal/security"
+	"veritas/internal/web"
 
 	_ "veritas/docs"
 
@@ -60,31 +71,271 @@ func main() {
 	}
 
 	router := gin.Default()
+	if err := router.SetTrustedProxies(config.GetTrustedProxies()); err != nil {
+		log.Fatalf("invalid TRUSTED_PROXIES: %v", err)
+	}
 
 	dbName := config.GetDatabaseName()
 	userRepository := db.NewUserRepository(client.Database(dbName))
-	userUsecase := usecases.NewUserUsecase(userRepository)
-	userHandler := handlers.NewUserHandler(*userUsecase)
-	authHandler := handlers.NewAuthHandler(*userUsecase)
+	auditRepository := db.NewAuditRepository(client.Database(dbName))
+	if err := auditRepository.EnsureIndexes(ctx); err != nil {
+		log.Fatalf("failed to prepare audit log: %v", err)
+	}
+
+	organizationRepository := db.NewOrganizationRepository(client.Database(dbName))
+	if err := organizationRepository.EnsureIndexes(ctx); err != nil {
+		log.Fatalf("failed to prepare organizations: %v", err)
+	}
+	organizationUsecase := usecases.NewOrganizationUsecase(organizationRepository, userRepository, auditRepository)
+	defaultOrganization, err := organizationUsecase.EnsureDefaultOrganization(ctx)
+	if err != nil {
+		log.Fatalf("failed to create the default organization: %v", err)
+	}
+	if err := db.AssignTenant(ctx, client.Database(dbName), defaultOrganization.ID); err != nil {
+		log.Fatalf("failed to assign records to the default organization: %v", err)
+	}
+	if err := userRepository.EnsureIndexes(ctx); err != nil {
+		log.Fatalf("failed to prepare users: %v", err)
+	}
+	if err := organizationUsecase.SeedAdmins(ctx, defaultOrganization, config.GetDefaultOrganizationAdmins()); err != nil {
+		log.Fatalf("failed to add default organization admins: %v", err)
+	}
+	organizationHandler := handlers.NewOrganizationHandler(*organizationUsecase)
+	passwordPolicyUsecase := usecases.NewPasswordPolicyUsecase(security.PasswordPolicy{
+		MinLength:     config.GetPasswordMinLength(),
+		RequireUpper:  config.GetPasswordRequireUpper(),
+		RequireLower:  config.GetPasswordRequireLower(),
+		RequireDigit:  config.GetPasswordRequireDigit(),
+		RequireSymbol: config.GetPasswordRequireSymbol(),
+		MinStrength:   config.GetPasswordMinStrength(),
+		HistorySize:   config.GetPasswordHistory(),
+	}, newBreachedPasswordStore())
+	userUsecase := usecases.NewUserUsecase(userRepository, passwordPolicyUsecase, auditRepository)
+	loginAttemptRepository := db.NewLoginAttemptRepository(client.Database(dbName))
+	loginProtectionUsecase := usecases.NewLoginProtectionUsecase(loginAttemptRepository, userRepository, events.NewLogPublisher(), auditRepository, usecases.LoginProtectionPolicy{
+		AccountThreshold: config.GetLockoutAccountThreshold(),
+		IPThreshold:      config.GetLockoutIPThreshold(),
+		Window:           config.GetLockoutWindow(),
+		LockoutDuration:  config.GetLockoutDuration(),
+		BaseDelay:        config.GetLoginDelayBase(),
+		MaxDelay:         config.GetLoginDelayMax(),
+	})
+	userHandler := handlers.NewUserHandler(*userUsecase, *loginProtectionUsecase)
+
+	encryptionKey, err := config.GetEncryptionKey()
+	if err != nil {
+		log.Fatalf("failed to load encryption key: %v", err)
+	}
+	mfaUsecase := usecases.NewMFAUsecase(userRepository, auditRepository, encryptionKey, config.GetMFAIssuer())
+
+	webAuthnCredentialRepository := db.NewWebAuthnCredentialRepository(client.Database(dbName))
+	webAuthnChallengeRepository := db.NewWebAuthnChallengeRepository(client.Database(dbName))
+	webAuthnUsecase := usecases.NewWebAuthnUsecase(webAuthnCredentialRepository, webAuthnChallengeRepository, userRepository, auditRepository, security.WebAuthnConfig{
+		RPID:    config.GetWebAuthnRPID(),
+		RPName:  config.GetWebAuthnRPName(),
+		Origins: config.GetWebAuthnOrigins(),
+	})
+	webAuthnHandler := handlers.NewWebAuthnHandler(*webAuthnUsecase)
+
+	emailSender := newEmailSender()
+	emailVerificationUsecase := usecases.NewEmailVerificationUsecase(userRepository, emailSender, auditRepository, config.GetAppBaseURL(), config.GetEmailVerificationResendInterval(), config.GetRequireEmailVerification())
+	oneTimeTokenRepository := db.NewOneTimeTokenRepository(client.Database(dbName))
+	sessionRepository := db.NewSessionRepository(client.Database(dbName))
+	if err := sessionRepository.EnsureIndexes(ctx); err != nil {
+		log.Fatalf("failed to prepare session store: %v", err)
+	}
+	passwordResetUsecase := usecases.NewPasswordResetUsecase(userRepository, oneTimeTokenRepository, sessionRepository, emailSender, passwordPolicyUsecase, auditRepository, config.GetAppBaseURL())
+	rateLimitRepository := db.NewRateLimitRepository(client.Database(dbName))
+	passwordlessUsecase := usecases.NewPasswordlessUsecase(userRepository, oneTimeTokenRepository, emailSender, rateLimitRepository, loginProtectionUsecase, auditRepository, config.GetAppBaseURL(), config.GetPasswordlessRateLimit())
+	smsUsecase := usecases.NewSMSUsecase(userRepository, oneTimeTokenRepository, newSMSSender(), rateLimitRepository, auditRepository, encryptionKey, config.GetSMSRateLimit())
+	sessionUsecase := usecases.NewSessionUsecase(sessionRepository, auditRepository, usecases.SessionPolicy{
+		IdleTimeout:     config.GetSessionIdleTimeout(),
+		AbsoluteTimeout: config.GetSessionAbsoluteTimeout(),
+		MaxSessions:     config.GetMaxSessions(),
+	})
+	sessionCookies, err := config.GetSessionCookieConfig()
+	if err != nil {
+		log.Fatalf("failed to configure session cookies: %v", err)
+	}
+	sessionHandler := handlers.NewSessionHandler(*sessionUsecase, sessionCookies)
+	mfaHandler := handlers.NewMFAHandler(*mfaUsecase, *webAuthnUsecase, *smsUsecase)
+	authHandler := handlers.NewAuthHandler(*userUsecase, *mfaUsecase, *webAuthnUsecase, *emailVerificationUsecase, *passwordResetUsecase, *passwordlessUsecase, *smsUsecase, *loginProtectionUsecase, *sessionUsecase, sessionCookies, config.GetRequireDPoP())
 
 	roleRepository := db.NewRoleRepository(client.Database(dbName))
-	roleUsecase := usecases.NewRoleUsecase(roleRepository)
+	roleUsecase := usecases.NewRoleUsecase(roleRepository, auditRepository)
 	roleHandler := handlers.NewRoleHandler(*roleUsecase)
 
 	claimRepository := db.NewClaimRepository(client.Database(dbName))
-	claimUsecase := usecases.NewClaimUsecase(claimRepository)
+	claimUsecase := usecases.NewClaimUsecase(claimRepository, auditRepository)
 	claimHandler := handlers.NewClaimHandler(*claimUsecase)
 
-	routes.SetupUserRoutes(router, userHandler)
+	invitationRepository := db.NewInvitationRepository(client.Database(dbName))
+	if err := invitationRepository.EnsureIndexes(ctx); err != nil {
+		log.Fatalf("failed to prepare invitations: %v", err)
+	}
+	invitationUsecase := usecases.NewInvitationUsecase(invitationRepository, organizationUsecase, userRepository, roleRepository, sessionRepository, webAuthnCredentialRepository, emailSender, passwordPolicyUsecase, auditRepository, config.GetAppBaseURL())
+	invitationHandler := handlers.NewInvitationHandler(*invitationUsecase)
+
+	brandingRepository := db.NewBrandingRepository(client.Database(dbName))
+	brandingUsecase := usecases.NewBrandingUsecase(brandingRepository, auditRepository)
+	brandingHandler := handlers.NewBrandingHandler(*brandingUsecase, *organizationUsecase)
+	pageRenderer, err := web.NewRenderer()
+	if err != nil {
+		log.Fatalf("failed to load hosted pages: %v", err)
+	}
+	pageHandler := handlers.NewPageHandler(authHandler, *brandingUsecase, *organizationUsecase, *invitationUsecase, pageRenderer)
+
+	auditUsecase := usecases.NewAuditUsecase(auditRepository)
+	auditHandler := handlers.NewAuditHandler(*auditUsecase)
+
+	if auditExportTargets := newAuditExportTargets(); len(auditExportTargets) > 0 {
+		auditCheckpointRepository := db.NewAuditCheckpointRepository(client.Database(dbName))
+		auditExportUsecase := usecases.NewAuditExportUsecase(auditRepository, auditCheckpointRepository, auditExportTargets)
+		go auditExportUsecase.Run(context.Background(), config.GetAuditExportInterval())
+	}
+
+	authMiddleware := middleware.AuthMiddleware(userRepository, sessionUsecase, sessionCookies, config.GetRequireDPoP())
+	adminMiddleware := middleware.RequireOrganizationAdmin(organizationUsecase)
+
+	rateLimitPolicies, err := config.GetRateLimitPolicies()
+	if err != nil {
+		log.Fatalf("failed to load rate limit policies: %v", err)
+	}
+	router.Use(middleware.RequestInfoMiddleware())
+	router.Use(middleware.TenantMiddleware(organizationUsecase))
+	router.Use(middleware.RateLimitMiddleware(newRateLimitStore(ctx, rateLimitRepository), rateLimitPolicies))
+
+	routes.SetupUserRoutes(router, userHandler, authMiddleware, adminMiddleware)
 	routes.SetupAuthRoutes(router, authHandler)
-	routes.SetupRoleRoutes(router, roleHandler)
-	routes.SetupClaimRoutes(router, claimHandler)
+	routes.SetupRoleRoutes(router, roleHandler, authMiddleware, adminMiddleware)
+	routes.SetupClaimRoutes(router, claimHandler, authMiddleware, adminMiddleware)
+	routes.SetupMFARoutes(router, mfaHandler, authMiddleware, adminMiddleware)
+	routes.SetupWebAuthnRoutes(router, webAuthnHandler, authMiddleware)
+	routes.SetupAuditRoutes(router, auditHandler, authMiddleware, adminMiddleware)
+	routes.SetupSessionRoutes(router, sessionHandler, authMiddleware, adminMiddleware)
+	routes.SetupOrganizationRoutes(router, organizationHandler, authMiddleware)
+	routes.SetupInvitationRoutes(router, invitationHandler, authMiddleware)
+	routes.SetupBrandingRoutes(router, brandingHandler, authMiddleware, adminMiddleware)
+	routes.SetupPageRoutes(router, pageHandler, authMiddleware)
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	tlsConfig, err := config.GetTLSConfig()
+	if err != nil {
+		log.Fatalf("failed to configure tls: %v", err)
+	}
+	if tlsConfig != nil {
+		tlsServer := &http.Server{
+			Addr:      ":" + config.GetTLSPort(),
+			Handler:   router,
+			TLSConfig: tlsConfig,
+		}
+		go func() {
+			log.Printf("TLS server listening on port %s", config.GetTLSPort())
+			if err := tlsServer.ListenAndServeTLS("", ""); err != nil {
+				log.Fatalf("failed to run tls server: %v", err)
+			}
+		}()
+	}
+
 	log.Printf("Server listening on port %s", port)
 	err = router.Run(":" + port)
 	if err != nil {
 		log.Fatalf("failed to run server: %v", err)
 	}
 }
+
+// newEmailSender returns the mailer adapter selected with MAILER.
+func newEmailSender() output.EmailSender {
+	switch mailerType := config.GetMailerType(); mailerType {
+	case config.MailerSMTP:
+		return mailer.NewSMTPSender(config.GetSMTPConfig(), config.GetMailFrom())
+	case config.MailerFile:
+		return mailer.NewFileSender(config.GetMailDir(), config.GetMailFrom())
+	case config.MailerMemory:
+		return mailer.NewMemorySender()
+	default:
+		log.Fatalf("unknown mailer: %s", mailerType)
+		return nil
+	}
+}
+
+// newBreachedPasswordStore opens BREACHED_PASSWORDS_FILE, returning nil when
+// it is not set.
+func newBreachedPasswordStore() output.BreachedPasswordOutputPort {
+	path := config.GetBreachedPasswordsFile()
+	if path == "" {
+		return nil
+	}
+	store, err := breach.NewFileStore(path)
+	if err != nil {
+		log.Fatalf("failed to open breached passwords: %v", err)
+	}
+	return store
+}
+
+// newRateLimitStore returns the rate limit store selected with
+// RATE_LIMIT_STORE.
+func newRateLimitStore(ctx context.Context, repository *db.RateLimitRepository) output.RateLimitOutputPort {
+	switch storeType := config.GetRateLimitStore(); storeType {
+	case config.RateLimitStoreMemory:
+		return memory.NewRateLimitStore()
+	case config.RateLimitStoreMongo:
+		if err := repository.EnsureIndexes(ctx); err != nil {
+			log.Fatalf("failed to prepare rate limit store: %v", err)
+		}
+		return repository
+	default:
+		log.Fatalf("unknown rate limit store: %s", storeType)
+		return nil
+	}
+}
+
+// newAuditExportTargets builds the exporters listed in AUDIT_EXPORT_CONFIG.
+func newAuditExportTargets() []usecases.AuditExportTarget {
+	exporters, err := config.GetAuditExporters()
+	if err != nil {
+		log.Fatalf("failed to load audit export config: %v", err)
+	}
+
+	var targets []usecases.AuditExportTarget
+	for _, exporter := range exporters {
+		format, err := siem.NewFormatter(exporter.Format)
+		if err != nil {
+			log.Fatalf("audit exporter %s: %v", exporter.Name, err)
+		}
+
+		var target output.AuditExporter
+		switch exporter.Type {
+		case config.AuditExportSyslog:
+			tlsConfig, err := exporter.TLSConfig()
+			if err != nil {
+				log.Fatalf("audit exporter %s: %v", exporter.Name, err)
+			}
+			target, err = siem.NewSyslogExporter(exporter.Network, exporter.Address, tlsConfig, format)
+			if err != nil {
+				log.Fatalf("audit exporter %s: %v", exporter.Name, err)
+			}
+		case config.AuditExportFile:
+			target = siem.NewFileExporter(exporter.Path, format, int64(exporter.MaxSizeMB)<<20, exporter.MaxFiles)
+		}
+		targets = append(targets, usecases.AuditExportTarget{Name: exporter.Name, Categories: exporter.Categories, Exporter: target})
+	}
+	return targets
+}
+
+// newSMSSender returns the SMS adapter selected with SMS_SENDER.
+func newSMSSender() output.SMSSender {
+	switch senderType := config.GetSMSSenderType(); senderType {
+	case config.SMSSenderLog:
+		return sms.NewLogSender(config.GetSMSLogFile())
+	case config.SMSSenderWebhook:
+		if config.GetSMSWebhookURL() == "" {
+			log.Fatal("SMS_WEBHOOK_URL is required for the webhook sms sender")
+		}
+		return sms.NewWebhookSender(config.GetSMSWebhookURL(), config.GetSMSWebhookToken())
+	default:
+		log.Fatalf("unknown sms sender: %s", senderType)
+		return nil
+	}
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Audit exporters selectable in AUDIT_EXPORT_CONFIG.
+const (
+	AuditExportSyslog = "syslog"
+	AuditExportFile   = "file"
+)
+
+// AuditExportConfig configures one audit exporter. Syslog exporters send to
+// Address over Network ("udp", "tcp" or "tls"); file exporters append to
+// Path and rotate it at MaxSizeMB, keeping MaxFiles old files. Format is
+// "json", "cef" or "leef". An empty Categories exports every category.
+type AuditExportConfig struct {
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	Format     string   `json:"format"`
+	Categories []string `json:"categories"`
+	Network    string   `json:"network"`
+	Address    string   `json:"address"`
+	CAFile     string   `json:"caFile"`
+	Path       string   `json:"path"`
+	MaxSizeMB  int      `json:"maxSizeMB"`
+	MaxFiles   int      `json:"maxFiles"`
+}
+
+// TLSConfig returns the client configuration for a syslog exporter over
+// TLS, trusting CAFile when it is set and the system roots otherwise.
+func (c AuditExportConfig) TLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if c.CAFile == "" {
+		return tlsConfig, nil
+	}
+
+	pem, err := os.ReadFile(c.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit export ca bundle: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in audit export ca bundle %s", c.CAFile)
+	}
+	tlsConfig.RootCAs = pool
+	return tlsConfig, nil
+}
+
+// GetAuditExporters reads the exporters from the JSON file named by
+// AUDIT_EXPORT_CONFIG. Audit entries are not exported when it is not set.
+func GetAuditExporters() ([]AuditExportConfig, error) {
+	path := os.Getenv("AUDIT_EXPORT_CONFIG")
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit export config: %w", err)
+	}
+	var exporters []AuditExportConfig
+	if err := json.Unmarshal(data, &exporters); err != nil {
+		return nil, fmt.Errorf("failed to parse audit export config: %w", err)
+	}
+
+	names := map[string]bool{}
+	for _, exporter := range exporters {
+		if exporter.Name == "" || names[exporter.Name] {
+			return nil, fmt.Errorf("audit exporter %q needs a unique name", exporter.Name)
+		}
+		names[exporter.Name] = true
+		switch {
+		case exporter.Type == AuditExportSyslog && exporter.Address == "":
+			return nil, fmt.Errorf("audit exporter %q needs an address", exporter.Name)
+		case exporter.Type == AuditExportFile && exporter.Path == "":
+			return nil, fmt.Errorf("audit exporter %q needs a path", exporter.Name)
+		case exporter.Type != AuditExportSyslog && exporter.Type != AuditExportFile:
+			return nil, fmt.Errorf("audit exporter %q has unknown type %q", exporter.Name, exporter.Type)
+		}
+	}
+	return exporters, nil
+}
+
+// GetAuditExportInterval returns how often new audit entries are exported.
+func GetAuditExportInterval() time.Duration {
+	return getEnvDuration("AUDIT_EXPORT_INTERVAL", 10*time.Second)
+}
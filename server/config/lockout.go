@@ -0,0 +1,32 @@
+package config
+
+import "time"
+
+// GetLockoutAccountThreshold returns how many failed logins lock an account.
+func GetLockoutAccountThreshold() int {
+	return getEnvInt("LOCKOUT_ACCOUNT_THRESHOLD", 5)
+}
+
+// GetLockoutIPThreshold returns how many failed logins lock a source IP.
+func GetLockoutIPThreshold() int {
+	return getEnvInt("LOCKOUT_IP_THRESHOLD", 20)
+}
+
+// GetLockoutWindow returns how long a failed login counts towards a lockout.
+func GetLockoutWindow() time.Duration {
+	return getEnvDuration("LOCKOUT_WINDOW", 15*time.Minute)
+}
+
+func GetLockoutDuration() time.Duration {
+	return getEnvDuration("LOCKOUT_DURATION", 15*time.Minute)
+}
+
+// GetLoginDelayBase returns the wait after the first failed login, which
+// doubles with every further failure.
+func GetLoginDelayBase() time.Duration {
+	return getEnvDuration("LOGIN_DELAY_BASE", time.Second)
+}
+
+func GetLoginDelayMax() time.Duration {
+	return getEnvDuration("LOGIN_DELAY_MAX", 30*time.Second)
+}
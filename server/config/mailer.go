@@ -0,0 +1,86 @@
+package config
+
+import (
+	"log"
+	"os"
+	"time"
+)
+
+// Mailer adapters selectable with MAILER.
+const (
+	MailerSMTP   = "smtp"
+	MailerFile   = "file"
+	MailerMemory = "memory"
+)
+
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+}
+
+func GetMailerType() string {
+	mailer := os.Getenv("MAILER")
+	if mailer == "" {
+		mailer = MailerFile
+		log.Println("MAILER not set, using default: file")
+	}
+	return mailer
+}
+
+func GetSMTPConfig() SMTPConfig {
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+	return SMTPConfig{
+		Host:     os.Getenv("SMTP_HOST"),
+		Port:     port,
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+	}
+}
+
+func GetMailFrom() string {
+	from := os.Getenv("MAIL_FROM")
+	if from == "" {
+		from = "Veritas <no-reply@localhost>"
+	}
+	return from
+}
+
+// GetMailDir returns where the file mailer writes messages.
+func GetMailDir() string {
+	dir := os.Getenv("MAIL_DIR")
+	if dir == "" {
+		dir = "mail"
+	}
+	return dir
+}
+
+// GetAppBaseURL returns the public URL used to build links sent to users.
+func GetAppBaseURL() string {
+	baseURL := os.Getenv("APP_BASE_URL")
+	if baseURL == "" {
+		baseURL = "http://localhost:8080"
+		log.Println("APP_BASE_URL not set, using default: http://localhost:8080")
+	}
+	return baseURL
+}
+
+// GetRequireEmailVerification reports whether login is refused until the
+// user has verified their email address.
+func GetRequireEmailVerification() bool {
+	return getEnvBool("REQUIRE_EMAIL_VERIFICATION", false)
+}
+
+func GetEmailVerificationResendInterval() time.Duration {
+	return getEnvDuration("EMAIL_VERIFICATION_RESEND_INTERVAL", time.Minute)
+}
+
+// GetPasswordlessRateLimit returns how many sign-in links and codes an
+// address may be sent per hour.
+func GetPasswordlessRateLimit() int {
+	return getEnvInt("PASSWORDLESS_RATE_LIMIT_PER_HOUR", 5)
+}
@@ -0,0 +1,19 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// GetDefaultOrganizationAdmins returns the comma-separated email addresses
+// of the users made admins of the default organization on startup. Admins
+// of the default organization manage every organization.
+func GetDefaultOrganizationAdmins() []string {
+	var result []string
+	for _, email := range strings.Split(os.Getenv("DEFAULT_ORGANIZATION_ADMINS"), ",") {
+		if email = strings.TrimSpace(email); email != "" {
+			result = append(result, email)
+		}
+	}
+	return result
+}
@@ -0,0 +1,42 @@
+package config
+
+import "os"
+
+func GetPasswordMinLength() int {
+	return getEnvInt("PASSWORD_MIN_LENGTH", 8)
+}
+
+// GetPasswordRequireUpper and the following getters return which character
+// classes a password must contain. None are required by default.
+func GetPasswordRequireUpper() bool {
+	return getEnvBool("PASSWORD_REQUIRE_UPPER", false)
+}
+
+func GetPasswordRequireLower() bool {
+	return getEnvBool("PASSWORD_REQUIRE_LOWER", false)
+}
+
+func GetPasswordRequireDigit() bool {
+	return getEnvBool("PASSWORD_REQUIRE_DIGIT", false)
+}
+
+func GetPasswordRequireSymbol() bool {
+	return getEnvBool("PASSWORD_REQUIRE_SYMBOL", false)
+}
+
+// GetPasswordMinStrength returns the lowest accepted strength score, from 0
+// to 4.
+func GetPasswordMinStrength() int {
+	return getEnvInt("PASSWORD_MIN_STRENGTH", 2)
+}
+
+// GetPasswordHistory returns how many recent passwords cannot be reused.
+func GetPasswordHistory() int {
+	return getEnvInt("PASSWORD_HISTORY", 5)
+}
+
+// GetBreachedPasswordsFile returns the sorted SHA-1 hash file of breached
+// passwords. Passwords are not checked against breaches when it is empty.
+func GetBreachedPasswordsFile() string {
+	return os.Getenv("BREACHED_PASSWORDS_FILE")
+}
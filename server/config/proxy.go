@@ -0,0 +1,20 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// GetTrustedProxies returns the comma-separated IP addresses and CIDR
+// ranges of the reverse proxies whose X-Forwarded-For and X-Real-IP headers
+// name the client. Without any, the client IP is the address of the peer,
+// so clients cannot choose the IP lockouts and rate limits count them by.
+func GetTrustedProxies() []string {
+	var result []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			result = append(result, proxy)
+		}
+	}
+	return result
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+// Rate limit stores selectable with RATE_LIMIT_STORE.
+const (
+	RateLimitStoreMemory = "memory"
+	RateLimitStoreMongo  = "mongo"
+)
+
+// What a rate limit policy counts requests by.
+const (
+	RateLimitKeyIP     = "ip"     // client IP
+	RateLimitKeyUser   = "user"   // subject of the access token, else client IP
+	RateLimitKeyClient = "client" // verified TLS client certificate, else client IP
+)
+
+// RateLimitPolicy limits the requests to a set of routes. Routes are gin
+// route paths, optionally prefixed with a method ("POST /auth/login"). A
+// trailing "*" matches any path with that prefix. Requests are counted over
+// a sliding window.
+type RateLimitPolicy struct {
+	Name   string   `json:"name"`
+	Routes []string `json:"routes"`
+	Limit  int      `json:"limit"`
+	Window Duration `json:"window"`
+	Key    string   `json:"key"`
+}
+
+// Duration is a time.Duration written as a string such as "1m" in JSON.
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// defaultRateLimitPolicies are strict on the endpoints that check secrets or
+// send messages and looser on the rest of the API. The first policy matching
+// a request applies.
+var defaultRateLimitPolicies = []RateLimitPolicy{
+	{Name: "login", Routes: []string{"POST /auth/login", "POST /auth/login/mfa", "POST /auth/webauthn/finish", "POST /ui/auth/login", "POST /ui/auth/login/mfa"}, Limit: 10, Window: Duration(time.Minute), Key: RateLimitKeyIP},
+	{Name: "signup", Routes: []string{"POST /auth/signup", "POST /ui/auth/signup"}, Limit: 5, Window: Duration(time.Hour), Key: RateLimitKeyIP},
+	{Name: "auth", Routes: []string{"/auth/*", "POST /ui/auth/*"}, Limit: 30, Window: Duration(time.Minute), Key: RateLimitKeyIP},
+	{Name: "api", Routes: []string{"/users*", "/roles*", "/claims*", "/me/*", "/branding*", "/orgs*"}, Limit: 300, Window: Duration(time.Minute), Key: RateLimitKeyUser},
+}
+
+func GetRateLimitStore() string {
+	store := os.Getenv("RATE_LIMIT_STORE")
+	if store == "" {
+		store = RateLimitStoreMemory
+		log.Println("RATE_LIMIT_STORE not set, using default: memory")
+	}
+	return store
+}
+
+// GetRateLimitPolicies reads the policies from the JSON file named by
+// RATE_LIMIT_CONFIG, or returns the defaults when it is not set.
+func GetRateLimitPolicies() ([]RateLimitPolicy, error) {
+	path := os.Getenv("RATE_LIMIT_CONFIG")
+	if path == "" {
+		return defaultRateLimitPolicies, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rate limit config: %w", err)
+	}
+	var policies []RateLimitPolicy
+	if err := json.Unmarshal(data, &policies); err != nil {
+		return nil, fmt.Errorf("failed to parse rate limit config: %w", err)
+	}
+
+	for _, policy := range policies {
+		if policy.Name == "" || policy.Limit <= 0 || policy.Window <= 0 {
+			return nil, fmt.Errorf("rate limit policy %q needs a name, a positive limit and a window", policy.Name)
+		}
+		switch policy.Key {
+		case RateLimitKeyIP, RateLimitKeyUser, RateLimitKeyClient:
+		default:
+			return nil, fmt.Errorf("rate limit policy %q has unknown key %q", policy.Name, policy.Key)
+		}
+	}
+	return policies, nil
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+)
+
+const defaultJWTSecret = "your_secret_key"
+
+var (
+	jwtSecret     []byte
+	jwtSecretOnce sync.Once
+)
+
+// GetJWTSecret returns the HMAC key used to sign and verify tokens. It is read
+// once, on first use.
+func GetJWTSecret() []byte {
+	jwtSecretOnce.Do(func() {
+		secret := os.Getenv("JWT_SECRET")
+		if secret == "" {
+			log.Println("JWT_SECRET not set, using the insecure default secret")
+			secret = defaultJWTSecret
+		}
+		jwtSecret = []byte(secret)
+	})
+	return jwtSecret
+}
+
+// GetEncryptionKey returns the 32 byte AES key used to encrypt secrets at
+// rest, such as TOTP seeds. DATA_ENCRYPTION_KEY must be base64 encoded.
+func GetEncryptionKey() ([]byte, error) {
+	encoded := os.Getenv("DATA_ENCRYPTION_KEY")
+	if encoded == "" {
+		log.Println("DATA_ENCRYPTION_KEY not set, deriving an insecure key from the default secret")
+		key := sha256.Sum256([]byte(defaultJWTSecret))
+		return key[:], nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DATA_ENCRYPTION_KEY: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("DATA_ENCRYPTION_KEY must decode to 32 bytes")
+	}
+	return key, nil
+}
+
+func GetMFAIssuer() string {
+	issuer := os.Getenv("MFA_ISSUER")
+	if issuer == "" {
+		issuer = "Veritas"
+	}
+	return issuer
+}
+
+// GetRequireDPoP reports whether access tokens must be bound to a client key
+// with DPoP. Cookie logins are still allowed, as browsers cannot sign proofs.
+func GetRequireDPoP() bool {
+	return getEnvBool("REQUIRE_DPOP", false)
+}
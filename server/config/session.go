@@ -0,0 +1,73 @@
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// GetSessionIdleTimeout returns how long a session may go unused before it
+// ends. Zero disables the idle timeout.
+func GetSessionIdleTimeout() time.Duration {
+	return getEnvDuration("SESSION_IDLE_TIMEOUT", 8*time.Hour)
+}
+
+// GetSessionAbsoluteTimeout returns how long a session lasts after login,
+// however much it is used.
+func GetSessionAbsoluteTimeout() time.Duration {
+	return getEnvDuration("SESSION_ABSOLUTE_TIMEOUT", 24*time.Hour)
+}
+
+// GetMaxSessions returns how many sessions a user may have at once. The
+// least recently used session ends when another one starts beyond it. Zero
+// is unlimited.
+func GetMaxSessions() int {
+	return getEnvInt("SESSION_MAX_PER_USER", 0)
+}
+
+// SessionCookieConfig describes the cookies set for browser sessions: an
+// HttpOnly cookie holding the access token and a readable one holding the
+// CSRF token for the page's scripts to echo back.
+type SessionCookieConfig struct {
+	Name     string
+	CSRFName string
+	Domain   string
+	Path     string
+	// MaxAge caps the lifetime of the cookies. Zero lets them expire with
+	// the access token.
+	MaxAge   time.Duration
+	Secure   bool
+	SameSite http.SameSite
+}
+
+// GetSessionCookieConfig reads the SESSION_COOKIE_* variables.
+func GetSessionCookieConfig() (SessionCookieConfig, error) {
+	cookie := SessionCookieConfig{
+		Name:     getEnvString("SESSION_COOKIE_NAME", "veritas_session"),
+		CSRFName: getEnvString("SESSION_COOKIE_CSRF_NAME", "veritas_csrf"),
+		Domain:   os.Getenv("SESSION_COOKIE_DOMAIN"),
+		Path:     getEnvString("SESSION_COOKIE_PATH", "/"),
+		MaxAge:   getEnvDuration("SESSION_COOKIE_MAX_AGE", 0),
+		Secure:   getEnvBool("SESSION_COOKIE_SECURE", true),
+	}
+	if cookie.Name == cookie.CSRFName {
+		return SessionCookieConfig{}, fmt.Errorf("SESSION_COOKIE_NAME and SESSION_COOKIE_CSRF_NAME must differ")
+	}
+
+	switch sameSite := strings.ToLower(getEnvString("SESSION_COOKIE_SAMESITE", "lax")); sameSite {
+	case "lax":
+		cookie.SameSite = http.SameSiteLaxMode
+	case "strict":
+		cookie.SameSite = http.SameSiteStrictMode
+	case "none":
+		if !cookie.Secure {
+			return SessionCookieConfig{}, fmt.Errorf("SESSION_COOKIE_SAMESITE=none requires SESSION_COOKIE_SECURE")
+		}
+		cookie.SameSite = http.SameSiteNoneMode
+	default:
+		return SessionCookieConfig{}, fmt.Errorf("unknown SESSION_COOKIE_SAMESITE %q", sameSite)
+	}
+	return cookie, nil
+}
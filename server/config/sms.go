@@ -0,0 +1,41 @@
+package config
+
+import (
+	"log"
+	"os"
+)
+
+// SMS adapters selectable with SMS_SENDER.
+const (
+	SMSSenderLog     = "log"
+	SMSSenderWebhook = "webhook"
+)
+
+func GetSMSSenderType() string {
+	sender := os.Getenv("SMS_SENDER")
+	if sender == "" {
+		sender = SMSSenderLog
+		log.Println("SMS_SENDER not set, using default: log")
+	}
+	return sender
+}
+
+// GetSMSLogFile returns the file the log sender appends messages to. Messages
+// are written to the application log when it is empty.
+func GetSMSLogFile() string {
+	return os.Getenv("SMS_LOG_FILE")
+}
+
+func GetSMSWebhookURL() string {
+	return os.Getenv("SMS_WEBHOOK_URL")
+}
+
+func GetSMSWebhookToken() string {
+	return os.Getenv("SMS_WEBHOOK_TOKEN")
+}
+
+// GetSMSRateLimit returns how many messages a phone number may receive per
+// hour.
+func GetSMSRateLimit() int {
+	return getEnvInt("SMS_RATE_LIMIT_PER_HOUR", 5)
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+// GetTLSConfig builds the configuration for the optional TLS listener. It
+// returns nil when TLS_CERT_FILE and TLS_KEY_FILE are not set. When
+// TLS_CLIENT_CA_FILES lists one or more PEM bundles, clients may present a
+// certificate issued by those CAs for mutual TLS.
+func GetTLSConfig() (*tls.Config, error) {
+	certFile := os.Getenv("TLS_CERT_FILE")
+	keyFile := os.Getenv("TLS_KEY_FILE")
+	if certFile == "" || keyFile == "" {
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tls certificate: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	caFiles := os.Getenv("TLS_CLIENT_CA_FILES")
+	if caFiles == "" {
+		log.Println("TLS_CLIENT_CA_FILES not set, client certificates will not be requested")
+		return tlsConfig, nil
+	}
+
+	pool := x509.NewCertPool()
+	for _, caFile := range strings.Split(caFiles, ",") {
+		pem, err := os.ReadFile(strings.TrimSpace(caFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client ca bundle: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in client ca bundle %s", caFile)
+		}
+	}
+	tlsConfig.ClientCAs = pool
+	tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+
+	return tlsConfig, nil
+}
+
+func GetTLSPort() string {
+	port := os.Getenv("TLS_PORT")
+	if port == "" {
+		port = "8443"
+	}
+	return port
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strings"
+)
+
+// GetWebAuthnRPID returns the relying party ID passkeys are scoped to, usually
+// the registrable domain of the web app.
+func GetWebAuthnRPID() string {
+	rpID := os.Getenv("WEBAUTHN_RP_ID")
+	if rpID == "" {
+		rpID = "localhost"
+		log.Println("WEBAUTHN_RP_ID not set, using default: localhost")
+	}
+	return rpID
+}
+
+func GetWebAuthnRPName() string {
+	rpName := os.Getenv("WEBAUTHN_RP_NAME")
+	if rpName == "" {
+		rpName = "Veritas"
+	}
+	return rpName
+}
+
+// GetWebAuthnOrigins returns the comma-separated origins allowed to run
+// WebAuthn ceremonies.
+func GetWebAuthnOrigins() []string {
+	origins := os.Getenv("WEBAUTHN_ORIGINS")
+	if origins == "" {
+		origins = "http://localhost:8080"
+		log.Println("WEBAUTHN_ORIGINS not set, using default: http://localhost:8080")
+	}
+
+	var result []string
+	for _, origin := range strings.Split(origins, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			result = append(result, origin)
+		}
+	}
+	return result
+}
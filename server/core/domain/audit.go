@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Audit categories group actions for filtering and export.
+const (
+	AuditCategoryAuthentication = "authentication" // logins, second factors and lockouts
+	AuditCategoryAccount        = "account"        // users managing their own account
+	AuditCategoryAdmin          = "admin"          // changes to users, roles and claims
+)
+
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeFailure = "failure"
+)
+
+// AuditEntry records who did what to which target. Entries form a hash
+// chain: each Hash covers the entry and the Hash of the entry before it, so
+// a changed or removed entry breaks the chain from there on. TenantID is the
+// organization the entry belongs to; entries recorded before organizations
+// existed have none.
+type AuditEntry struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Sequence   int64              `bson:"sequence" json:"sequence"`
+	TenantID   primitive.ObjectID `bson:"tenantId,omitempty" json:"tenantId,omitempty"`
+	Time       time.Time          `bson:"time" json:"time"`
+	Category   string             `bson:"category" json:"category"`
+	Action     string             `bson:"action" json:"action"`
+	Outcome    string             `bson:"outcome" json:"outcome"`
+	Reason     string             `bson:"reason" json:"reason,omitempty"`
+	ActorID    string             `bson:"actorId" json:"actorId,omitempty"`
+	TargetType string             `bson:"targetType" json:"targetType,omitempty"`
+	TargetID   string             `bson:"targetId" json:"targetId,omitempty"`
+	Changes    []AuditChange      `bson:"changes" json:"changes,omitempty"`
+	IP         string             `bson:"ip" json:"ip,omitempty"`
+	UserAgent  string             `bson:"userAgent" json:"userAgent,omitempty"`
+	RequestID  string             `bson:"requestId" json:"requestId,omitempty"`
+	PrevHash   string             `bson:"prevHash" json:"prevHash"`
+	Hash       string             `bson:"hash" json:"hash"`
+}
+
+// AuditChange is a field changed by an audited action. Secret values are
+// recorded as changed without their content.
+type AuditChange struct {
+	Field  string `bson:"field" json:"field"`
+	Before string `bson:"before" json:"before"`
+	After  string `bson:"after" json:"after"`
+}
+
+// hashedAuditEntry is the content an entry's hash covers. The organization
+// is hashed as its hex ID and left out when unset, so entries recorded
+// before organizations existed keep their hashes.
+type hashedAuditEntry struct {
+	AuditEntry
+	TenantID string `json:"tenantId,omitempty"`
+}
+
+// ComputeHash returns the chain hash of the entry: the hex SHA-256 of its
+// content, including PrevHash, without ID and Hash.
+func (e *AuditEntry) ComputeHash() string {
+	content := hashedAuditEntry{AuditEntry: *e}
+	content.ID = primitive.NilObjectID
+	content.Hash = ""
+	content.Time = e.Time.UTC()
+	if len(content.Changes) == 0 {
+		content.Changes = nil
+	}
+	if !e.TenantID.IsZero() {
+		content.TenantID = e.TenantID.Hex()
+	}
+	data, _ := json.Marshal(content)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// AuditFilter selects audit entries. Empty fields match every entry.
+type AuditFilter struct {
+	ActorID    string
+	Action     string
+	Category   string
+	Outcome    string
+	TargetType string
+	TargetID   string
+	From       time.Time
+	To         time.Time
+}
@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// AuditExportCheckpoint is the sequence number of the last audit entry an
+// exporter delivered. Export resumes after it.
+type AuditExportCheckpoint struct {
+	Exporter  string    `bson:"_id" json:"exporter"`
+	Sequence  int64     `bson:"sequence" json:"sequence"`
+	UpdatedAt time.Time `bson:"updatedAt" json:"updatedAt"`
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// DefaultBrandingID identifies the branding of tenants without their own.
+const DefaultBrandingID = "default"
+
+// Branding themes the hosted pages of a tenant. Copy overrides the text of
+// the pages by key, such as "login.title".
+type Branding struct {
+	ID              string            `bson:"_id" json:"id"`
+	ProductName     string            `bson:"productName" json:"productName"`
+	LogoURL         string            `bson:"logoUrl" json:"logoUrl"`
+	PrimaryColor    string            `bson:"primaryColor" json:"primaryColor"`
+	BackgroundColor string            `bson:"backgroundColor" json:"backgroundColor"`
+	TextColor       string            `bson:"textColor" json:"textColor"`
+	Copy            map[string]string `bson:"copy" json:"copy"`
+	UpdatedAt       time.Time         `bson:"updatedAt" json:"updatedAt"`
+}
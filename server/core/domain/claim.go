@@ -8,6 +8,7 @@ import (
 
 type Claim struct {
 	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	TenantID    primitive.ObjectID `bson:"tenantId" json:"tenantId"`
 	Name        string             `bson:"name" json:"name"`
 	Description string             `bson:"description" json:"description"`
 	CreatedAt   time.Time          `bson:"createdAt" json:"createdAt"`
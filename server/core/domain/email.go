@@ -0,0 +1,9 @@
+package domain
+
+// EmailMessage is a message handed to an EmailSender.
+type EmailMessage struct {
+	To       string
+	Subject  string
+	TextBody string
+	HTMLBody string
+}
@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// Event types published through the EventPublisher.
+const (
+	EventAccountLocked = "account.locked"
+	EventIPLocked      = "ip.locked"
+)
+
+// Event is a notable security event, such as an account being locked.
+type Event struct {
+	Type   string            `bson:"type" json:"type"`
+	Time   time.Time         `bson:"time" json:"time"`
+	UserID string            `bson:"userId,omitempty" json:"userId,omitempty"`
+	Data   map[string]string `bson:"data,omitempty" json:"data,omitempty"`
+}
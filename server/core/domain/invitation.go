@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Invitation asks someone to join an organization with the given roles. It
+// is pending until accepted, revoked or expired, and is deleted by the
+// first two.
+type Invitation struct {
+	ID       primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	TenantID primitive.ObjectID `bson:"tenantId" json:"tenantId"`
+	Email    string             `bson:"email" json:"email"`
+
+	// RoleIDs are roles of the organization given to the invitee, and Admin
+	// makes them one of its admins.
+	RoleIDs []primitive.ObjectID `bson:"roleIds" json:"roleIds"`
+	Admin   bool                 `bson:"admin" json:"admin"`
+
+	InvitedBy primitive.ObjectID `bson:"invitedBy" json:"invitedBy"`
+	ExpiresAt time.Time          `bson:"expiresAt" json:"expiresAt"`
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+}
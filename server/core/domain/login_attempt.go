@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// LoginAttempt tracks failed logins for an account or a source IP.
+type LoginAttempt struct {
+	Key           string    `bson:"key" json:"key"`
+	Failures      int       `bson:"failures" json:"failures"`
+	LastFailureAt time.Time `bson:"lastFailureAt" json:"lastFailureAt"`
+	LockedUntil   time.Time `bson:"lockedUntil,omitempty" json:"lockedUntil,omitempty"`
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Purposes a one-time token can be issued for.
+const (
+	TokenPurposePasswordReset = "password_reset"
+	TokenPurposeMagicLink     = "magic_link"
+	TokenPurposeEmailOTP      = "email_otp"
+	TokenPurposeSMSLogin      = "sms_login"
+	TokenPurposePhoneVerify   = "phone_verification"
+)
+
+// OneTimeToken is a secret sent to a user out of band. Only its hash is
+// stored, and it is deleted by the first use.
+type OneTimeToken struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	UserID    primitive.ObjectID `bson:"userId" json:"userId"`
+	Purpose   string             `bson:"purpose" json:"purpose"`
+	TokenHash string             `bson:"tokenHash" json:"-"` // SHA-256
+
+	// BindingHash ties the token to the browser or device that requested it.
+	// The token is only accepted together with the matching request ID.
+	BindingHash string `bson:"bindingHash,omitempty" json:"-"`
+	Attempts    int    `bson:"attempts" json:"attempts"`
+
+	ExpiresAt time.Time `bson:"expiresAt" json:"expiresAt"`
+	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DefaultOrganizationSlug names the organization that owns the data created
+// before organizations existed, and serves requests that do not name one.
+// Its admins administer every organization.
+const DefaultOrganizationSlug = "default"
+
+// Organization is a tenant. Users, roles and claims belong to exactly one,
+// and are only visible to requests made in it.
+type Organization struct {
+	ID   primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Name string             `bson:"name" json:"name"`
+	Slug string             `bson:"slug" json:"slug"` // unique, such as "acme"
+
+	// Domains are the hosts the organization's hosted pages are served on,
+	// such as "login.acme.example".
+	Domains []string `bson:"domains,omitempty" json:"domains"`
+
+	// AdminIDs are the members allowed to manage the organization.
+	AdminIDs []primitive.ObjectID `bson:"adminIds" json:"adminIds"`
+
+	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+// IsDefault reports whether o is the default organization.
+func (o *Organization) IsDefault() bool {
+	return o.Slug == DefaultOrganizationSlug
+}
+
+// IsAdmin reports whether the user is an admin of o.
+func (o *Organization) IsAdmin(userID primitive.ObjectID) bool {
+	for _, id := range o.AdminIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// BrandingID returns the ID of the branding the organization's hosted pages
+// are rendered with. The default organization's branding is the base every
+// other organization's is layered on.
+func (o *Organization) BrandingID() string {
+	if o.IsDefault() {
+		return DefaultBrandingID
+	}
+	return o.ID.Hex()
+}
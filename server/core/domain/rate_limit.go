@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// RateLimitCounter counts hits for a key within one fixed window.
+// PreviousCount is the count of the window before it, which lets callers
+// approximate a sliding window.
+type RateLimitCounter struct {
+	Key           string    `bson:"key" json:"key"`
+	Count         int       `bson:"count" json:"count"`
+	PreviousCount int       `bson:"-" json:"previousCount"`
+	ResetAt       time.Time `bson:"resetAt" json:"resetAt"`
+}
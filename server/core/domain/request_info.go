@@ -0,0 +1,26 @@
+package domain
+
+import "context"
+
+// RequestInfo describes the request an action is taken for. ActorID is
+// filled in once the request is authenticated.
+type RequestInfo struct {
+	ActorID   string
+	IP        string
+	UserAgent string
+	RequestID string
+}
+
+type requestInfoKey struct{}
+
+// WithRequestInfo returns a context carrying info.
+func WithRequestInfo(ctx context.Context, info *RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// RequestInfoFromContext returns the RequestInfo of ctx, or nil outside a
+// request.
+func RequestInfoFromContext(ctx context.Context) *RequestInfo {
+	info, _ := ctx.Value(requestInfoKey{}).(*RequestInfo)
+	return info
+}
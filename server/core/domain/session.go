@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Session is a signed-in device. Access tokens carry its ID in the sid
+// claim and stop working once it ends.
+type Session struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	UserID      primitive.ObjectID `bson:"userId" json:"userId"`
+	Device      string             `bson:"device" json:"device"` // such as "Firefox on Windows", derived from the user agent
+	UserAgent   string             `bson:"userAgent" json:"userAgent"`
+	IP          string             `bson:"ip" json:"ip"` // as of the last request
+	AuthMethods []string           `bson:"authMethods" json:"authMethods"`
+	CreatedAt   time.Time          `bson:"createdAt" json:"createdAt"`
+	LastSeenAt  time.Time          `bson:"lastSeenAt" json:"lastSeenAt"`
+	ExpiresAt   time.Time          `bson:"expiresAt" json:"expiresAt"` // absolute timeout
+}
@@ -0,0 +1,7 @@
+package domain
+
+// SMSMessage is a text message handed to an SMSSender.
+type SMSMessage struct {
+	To   string // E.164
+	Body string
+}
@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type tenantKey struct{}
+
+// WithTenant returns a context scoped to the organization with the given ID.
+// Repositories of tenant-owned data only read and write that organization's
+// records.
+func WithTenant(ctx context.Context, tenantID primitive.ObjectID) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantID)
+}
+
+// TenantFromContext returns the organization ctx is scoped to, and false
+// when it is not scoped to one.
+func TenantFromContext(ctx context.Context) (primitive.ObjectID, bool) {
+	tenantID, ok := ctx.Value(tenantKey{}).(primitive.ObjectID)
+	return tenantID, ok && !tenantID.IsZero()
+}
@@ -8,9 +8,39 @@ import (
 
 type User struct {
 	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	TenantID  primitive.ObjectID `bson:"tenantId" json:"tenantId"`
 	Username  string             `bson:"username" json:"username"`
 	Email     string             `bson:"email" json:"email"`
-	Password  string             `bson:"password" json:"password,omitempty"` // In a real app, hash and salt this!
+	Password  string             `bson:"password" json:"-"` // bcrypt hash
 	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
 	UpdatedAt time.Time          `bson:"updatedAt" json:"updatedAt"`
+
+	// RoleIDs are the roles of the user's organization assigned to them.
+	RoleIDs []primitive.ObjectID `bson:"roleIds" json:"roleIds"`
+
+	// PasswordHistory holds bcrypt hashes of earlier passwords, most recent
+	// first, so they cannot be reused.
+	PasswordHistory []string `bson:"passwordHistory" json:"-"`
+
+	Status        UserStatus         `bson:"status" json:"status"`
+	StatusHistory []UserStatusChange `bson:"statusHistory" json:"-"`
+
+	EmailVerified      bool      `bson:"emailVerified" json:"emailVerified"`
+	VerificationSentAt time.Time `bson:"verificationSentAt" json:"-"`
+
+	// PhoneNumber is in E.164 format. Once verified it can receive one-time
+	// codes as a second factor.
+	PhoneNumber   string `bson:"phoneNumber" json:"phoneNumber,omitempty"`
+	PhoneVerified bool   `bson:"phoneVerified" json:"phoneVerified"`
+
+	// TokensRevokedAt invalidates every token issued at or before it, for
+	// example after a password reset.
+	TokensRevokedAt time.Time `bson:"tokensRevokedAt" json:"-"`
+
+	// TOTPSecret is encrypted at rest. It is set during enrollment and only
+	// used for login once TOTPEnabled is true.
+	TOTPSecret       string   `bson:"totpSecret" json:"-"`
+	TOTPEnabled      bool     `bson:"totpEnabled" json:"totpEnabled"`
+	TOTPLastUsedStep int64    `bson:"totpLastUsedStep" json:"-"`
+	RecoveryCodes    []string `bson:"recoveryCodes" json:"-"` // SHA-256 hashes of unused codes
 }
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+type UserStatus string
+
+const (
+	// UserStatusPending accounts have not verified their email address yet.
+	UserStatusPending UserStatus = "pending"
+	UserStatusActive  UserStatus = "active"
+	// UserStatusSuspended accounts are barred by an administrator until they
+	// are reactivated.
+	UserStatusSuspended UserStatus = "suspended"
+	// UserStatusDisabled accounts are closed.
+	UserStatusDisabled UserStatus = "disabled"
+	// UserStatusLocked accounts are barred for security reasons, for example
+	// a suspected compromise.
+	UserStatusLocked UserStatus = "locked"
+)
+
+// UserStatusChange records a change of a user's status. ActorID is empty
+// for changes made by the system.
+type UserStatusChange struct {
+	From      UserStatus `bson:"from" json:"from"`
+	To        UserStatus `bson:"to" json:"to"`
+	Reason    string     `bson:"reason" json:"reason"`
+	ActorID   string     `bson:"actorId" json:"actorId,omitempty"`
+	ChangedAt time.Time  `bson:"changedAt" json:"changedAt"`
+}
+
+// CurrentStatus returns the user's status. Users created before statuses
+// were introduced are active.
+func (u *User) CurrentStatus() UserStatus {
+	if u.Status == "" {
+		return UserStatusActive
+	}
+	return u.Status
+}
+
+// CanSignIn reports whether the user may log in and use tokens issued to
+// them. Pending users are held back by email verification instead, when it
+// is required.
+func (u *User) CanSignIn() bool {
+	status := u.CurrentStatus()
+	return status == UserStatusActive || status == UserStatusPending
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// WebAuthn ceremonies a challenge can be issued for.
+const (
+	WebAuthnCeremonyRegistration   = "registration"
+	WebAuthnCeremonyAuthentication = "authentication"
+)
+
+// WebAuthnCredential is a passkey or security key registered by a user.
+type WebAuthnCredential struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	UserID         primitive.ObjectID `bson:"userId" json:"userId"`
+	Name           string             `bson:"name" json:"name"`
+	CredentialID   []byte             `bson:"credentialId" json:"credentialId"`
+	PublicKey      []byte             `bson:"publicKey" json:"-"` // COSE_Key
+	AAGUID         []byte             `bson:"aaguid" json:"aaguid"`
+	SignCount      uint32             `bson:"signCount" json:"signCount"`
+	Transports     []string           `bson:"transports" json:"transports"`
+	BackupEligible bool               `bson:"backupEligible" json:"backupEligible"`
+	BackupState    bool               `bson:"backupState" json:"backupState"`
+	CreatedAt      time.Time          `bson:"createdAt" json:"createdAt"`
+	LastUsedAt     time.Time          `bson:"lastUsedAt,omitempty" json:"lastUsedAt,omitempty"`
+}
+
+// WebAuthnChallenge is a pending registration or authentication ceremony. It
+// is consumed by the first response that references it.
+type WebAuthnChallenge struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Challenge string             `bson:"challenge" json:"challenge"` // base64url, as echoed in clientDataJSON
+	Ceremony  string             `bson:"ceremony" json:"ceremony"`
+	UserID    primitive.ObjectID `bson:"userId,omitempty" json:"userId,omitempty"` // zero for discoverable logins
+	RequireUV bool               `bson:"requireUv" json:"requireUv"`
+	ExpiresAt time.Time          `bson:"expiresAt" json:"expiresAt"`
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+}
@@ -0,0 +1,116 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+	"veritas/core/domain"
+	"veritas/internal/ports/output"
+)
+
+const defaultAuditExportBatchSize = 100
+
+// AuditExportTarget is an exporter and the audit categories it receives.
+// An empty Categories receives every entry.
+type AuditExportTarget struct {
+	Name       string
+	Categories []string
+	Exporter   output.AuditExporter
+}
+
+type AuditExportUsecase struct {
+	store       output.AuditOutputPort
+	checkpoints output.AuditCheckpointOutputPort
+	targets     []AuditExportTarget
+	batchSize   int
+}
+
+func NewAuditExportUsecase(store output.AuditOutputPort, checkpoints output.AuditCheckpointOutputPort, targets []AuditExportTarget) *AuditExportUsecase {
+	return &AuditExportUsecase{store: store, checkpoints: checkpoints, targets: targets, batchSize: defaultAuditExportBatchSize}
+}
+
+// Run exports new entries every interval until ctx is cancelled, then
+// closes the exporters.
+func (uc *AuditExportUsecase) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	defer uc.close()
+
+	for {
+		if err := uc.ExportPending(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("audit export: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ExportPending sends every entry after each target's checkpoint to it. The
+// checkpoint only advances once the exporter accepted a batch, so entries
+// are delivered at least once: a batch that fails, or whose checkpoint could
+// not be saved, is sent again. A failing target does not hold up the others.
+func (uc *AuditExportUsecase) ExportPending(ctx context.Context) error {
+	var errs []error
+	for _, target := range uc.targets {
+		if err := uc.exportTarget(ctx, target); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", target.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (uc *AuditExportUsecase) exportTarget(ctx context.Context, target AuditExportTarget) error {
+	checkpoint, err := uc.checkpoints.GetCheckpoint(ctx, target.Name)
+	if err != nil {
+		return err
+	}
+	if checkpoint == nil {
+		checkpoint = &domain.AuditExportCheckpoint{Exporter: target.Name}
+	}
+
+	var batch []*domain.AuditEntry
+	last := checkpoint.Sequence
+	flush := func() error {
+		if len(batch) > 0 {
+			if err := target.Exporter.Export(ctx, batch); err != nil {
+				return err
+			}
+			batch = nil
+		}
+		if last == checkpoint.Sequence {
+			return nil
+		}
+		checkpoint.Sequence = last
+		checkpoint.UpdatedAt = time.Now()
+		return uc.checkpoints.SaveCheckpoint(ctx, checkpoint)
+	}
+
+	err = uc.store.ScanEntries(ctx, checkpoint.Sequence+1, func(entry *domain.AuditEntry) error {
+		if len(target.Categories) == 0 || slices.Contains(target.Categories, entry.Category) {
+			batch = append(batch, entry)
+		}
+		last = entry.Sequence
+		if len(batch) >= uc.batchSize {
+			return flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return flush()
+}
+
+func (uc *AuditExportUsecase) close() {
+	for _, target := range uc.targets {
+		if err := target.Exporter.Close(); err != nil {
+			log.Printf("audit export: failed to close %s: %v", target.Name, err)
+		}
+	}
+}
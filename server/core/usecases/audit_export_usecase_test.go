@@ -0,0 +1,126 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"veritas/core/domain"
+	"veritas/core/usecases"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeAuditCheckpoints keeps export checkpoints in memory.
+type fakeAuditCheckpoints struct {
+	checkpoints map[string]domain.AuditExportCheckpoint
+}
+
+func (f *fakeAuditCheckpoints) GetCheckpoint(ctx context.Context, exporter string) (*domain.AuditExportCheckpoint, error) {
+	checkpoint, ok := f.checkpoints[exporter]
+	if !ok {
+		return nil, nil
+	}
+	return &checkpoint, nil
+}
+
+func (f *fakeAuditCheckpoints) SaveCheckpoint(ctx context.Context, checkpoint *domain.AuditExportCheckpoint) error {
+	f.checkpoints[checkpoint.Exporter] = *checkpoint
+	return nil
+}
+
+// fakeAuditExporter collects the batches it is given, failing while err is
+// set.
+type fakeAuditExporter struct {
+	batches [][]int64
+	err     error
+}
+
+func (f *fakeAuditExporter) Export(ctx context.Context, entries []*domain.AuditEntry) error {
+	if f.err != nil {
+		return f.err
+	}
+	var sequences []int64
+	for _, entry := range entries {
+		sequences = append(sequences, entry.Sequence)
+	}
+	f.batches = append(f.batches, sequences)
+	return nil
+}
+
+func (f *fakeAuditExporter) Close() error {
+	return nil
+}
+
+type AuditExportUseCaseTestSuite struct {
+	suite.Suite
+	log         *fakeAuditLog
+	checkpoints *fakeAuditCheckpoints
+	auth        *fakeAuditExporter
+	all         *fakeAuditExporter
+	useCase     *usecases.AuditExportUsecase
+	ctx         context.Context
+}
+
+func (s *AuditExportUseCaseTestSuite) SetupTest() {
+	s.log = &fakeAuditLog{}
+	s.checkpoints = &fakeAuditCheckpoints{checkpoints: map[string]domain.AuditExportCheckpoint{}}
+	s.auth = &fakeAuditExporter{}
+	s.all = &fakeAuditExporter{}
+	s.useCase = usecases.NewAuditExportUsecase(s.log, s.checkpoints, []usecases.AuditExportTarget{
+		{Name: "soc", Categories: []string{domain.AuditCategoryAuthentication}, Exporter: s.auth},
+		{Name: "archive", Exporter: s.all},
+	})
+	s.ctx = context.Background()
+}
+
+func (s *AuditExportUseCaseTestSuite) record(categories ...string) {
+	for _, category := range categories {
+		s.Require().NoError(s.log.Record(s.ctx, &domain.AuditEntry{Category: category, Action: "test"}))
+	}
+}
+
+func (s *AuditExportUseCaseTestSuite) TestExportPending() {
+	s.record(domain.AuditCategoryAuthentication, domain.AuditCategoryAdmin, domain.AuditCategoryAuthentication, domain.AuditCategoryAccount)
+
+	// Test case 1: Each target receives its categories and is checkpointed at the last entry
+	s.NoError(s.useCase.ExportPending(s.ctx))
+	s.Equal([][]int64{{1, 3}}, s.auth.batches)
+	s.Equal([][]int64{{1, 2, 3, 4}}, s.all.batches)
+	s.Equal(int64(4), s.checkpoints.checkpoints["soc"].Sequence)
+	s.Equal(int64(4), s.checkpoints.checkpoints["archive"].Sequence)
+
+	// Test case 2: Nothing is sent again without new entries
+	s.NoError(s.useCase.ExportPending(s.ctx))
+	s.Len(s.auth.batches, 1)
+	s.Len(s.all.batches, 1)
+
+	// Test case 3: A failing target keeps its checkpoint and does not hold up the others
+	s.record(domain.AuditCategoryAuthentication)
+	s.auth.err = errors.New("connection refused")
+	s.ErrorContains(s.useCase.ExportPending(s.ctx), "soc: connection refused")
+	s.Equal(int64(4), s.checkpoints.checkpoints["soc"].Sequence)
+	s.Equal([]int64{5}, s.all.batches[1])
+
+	// Test case 4: Entries that failed are delivered once the target recovers
+	s.auth.err = nil
+	s.NoError(s.useCase.ExportPending(s.ctx))
+	s.Equal([]int64{5}, s.auth.batches[1])
+	s.Equal(int64(5), s.checkpoints.checkpoints["soc"].Sequence)
+}
+
+func (s *AuditExportUseCaseTestSuite) TestExportBatches() {
+	for range 150 {
+		s.record(domain.AuditCategoryAuthentication)
+	}
+
+	// Test case 1: Large backlogs are sent in batches
+	s.NoError(s.useCase.ExportPending(s.ctx))
+	s.Require().Len(s.all.batches, 2)
+	s.Len(s.all.batches[0], 100)
+	s.Len(s.all.batches[1], 50)
+	s.Equal(int64(150), s.checkpoints.checkpoints["archive"].Sequence)
+}
+
+func TestAuditExportUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(AuditExportUseCaseTestSuite))
+}
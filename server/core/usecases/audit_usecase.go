@@ -0,0 +1,153 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+	"veritas/core/domain"
+	"veritas/internal/ports/output"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultAuditPageSize = 50
+	maxAuditPageSize     = 500
+)
+
+// errChainBroken stops a chain scan at the first broken entry.
+var errChainBroken = errors.New("audit chain broken")
+
+type AuditUsecase struct {
+	store output.AuditOutputPort
+}
+
+func NewAuditUsecase(store output.AuditOutputPort) *AuditUsecase {
+	return &AuditUsecase{store: store}
+}
+
+// AuditPage is one page of audit entries, newest first.
+type AuditPage struct {
+	Entries  []*domain.AuditEntry
+	Page     int
+	PageSize int
+	Total    int64
+}
+
+// ListEntries returns the page of entries matching filter. Pages start at 1.
+func (uc *AuditUsecase) ListEntries(ctx context.Context, filter domain.AuditFilter, page, pageSize int) (*AuditPage, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultAuditPageSize
+	}
+	pageSize = min(pageSize, maxAuditPageSize)
+
+	entries, total, err := uc.store.FindEntries(ctx, filter, int64((page-1)*pageSize), int64(pageSize))
+	if err != nil {
+		return nil, err
+	}
+	return &AuditPage{Entries: entries, Page: page, PageSize: pageSize, Total: total}, nil
+}
+
+// AuditVerification is the result of checking the audit hash chain.
+// BrokenAt is the sequence number of the first entry that does not fit the
+// chain, or zero when the chain is intact. LastHash is the hash of the last
+// intact entry.
+type AuditVerification struct {
+	Checked  int64
+	BrokenAt int64
+	Problem  string
+	LastHash string
+}
+
+// VerifyChain recomputes the hash of every entry and checks that each links
+// to the one before it, so changed, inserted and removed entries are found.
+// Entries removed from the end of the log cannot be detected this way; the
+// last hash has to be compared with a copy kept elsewhere, such as an export.
+func (uc *AuditUsecase) VerifyChain(ctx context.Context) (*AuditVerification, error) {
+	result := &AuditVerification{}
+	var previous *domain.AuditEntry
+
+	err := uc.store.ScanEntries(ctx, 1, func(entry *domain.AuditEntry) error {
+		var problem string
+		switch {
+		case previous == nil && (entry.Sequence != 1 || entry.PrevHash != ""):
+			problem = "log does not start at the first entry"
+		case previous != nil && entry.Sequence != previous.Sequence+1:
+			problem = fmt.Sprintf("entries %d to %d are missing", previous.Sequence+1, entry.Sequence-1)
+		case previous != nil && entry.PrevHash != previous.Hash:
+			problem = "previous hash does not match"
+		case entry.ComputeHash() != entry.Hash:
+			problem = "hash does not match content"
+		}
+		if problem != "" {
+			result.BrokenAt = entry.Sequence
+			result.Problem = problem
+			return errChainBroken
+		}
+
+		result.Checked++
+		result.LastHash = entry.Hash
+		previous = entry
+		return nil
+	})
+	if err != nil && !errors.Is(err, errChainBroken) {
+		return nil, err
+	}
+	return result, nil
+}
+
+// recordAudit completes entry with the time, the outcome, and the
+// organization and details of the request in ctx, and appends it to the
+// audit log. A failure to write is logged rather than failing the audited
+// action.
+func recordAudit(ctx context.Context, logger output.AuditLogger, entry *domain.AuditEntry) {
+	entry.Time = time.Now()
+	if entry.Outcome == "" {
+		entry.Outcome = domain.AuditOutcomeSuccess
+	}
+	if tenantID, ok := domain.TenantFromContext(ctx); ok {
+		entry.TenantID = tenantID
+	}
+	if info := domain.RequestInfoFromContext(ctx); info != nil {
+		if entry.ActorID == "" {
+			entry.ActorID = info.ActorID
+		}
+		entry.IP = info.IP
+		entry.UserAgent = info.UserAgent
+		entry.RequestID = info.RequestID
+	}
+
+	if err := logger.Record(ctx, entry); err != nil {
+		log.Printf("failed to record audit entry %s: %v", entry.Action, err)
+	}
+}
+
+// userAuditEntry returns an entry for an action on a user's account.
+func userAuditEntry(category, action string, userID primitive.ObjectID) *domain.AuditEntry {
+	return &domain.AuditEntry{Category: category, Action: action, TargetType: "user", TargetID: userID.Hex()}
+}
+
+// failed marks entry as the record of a failed attempt.
+func failed(entry *domain.AuditEntry, reason string) *domain.AuditEntry {
+	entry.Outcome = domain.AuditOutcomeFailure
+	entry.Reason = reason
+	return entry
+}
+
+// appendChange adds a change of field to changes when the values differ.
+func appendChange(changes []domain.AuditChange, field, before, after string) []domain.AuditChange {
+	if before == after {
+		return changes
+	}
+	return append(changes, domain.AuditChange{Field: field, Before: before, After: after})
+}
+
+// secretChange records that a secret field changed without its value.
+func secretChange(field string) domain.AuditChange {
+	return domain.AuditChange{Field: field, Before: "[redacted]", After: "[redacted]"}
+}
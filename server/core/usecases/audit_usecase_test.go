@@ -0,0 +1,139 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"veritas/core/domain"
+	"veritas/core/usecases"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeAuditLog keeps a hash-chained audit log in memory, oldest first.
+type fakeAuditLog struct {
+	entries []*domain.AuditEntry
+}
+
+func (f *fakeAuditLog) Record(ctx context.Context, entry *domain.AuditEntry) error {
+	entry.Sequence = int64(len(f.entries)) + 1
+	entry.PrevHash = ""
+	if len(f.entries) > 0 {
+		entry.PrevHash = f.entries[len(f.entries)-1].Hash
+	}
+	entry.Hash = entry.ComputeHash()
+	f.entries = append(f.entries, entry)
+	return nil
+}
+
+func (f *fakeAuditLog) FindEntries(ctx context.Context, filter domain.AuditFilter, offset, limit int64) ([]*domain.AuditEntry, int64, error) {
+	var matches []*domain.AuditEntry
+	for i := len(f.entries) - 1; i >= 0; i-- {
+		entry := f.entries[i]
+		if (filter.ActorID == "" || entry.ActorID == filter.ActorID) && (filter.Action == "" || entry.Action == filter.Action) && (filter.Outcome == "" || entry.Outcome == filter.Outcome) {
+			matches = append(matches, entry)
+		}
+	}
+	total := int64(len(matches))
+	matches = matches[min(offset, total):min(offset+limit, total)]
+	return matches, total, nil
+}
+
+func (f *fakeAuditLog) ScanEntries(ctx context.Context, fromSequence int64, fn func(*domain.AuditEntry) error) error {
+	for _, entry := range f.entries {
+		if entry.Sequence < fromSequence {
+			continue
+		}
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// actions returns the action and outcome of every recorded entry.
+func (f *fakeAuditLog) actions() []string {
+	var actions []string
+	for _, entry := range f.entries {
+		actions = append(actions, entry.Action+":"+entry.Outcome)
+	}
+	return actions
+}
+
+type AuditUseCaseTestSuite struct {
+	suite.Suite
+	log     *fakeAuditLog
+	useCase *usecases.AuditUsecase
+	ctx     context.Context
+}
+
+func (s *AuditUseCaseTestSuite) SetupTest() {
+	s.log = &fakeAuditLog{}
+	s.useCase = usecases.NewAuditUsecase(s.log)
+	s.ctx = domain.WithRequestInfo(context.Background(), &domain.RequestInfo{
+		ActorID:   "admin",
+		IP:        "192.0.2.1",
+		UserAgent: "test",
+		RequestID: "req-1",
+	})
+
+	for _, action := range []string{"user.create", "auth.login", "user.delete"} {
+		s.NoError(s.log.Record(s.ctx, &domain.AuditEntry{Category: domain.AuditCategoryAccount, Action: action, Outcome: domain.AuditOutcomeSuccess, ActorID: "admin"}))
+	}
+}
+
+func (s *AuditUseCaseTestSuite) TestListEntries() {
+	// Test case 1: Newest entries come first
+	page, err := s.useCase.ListEntries(s.ctx, domain.AuditFilter{}, 1, 2)
+	s.NoError(err)
+	s.Equal(int64(3), page.Total)
+	s.Len(page.Entries, 2)
+	s.Equal("user.delete", page.Entries[0].Action)
+
+	// Test case 2: Later pages continue where the previous one ended
+	page, err = s.useCase.ListEntries(s.ctx, domain.AuditFilter{}, 2, 2)
+	s.NoError(err)
+	s.Len(page.Entries, 1)
+	s.Equal("user.create", page.Entries[0].Action)
+
+	// Test case 3: Filters narrow the entries and the total
+	page, err = s.useCase.ListEntries(s.ctx, domain.AuditFilter{Action: "auth.login"}, 0, 0)
+	s.NoError(err)
+	s.Equal(int64(1), page.Total)
+	s.Equal(1, page.Page)
+	s.Equal(50, page.PageSize)
+}
+
+func (s *AuditUseCaseTestSuite) TestVerifyChain() {
+	// Test case 1: An untouched log is intact
+	result, err := s.useCase.VerifyChain(s.ctx)
+	s.NoError(err)
+	s.Equal(int64(3), result.Checked)
+	s.Zero(result.BrokenAt)
+	s.Equal(s.log.entries[2].Hash, result.LastHash)
+
+	// Test case 2: A changed entry breaks the chain at that entry
+	s.log.entries[1].ActorID = "someone-else"
+	result, err = s.useCase.VerifyChain(s.ctx)
+	s.NoError(err)
+	s.Equal(int64(2), result.BrokenAt)
+	s.Equal("hash does not match content", result.Problem)
+
+	// Test case 3: Rehashing the changed entry does not hide it from the next one
+	s.log.entries[1].Hash = s.log.entries[1].ComputeHash()
+	result, err = s.useCase.VerifyChain(s.ctx)
+	s.NoError(err)
+	s.Equal(int64(3), result.BrokenAt)
+	s.Equal("previous hash does not match", result.Problem)
+
+	// Test case 4: A removed entry is reported as missing
+	s.SetupTest()
+	s.log.entries = append(s.log.entries[:1], s.log.entries[2:]...)
+	result, err = s.useCase.VerifyChain(s.ctx)
+	s.NoError(err)
+	s.Equal(int64(3), result.BrokenAt)
+	s.Equal("entries 2 to 2 are missing", result.Problem)
+}
+
+func TestAuditUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(AuditUseCaseTestSuite))
+}
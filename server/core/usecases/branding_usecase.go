@@ -0,0 +1,211 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"veritas/core/domain"
+	"veritas/internal/ports/output"
+)
+
+var ErrInvalidBranding = errors.New("invalid branding")
+
+var (
+	colorPattern   = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+	copyKeyPattern = regexp.MustCompile(`^[a-z_]+\.[a-z_]+$`)
+)
+
+const (
+	maxProductNameLength = 100
+	maxCopyEntries       = 100
+	maxCopyLength        = 1000
+)
+
+type BrandingUsecase struct {
+	repo  output.BrandingOutputPort
+	audit output.AuditLogger
+}
+
+func NewBrandingUsecase(repo output.BrandingOutputPort, audit output.AuditLogger) *BrandingUsecase {
+	return &BrandingUsecase{repo: repo, audit: audit}
+}
+
+// builtInBranding is the theme of the hosted pages before any branding is
+// saved.
+func builtInBranding() *domain.Branding {
+	return &domain.Branding{
+		ID:              domain.DefaultBrandingID,
+		ProductName:     "Veritas",
+		PrimaryColor:    "#2563eb",
+		BackgroundColor: "#f8fafc",
+		TextColor:       "#0f172a",
+		Copy:            map[string]string{},
+	}
+}
+
+// GetBranding returns the branding of a tenant. Fields the tenant left empty
+// are taken from the default branding, and then from the built-in theme.
+func (uc *BrandingUsecase) GetBranding(ctx context.Context, tenant string) (*domain.Branding, error) {
+	ids := []string{domain.DefaultBrandingID}
+	if tenant != "" && tenant != domain.DefaultBrandingID {
+		ids = append(ids, tenant)
+	}
+
+	branding := builtInBranding()
+	for _, id := range ids {
+		saved, err := uc.repo.GetBranding(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if saved != nil {
+			mergeBranding(branding, saved)
+		}
+		branding.ID = id
+	}
+	return branding, nil
+}
+
+func mergeBranding(branding, override *domain.Branding) {
+	for _, field := range []struct {
+		dst *string
+		src string
+	}{
+		{&branding.ProductName, override.ProductName},
+		{&branding.LogoURL, override.LogoURL},
+		{&branding.PrimaryColor, override.PrimaryColor},
+		{&branding.BackgroundColor, override.BackgroundColor},
+		{&branding.TextColor, override.TextColor},
+	} {
+		if field.src != "" {
+			*field.dst = field.src
+		}
+	}
+	for key, text := range override.Copy {
+		branding.Copy[key] = text
+	}
+	if override.UpdatedAt.After(branding.UpdatedAt) {
+		branding.UpdatedAt = override.UpdatedAt
+	}
+}
+
+type UpdateBrandingInput struct {
+	ProductName     string
+	LogoURL         string
+	PrimaryColor    string
+	BackgroundColor string
+	TextColor       string
+	Copy            map[string]string
+}
+
+// UpdateBranding replaces the saved branding of a tenant and returns the
+// branding its pages now use. Empty fields fall back to the default
+// branding.
+func (uc *BrandingUsecase) UpdateBranding(ctx context.Context, tenant string, input UpdateBrandingInput) (*domain.Branding, error) {
+	if tenant == "" {
+		tenant = domain.DefaultBrandingID
+	}
+	if err := validateBranding(input); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.repo.GetBranding(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		existing = &domain.Branding{}
+	}
+
+	branding := &domain.Branding{
+		ID:              tenant,
+		ProductName:     input.ProductName,
+		LogoURL:         input.LogoURL,
+		PrimaryColor:    input.PrimaryColor,
+		BackgroundColor: input.BackgroundColor,
+		TextColor:       input.TextColor,
+		Copy:            input.Copy,
+		UpdatedAt:       time.Now(),
+	}
+	if branding.Copy == nil {
+		branding.Copy = map[string]string{}
+	}
+
+	var changes []domain.AuditChange
+	changes = appendChange(changes, "productName", existing.ProductName, branding.ProductName)
+	changes = appendChange(changes, "logoUrl", existing.LogoURL, branding.LogoURL)
+	changes = appendChange(changes, "primaryColor", existing.PrimaryColor, branding.PrimaryColor)
+	changes = appendChange(changes, "backgroundColor", existing.BackgroundColor, branding.BackgroundColor)
+	changes = appendChange(changes, "textColor", existing.TextColor, branding.TextColor)
+	for _, key := range copyKeys(existing.Copy, branding.Copy) {
+		changes = appendChange(changes, "copy."+key, existing.Copy[key], branding.Copy[key])
+	}
+
+	if err := uc.repo.SaveBranding(ctx, branding); err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, uc.audit, &domain.AuditEntry{
+		Category:   domain.AuditCategoryAdmin,
+		Action:     "branding.update",
+		TargetType: "branding",
+		TargetID:   tenant,
+		Changes:    changes,
+	})
+	return uc.GetBranding(ctx, tenant)
+}
+
+func validateBranding(input UpdateBrandingInput) error {
+	if len(input.ProductName) > maxProductNameLength {
+		return fmt.Errorf("%w: productName is longer than %d characters", ErrInvalidBranding, maxProductNameLength)
+	}
+	for name, color := range map[string]string{"primaryColor": input.PrimaryColor, "backgroundColor": input.BackgroundColor, "textColor": input.TextColor} {
+		if color != "" && !colorPattern.MatchString(color) {
+			return fmt.Errorf("%w: %s must be a hex color such as #2563eb", ErrInvalidBranding, name)
+		}
+	}
+	if input.LogoURL != "" && !isAllowedLogoURL(input.LogoURL) {
+		return fmt.Errorf("%w: logoUrl must be an https URL or an absolute path", ErrInvalidBranding)
+	}
+	if len(input.Copy) > maxCopyEntries {
+		return fmt.Errorf("%w: copy has more than %d entries", ErrInvalidBranding, maxCopyEntries)
+	}
+	for key, text := range input.Copy {
+		if !copyKeyPattern.MatchString(key) {
+			return fmt.Errorf("%w: copy key %q must look like page.field", ErrInvalidBranding, key)
+		}
+		if len(text) > maxCopyLength {
+			return fmt.Errorf("%w: copy %q is longer than %d characters", ErrInvalidBranding, key, maxCopyLength)
+		}
+	}
+	return nil
+}
+
+// isAllowedLogoURL accepts https URLs and paths on this server, which the
+// hosted pages' content security policy allows images from.
+func isAllowedLogoURL(logoURL string) bool {
+	if strings.HasPrefix(logoURL, "/") {
+		return !strings.HasPrefix(logoURL, "//") && !strings.HasPrefix(logoURL, "/\\")
+	}
+	parsed, err := url.Parse(logoURL)
+	return err == nil && parsed.Scheme == "https" && parsed.Host != ""
+}
+
+// copyKeys returns the keys of both maps in order.
+func copyKeys(before, after map[string]string) []string {
+	keys := make([]string, 0, len(before)+len(after))
+	for key := range before {
+		keys = append(keys, key)
+	}
+	for key := range after {
+		if _, ok := before[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,117 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"veritas/core/domain"
+	"veritas/core/usecases"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeBrandings keeps brandings in memory.
+type fakeBrandings struct {
+	brandings map[string]*domain.Branding
+}
+
+func (f *fakeBrandings) GetBranding(ctx context.Context, id string) (*domain.Branding, error) {
+	branding, ok := f.brandings[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *branding
+	return &copied, nil
+}
+
+func (f *fakeBrandings) SaveBranding(ctx context.Context, branding *domain.Branding) error {
+	copied := *branding
+	f.brandings[branding.ID] = &copied
+	return nil
+}
+
+type BrandingUseCaseTestSuite struct {
+	suite.Suite
+	brandings *fakeBrandings
+	audit     *fakeAuditLog
+	useCase   *usecases.BrandingUsecase
+	ctx       context.Context
+}
+
+func (s *BrandingUseCaseTestSuite) SetupTest() {
+	s.brandings = &fakeBrandings{brandings: map[string]*domain.Branding{}}
+	s.audit = &fakeAuditLog{}
+	s.useCase = usecases.NewBrandingUsecase(s.brandings, s.audit)
+	s.ctx = context.Background()
+}
+
+func (s *BrandingUseCaseTestSuite) TestGetBranding() {
+	// Test case 1: Without saved branding the built-in theme is used
+	branding, err := s.useCase.GetBranding(s.ctx, domain.DefaultBrandingID)
+	s.NoError(err)
+	s.Equal("Veritas", branding.ProductName)
+	s.Equal("#2563eb", branding.PrimaryColor)
+	s.Empty(branding.Copy)
+
+	// Test case 2: A tenant's branding falls back to the default branding
+	s.brandings.brandings[domain.DefaultBrandingID] = &domain.Branding{ID: domain.DefaultBrandingID, ProductName: "Acme", Copy: map[string]string{"login.title": "Welcome to Acme"}}
+	s.brandings.brandings["tenant"] = &domain.Branding{ID: "tenant", PrimaryColor: "#ff0000", Copy: map[string]string{"signup.title": "Join"}}
+	branding, err = s.useCase.GetBranding(s.ctx, "tenant")
+	s.NoError(err)
+	s.Equal("tenant", branding.ID)
+	s.Equal("Acme", branding.ProductName)
+	s.Equal("#ff0000", branding.PrimaryColor)
+	s.Equal("#f8fafc", branding.BackgroundColor)
+	s.Equal(map[string]string{"login.title": "Welcome to Acme", "signup.title": "Join"}, branding.Copy)
+}
+
+func (s *BrandingUseCaseTestSuite) TestUpdateBranding() {
+	// Test case 1: Saved branding is returned merged with the built-in theme and audited
+	branding, err := s.useCase.UpdateBranding(s.ctx, "", usecases.UpdateBrandingInput{
+		ProductName:  "Acme",
+		LogoURL:      "https://cdn.example.com/logo.svg",
+		PrimaryColor: "#123abc",
+		Copy:         map[string]string{"login.title": "Welcome back"},
+	})
+	s.NoError(err)
+	s.Equal("Acme", branding.ProductName)
+	s.Equal("#123abc", branding.PrimaryColor)
+	s.Equal("#0f172a", branding.TextColor)
+	s.Equal("", s.brandings.brandings[domain.DefaultBrandingID].TextColor)
+	s.Equal([]string{"branding.update:success"}, s.audit.actions())
+	var fields []string
+	for _, change := range s.audit.entries[0].Changes {
+		fields = append(fields, change.Field)
+	}
+	s.Equal([]string{"productName", "logoUrl", "primaryColor", "copy.login.title"}, fields)
+
+	// Test case 2: Removing a copy override is recorded
+	_, err = s.useCase.UpdateBranding(s.ctx, domain.DefaultBrandingID, usecases.UpdateBrandingInput{ProductName: "Acme"})
+	s.NoError(err)
+	s.Len(s.audit.entries, 2)
+	s.Contains(s.audit.entries[1].Changes, domain.AuditChange{Field: "copy.login.title", Before: "Welcome back"})
+}
+
+func (s *BrandingUseCaseTestSuite) TestUpdateBrandingValidation() {
+	// Test case 1: Values that could break out of the page are rejected
+	for _, input := range []usecases.UpdateBrandingInput{
+		{PrimaryColor: "red; background: url(https://evil.example)"},
+		{BackgroundColor: "#12345"},
+		{LogoURL: "javascript:alert(1)"},
+		{LogoURL: "http://cdn.example.com/logo.svg"},
+		{LogoURL: "//evil.example/logo.svg"},
+		{Copy: map[string]string{"Login Title": "Hi"}},
+	} {
+		_, err := s.useCase.UpdateBranding(s.ctx, "", input)
+		s.ErrorIs(err, usecases.ErrInvalidBranding)
+	}
+	s.Empty(s.brandings.brandings)
+	s.Empty(s.audit.entries)
+
+	// Test case 2: Paths on the server are accepted as logos
+	_, err := s.useCase.UpdateBranding(s.ctx, "", usecases.UpdateBrandingInput{LogoURL: "/ui/static/logo.svg"})
+	s.NoError(err)
+}
+
+func TestBrandingUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(BrandingUseCaseTestSuite))
+}
@@ -11,11 +11,12 @@ import (
 )
 
 type ClaimUsecase struct {
-	repo output.ClaimOutputPort
+	repo  output.ClaimOutputPort
+	audit output.AuditLogger
 }
 
-func NewClaimUsecase(repo output.ClaimOutputPort) *ClaimUsecase {
-	return &ClaimUsecase{repo: repo}
+func NewClaimUsecase(repo output.ClaimOutputPort, audit output.AuditLogger) *ClaimUsecase {
+	return &ClaimUsecase{repo: repo, audit: audit}
 }
 
 type CreateClaimInput struct {
@@ -30,7 +31,22 @@ func (uc *ClaimUsecase) CreateClaim(ctx context.Context, input CreateClaimInput)
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
-	return uc.repo.CreateClaim(ctx, claim)
+	id, err := uc.repo.CreateClaim(ctx, claim)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+
+	recordAudit(ctx, uc.audit, &domain.AuditEntry{
+		Category:   domain.AuditCategoryAdmin,
+		Action:     "claim.create",
+		TargetType: "claim",
+		TargetID:   id.Hex(),
+		Changes: []domain.AuditChange{
+			{Field: "name", After: claim.Name},
+			{Field: "description", After: claim.Description},
+		},
+	})
+	return id, nil
 }
 
 func (uc *ClaimUsecase) ReadClaim(ctx context.Context, id string) (*domain.Claim, error) {
@@ -57,10 +73,13 @@ func (uc *ClaimUsecase) UpdateClaim(ctx context.Context, id string, input Update
 		return nil, fmt.Errorf("failed to get claim: %w", err)
 	}
 
+	var changes []domain.AuditChange
 	if input.Name != "" {
+		changes = appendChange(changes, "name", existingClaim.Name, input.Name)
 		existingClaim.Name = input.Name
 	}
 	if input.Description != "" {
+		changes = appendChange(changes, "description", existingClaim.Description, input.Description)
 		existingClaim.Description = input.Description
 	}
 
@@ -71,6 +90,13 @@ func (uc *ClaimUsecase) UpdateClaim(ctx context.Context, id string, input Update
 		return nil, err
 	}
 
+	recordAudit(ctx, uc.audit, &domain.AuditEntry{
+		Category:   domain.AuditCategoryAdmin,
+		Action:     "claim.update",
+		TargetType: "claim",
+		TargetID:   id,
+		Changes:    changes,
+	})
 	return existingClaim, nil
 }
 
@@ -79,7 +105,17 @@ func (uc *ClaimUsecase) DeleteClaim(ctx context.Context, id string) error {
 	if err != nil {
 		return fmt.Errorf("invalid id: %w", err)
 	}
-	return uc.repo.DeleteClaim(ctx, objectID)
+	if err := uc.repo.DeleteClaim(ctx, objectID); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, uc.audit, &domain.AuditEntry{
+		Category:   domain.AuditCategoryAdmin,
+		Action:     "claim.delete",
+		TargetType: "claim",
+		TargetID:   id,
+	})
+	return nil
 }
 
 func (uc *ClaimUsecase) GetAllClaims(ctx context.Context) ([]*domain.Claim, error) {
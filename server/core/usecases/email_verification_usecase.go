@@ -0,0 +1,146 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"veritas/core/domain"
+	"veritas/internal/ports/output"
+	"veritas/internal/security"
+
+	"github.com/dgrijalva/jwt-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const emailVerificationTTL = 24 * time.Hour
+
+var (
+	ErrInvalidVerificationToken = errors.New("invalid or expired verification token")
+	ErrEmailNotVerified         = errors.New("email address is not verified")
+)
+
+type EmailVerificationUsecase struct {
+	repo           output.UserOutputPort
+	mailer         output.EmailSender
+	audit          output.AuditLogger
+	baseURL        string
+	resendInterval time.Duration
+	required       bool
+}
+
+func NewEmailVerificationUsecase(repo output.UserOutputPort, mailer output.EmailSender, audit output.AuditLogger, baseURL string, resendInterval time.Duration, required bool) *EmailVerificationUsecase {
+	return &EmailVerificationUsecase{
+		repo:           repo,
+		mailer:         mailer,
+		audit:          audit,
+		baseURL:        strings.TrimRight(baseURL, "/"),
+		resendInterval: resendInterval,
+		required:       required,
+	}
+}
+
+// SendVerification emails the user a link carrying a signed token for their
+// current address.
+func (uc *EmailVerificationUsecase) SendVerification(ctx context.Context, user *domain.User) error {
+	token, err := security.SignToken(security.TokenUseVerifyEmail, jwt.MapClaims{
+		"sub":   user.ID.Hex(),
+		"email": user.Email,
+	}, emailVerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	link := uc.baseURL + "/auth/verify-email?token=" + url.QueryEscape(token) + tenantQuery(ctx)
+	err = uc.mailer.SendEmail(ctx, &domain.EmailMessage{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		TextBody: fmt.Sprintf("Hello %s,\n\nPlease confirm your email address by opening the link below:\n\n%s\n\nThe link expires in %d hours. If you did not create an account, you can ignore this message.\n",
+			user.Username, link, int(emailVerificationTTL.Hours())),
+	})
+	if err != nil {
+		return err
+	}
+
+	user.VerificationSentAt = time.Now()
+	if err := uc.repo.UpdateUser(ctx, user.ID, user); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, uc.audit, userAuditEntry(domain.AuditCategoryAccount, "email.verification_sent", user.ID))
+	return nil
+}
+
+// ResendVerification sends a new verification email to an unverified
+// account. Unknown and already verified addresses, as well as requests made
+// before the resend interval has passed, are silently ignored so the caller
+// cannot learn which addresses are registered.
+func (uc *EmailVerificationUsecase) ResendVerification(ctx context.Context, email string) error {
+	user, err := uc.repo.GetUserByEmail(ctx, email)
+	if err != nil || user.EmailVerified {
+		return nil
+	}
+	if time.Since(user.VerificationSentAt) < uc.resendInterval {
+		return nil
+	}
+	return uc.SendVerification(ctx, user)
+}
+
+// VerifyEmail checks a token from a verification email and marks the address
+// as verified. Tokens issued for a previous address of the user are rejected.
+func (uc *EmailVerificationUsecase) VerifyEmail(ctx context.Context, token string) (*domain.User, error) {
+	claims, err := security.ParseToken(token, security.TokenUseVerifyEmail)
+	if err != nil {
+		return nil, ErrInvalidVerificationToken
+	}
+	sub, _ := claims["sub"].(string)
+	email, _ := claims["email"].(string)
+
+	user, err := uc.userByID(ctx, sub)
+	if err != nil || user.Email != email {
+		return nil, ErrInvalidVerificationToken
+	}
+	if user.EmailVerified {
+		return user, nil
+	}
+
+	markEmailVerified(user)
+	user.UpdatedAt = time.Now()
+	if err := uc.repo.UpdateUser(ctx, user.ID, user); err != nil {
+		return nil, err
+	}
+
+	entry := userAuditEntry(domain.AuditCategoryAccount, "email.verify", user.ID)
+	entry.Changes = []domain.AuditChange{{Field: "emailVerified", Before: "false", After: "true"}}
+	recordAudit(ctx, uc.audit, entry)
+
+	return user, nil
+}
+
+// CheckLoginAllowed returns ErrEmailNotVerified when verification is required
+// and the user has not completed it.
+func (uc *EmailVerificationUsecase) CheckLoginAllowed(user *domain.User) error {
+	if uc.required && !user.EmailVerified {
+		return ErrEmailNotVerified
+	}
+	return nil
+}
+
+// markEmailVerified records that the user controls their email address,
+// which activates a pending account.
+func markEmailVerified(user *domain.User) {
+	user.EmailVerified = true
+	if user.CurrentStatus() == domain.UserStatusPending {
+		transitionStatus(user, domain.UserStatusActive, "email address verified", "")
+	}
+}
+
+func (uc *EmailVerificationUsecase) userByID(ctx context.Context, id string) (*domain.User, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id: %w", err)
+	}
+	return uc.repo.GetUser(ctx, objectID)
+}
@@ -0,0 +1,106 @@
+package usecases_test
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+	"veritas/core/domain"
+	"veritas/core/usecases"
+	"veritas/internal/adapters/mailer"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type EmailVerificationUseCaseTestSuite struct {
+	suite.Suite
+	mockOutputPort *MockUserOutputPort
+	mailer         *mailer.MemorySender
+	useCase        *usecases.EmailVerificationUsecase
+	audit          *fakeAuditLog
+	ctx            context.Context
+	user           *domain.User
+}
+
+func (s *EmailVerificationUseCaseTestSuite) SetupTest() {
+	s.audit = &fakeAuditLog{}
+	s.mockOutputPort = new(MockUserOutputPort)
+	s.mailer = mailer.NewMemorySender()
+	s.useCase = usecases.NewEmailVerificationUsecase(s.mockOutputPort, s.mailer, s.audit, "https://id.example.com/", time.Minute, true)
+	s.ctx = context.Background()
+	s.user = &domain.User{
+		ID:       primitive.NewObjectID(),
+		Username: "test",
+		Email:    "test@example.com",
+		Status:   domain.UserStatusPending,
+	}
+	s.mockOutputPort.On("GetUser", s.ctx, s.user.ID).Return(s.user, nil)
+	s.mockOutputPort.On("GetUserByEmail", s.ctx, s.user.Email).Return(s.user, nil)
+	s.mockOutputPort.On("UpdateUser", s.ctx, s.user.ID, s.user).Return(nil)
+}
+
+// sentToken extracts the token from the link in the last email sent.
+func (s *EmailVerificationUseCaseTestSuite) sentToken() string {
+	messages := s.mailer.Messages()
+	s.Require().NotEmpty(messages)
+	body := messages[len(messages)-1].TextBody
+	start := strings.Index(body, "https://id.example.com/auth/verify-email?token=")
+	s.Require().NotEqual(-1, start)
+	link := strings.Fields(body[start:])[0]
+	parsed, err := url.Parse(link)
+	s.Require().NoError(err)
+	return parsed.Query().Get("token")
+}
+
+func (s *EmailVerificationUseCaseTestSuite) TestVerifyEmail() {
+	// Test case 1: Unverified users cannot log in
+	s.ErrorIs(s.useCase.CheckLoginAllowed(s.user), usecases.ErrEmailNotVerified)
+
+	// Test case 2: The emailed token verifies the address
+	err := s.useCase.SendVerification(s.ctx, s.user)
+	s.NoError(err)
+	s.Equal("test@example.com", s.mailer.Messages()[0].To)
+	token := s.sentToken()
+
+	user, err := s.useCase.VerifyEmail(s.ctx, token)
+	s.NoError(err)
+	s.True(user.EmailVerified)
+	s.Equal(domain.UserStatusActive, user.Status)
+	s.NoError(s.useCase.CheckLoginAllowed(s.user))
+
+	// Test case 3: Tampered token
+	_, err = s.useCase.VerifyEmail(s.ctx, token+"x")
+	s.ErrorIs(err, usecases.ErrInvalidVerificationToken)
+
+	// Test case 4: Token issued for a previous address
+	s.user.Email = "new@example.com"
+	s.user.EmailVerified = false
+	_, err = s.useCase.VerifyEmail(s.ctx, token)
+	s.ErrorIs(err, usecases.ErrInvalidVerificationToken)
+	s.False(s.user.EmailVerified)
+}
+
+func (s *EmailVerificationUseCaseTestSuite) TestResendVerification() {
+	// Test case 1: First request sends an email
+	err := s.useCase.ResendVerification(s.ctx, s.user.Email)
+	s.NoError(err)
+	s.Len(s.mailer.Messages(), 1)
+
+	// Test case 2: Requests within the interval are throttled
+	err = s.useCase.ResendVerification(s.ctx, s.user.Email)
+	s.NoError(err)
+	s.Len(s.mailer.Messages(), 1)
+
+	// Test case 3: Verified users do not receive another email
+	s.user.VerificationSentAt = time.Time{}
+	s.user.EmailVerified = true
+	err = s.useCase.ResendVerification(s.ctx, s.user.Email)
+	s.NoError(err)
+	s.Len(s.mailer.Messages(), 1)
+}
+
+func TestEmailVerificationUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(EmailVerificationUseCaseTestSuite))
+}
@@ -0,0 +1,380 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"veritas/core/domain"
+	"veritas/internal/ports/output"
+	"veritas/internal/security"
+
+	"github.com/dgrijalva/jwt-go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const invitationTTL = 7 * 24 * time.Hour
+
+var (
+	ErrInvitationNotFound     = errors.New("invitation not found")
+	ErrInvalidInvitation      = errors.New("invalid invitation")
+	ErrInvalidInvitationToken = errors.New("invalid or expired invitation")
+	ErrInvitationNameRequired = errors.New("a name is required to create the account")
+)
+
+type InvitationUsecase struct {
+	invitations   output.InvitationOutputPort
+	organizations *OrganizationUsecase
+	users         output.UserOutputPort
+	roles         output.RoleOutputPort
+	sessions      output.SessionOutputPort
+	credentials   output.WebAuthnCredentialOutputPort
+	mailer        output.EmailSender
+	passwords     *PasswordPolicyUsecase
+	audit         output.AuditLogger
+	baseURL       string
+}
+
+func NewInvitationUsecase(invitations output.InvitationOutputPort, organizations *OrganizationUsecase, users output.UserOutputPort, roles output.RoleOutputPort, sessions output.SessionOutputPort, credentials output.WebAuthnCredentialOutputPort, mailer output.EmailSender, passwords *PasswordPolicyUsecase, audit output.AuditLogger, baseURL string) *InvitationUsecase {
+	return &InvitationUsecase{
+		invitations:   invitations,
+		organizations: organizations,
+		users:         users,
+		roles:         roles,
+		sessions:      sessions,
+		credentials:   credentials,
+		mailer:        mailer,
+		passwords:     passwords,
+		audit:         audit,
+		baseURL:       strings.TrimRight(baseURL, "/"),
+	}
+}
+
+type CreateInvitationInput struct {
+	Email   string
+	RoleIDs []string
+	Admin   bool
+}
+
+// Invite emails an invitation to join an organization with the given roles
+// of it. Inviting an address again replaces its pending invitation, so only
+// the latest link works.
+func (uc *InvitationUsecase) Invite(ctx context.Context, actorID string, organizationID string, input CreateInvitationInput) (*domain.Invitation, error) {
+	organization, err := uc.organizations.ReadOrganization(ctx, actorID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	tenantCtx := domain.WithTenant(ctx, organization.ID)
+
+	email := normalizeEmail(input.Email)
+	if email == "" {
+		return nil, fmt.Errorf("%w: email is required", ErrInvalidInvitation)
+	}
+	roleIDs, err := uc.organizationRoles(tenantCtx, input.RoleIDs)
+	if err != nil {
+		return nil, err
+	}
+	invitedBy, _ := primitive.ObjectIDFromHex(actorID)
+
+	if err := uc.invitations.DeleteInvitationsByEmail(tenantCtx, email); err != nil {
+		return nil, err
+	}
+	invitation := &domain.Invitation{
+		Email:     email,
+		RoleIDs:   roleIDs,
+		Admin:     input.Admin,
+		InvitedBy: invitedBy,
+		ExpiresAt: time.Now().Add(invitationTTL),
+	}
+	id, err := uc.invitations.CreateInvitation(tenantCtx, invitation)
+	if err != nil {
+		return nil, err
+	}
+	invitation.ID = id
+
+	// An invitation that never reached its address is of no use to anyone.
+	if err := uc.send(tenantCtx, organization, invitation); err != nil {
+		if deleteErr := uc.invitations.DeleteInvitation(tenantCtx, id); deleteErr != nil {
+			log.Printf("failed to delete undelivered invitation %s: %v", id.Hex(), deleteErr)
+		}
+		return nil, err
+	}
+
+	recordAudit(ctx, uc.audit, &domain.AuditEntry{
+		Category:   domain.AuditCategoryAdmin,
+		Action:     "invitation.create",
+		TargetType: "invitation",
+		TargetID:   id.Hex(),
+		Changes: []domain.AuditChange{
+			{Field: "organization", After: organization.ID.Hex()},
+			{Field: "email", After: email},
+			{Field: "roles", After: joinIDs(roleIDs)},
+			{Field: "admin", After: fmt.Sprint(invitation.Admin)},
+		},
+	})
+	return invitation, nil
+}
+
+// ListInvitations returns the pending invitations of an organization.
+func (uc *InvitationUsecase) ListInvitations(ctx context.Context, actorID string, organizationID string) ([]*domain.Invitation, error) {
+	organization, err := uc.organizations.ReadOrganization(ctx, actorID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.invitations.GetPendingInvitations(domain.WithTenant(ctx, organization.ID))
+}
+
+// RevokeInvitation deletes a pending invitation, so its link stops working.
+func (uc *InvitationUsecase) RevokeInvitation(ctx context.Context, actorID string, organizationID string, invitationID string) error {
+	organization, err := uc.organizations.ReadOrganization(ctx, actorID, organizationID)
+	if err != nil {
+		return err
+	}
+	tenantCtx := domain.WithTenant(ctx, organization.ID)
+
+	objectID, err := primitive.ObjectIDFromHex(invitationID)
+	if err != nil {
+		return ErrInvitationNotFound
+	}
+	invitation, err := uc.invitations.GetInvitation(tenantCtx, objectID)
+	if err != nil {
+		return ErrInvitationNotFound
+	}
+	if err := uc.invitations.DeleteInvitation(tenantCtx, invitation.ID); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, uc.audit, &domain.AuditEntry{
+		Category:   domain.AuditCategoryAdmin,
+		Action:     "invitation.revoke",
+		TargetType: "invitation",
+		TargetID:   invitation.ID.Hex(),
+		Changes:    []domain.AuditChange{{Field: "email", Before: invitation.Email}},
+	})
+	return nil
+}
+
+// InvitationDetails describes an invitation to the invitee.
+type InvitationDetails struct {
+	Invitation   *domain.Invitation
+	Organization *domain.Organization
+
+	// HasAccount is true when the invitee already has an account in the
+	// organization with a verified address, which accepting links instead
+	// of creating one. An account whose address was never verified is taken
+	// over instead, and needs a password as a new one would.
+	HasAccount bool
+}
+
+// ReadInvitation returns the invitation of a link, looked up in the
+// organization ctx is scoped to.
+func (uc *InvitationUsecase) ReadInvitation(ctx context.Context, token string) (*InvitationDetails, error) {
+	claims, err := security.ParseToken(token, security.TokenUseInvitation)
+	if err != nil {
+		return nil, ErrInvalidInvitationToken
+	}
+	sub, _ := claims["sub"].(string)
+	email, _ := claims["email"].(string)
+
+	id, err := primitive.ObjectIDFromHex(sub)
+	if err != nil {
+		return nil, ErrInvalidInvitationToken
+	}
+	invitation, err := uc.invitations.GetInvitation(ctx, id)
+	if err != nil || invitation.Email != email || time.Now().After(invitation.ExpiresAt) {
+		return nil, ErrInvalidInvitationToken
+	}
+	organization, err := uc.organizations.CurrentOrganization(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.users.GetUserByEmail(ctx, invitation.Email)
+	return &InvitationDetails{Invitation: invitation, Organization: organization, HasAccount: err == nil && user.EmailVerified}, nil
+}
+
+type AcceptInvitationInput struct {
+	Token    string
+	Name     string
+	Password string
+}
+
+// AcceptInvitation gives the invitee the roles of the invitation. An
+// existing account with the invited, verified address is linked; otherwise
+// an account is created with the given name and password. The link was
+// delivered to the address, so it counts as verified. It returns the user
+// and whether the account was created.
+//
+// Anyone can sign up with an address they do not own, so an existing
+// account whose address was never verified is not simply linked: the
+// invitee takes it over as with a password reset, setting a new password
+// and ending the sessions, second factors and passkeys set up before.
+func (uc *InvitationUsecase) AcceptInvitation(ctx context.Context, input AcceptInvitationInput) (*domain.User, bool, error) {
+	details, err := uc.ReadInvitation(ctx, input.Token)
+	if err != nil {
+		return nil, false, err
+	}
+	invitation := details.Invitation
+
+	user, err := uc.users.GetUserByEmail(ctx, invitation.Email)
+	created := err != nil
+	reclaimed := !created && !user.EmailVerified
+	if created {
+		name := strings.TrimSpace(input.Name)
+		if name == "" {
+			return nil, false, ErrInvitationNameRequired
+		}
+		now := time.Now()
+		user = &domain.User{
+			Username:      name,
+			Email:         invitation.Email,
+			Status:        domain.UserStatusActive,
+			EmailVerified: true,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+		user.StatusHistory = []domain.UserStatusChange{{To: domain.UserStatusActive, Reason: "invitation accepted", ChangedAt: now}}
+		// Check the policy before consuming the invitation so a rejected
+		// password does not burn it.
+		if err := uc.passwords.SetPassword(ctx, user, input.Password); err != nil {
+			return nil, false, err
+		}
+	}
+	if reclaimed {
+		if name := strings.TrimSpace(input.Name); name != "" {
+			user.Username = name
+		}
+		if err := uc.passwords.SetPassword(ctx, user, input.Password); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if _, err := uc.invitations.ConsumeInvitation(ctx, invitation.ID); err != nil {
+		return nil, false, ErrInvalidInvitationToken
+	}
+
+	// Roles deleted since the invitation was sent are skipped.
+	before := joinIDs(user.RoleIDs)
+	for _, roleID := range invitation.RoleIDs {
+		if _, err := uc.roles.GetRole(ctx, roleID); err == nil && !slices.Contains(user.RoleIDs, roleID) {
+			user.RoleIDs = append(user.RoleIDs, roleID)
+		}
+	}
+
+	if created {
+		id, err := uc.users.CreateUser(ctx, user)
+		if err != nil {
+			return nil, false, err
+		}
+		user.ID = id
+		recordAudit(ctx, uc.audit, &domain.AuditEntry{
+			Category:   domain.AuditCategoryAccount,
+			Action:     "user.create",
+			TargetType: "user",
+			TargetID:   id.Hex(),
+			Changes: []domain.AuditChange{
+				{Field: "name", After: user.Username},
+				{Field: "email", After: user.Email},
+			},
+		})
+	} else {
+		if reclaimed {
+			markEmailVerified(user)
+			clearSecondFactors(user)
+			user.TokensRevokedAt = time.Now()
+		}
+		user.UpdatedAt = time.Now()
+		if err := uc.users.UpdateUser(ctx, user.ID, user); err != nil {
+			return nil, false, err
+		}
+		if reclaimed {
+			if err := uc.signOut(ctx, user); err != nil {
+				return nil, false, err
+			}
+		}
+	}
+
+	if invitation.Admin {
+		if err := uc.organizations.addAdmin(ctx, details.Organization, user.ID); err != nil {
+			return nil, false, err
+		}
+	}
+
+	entry := userAuditEntry(domain.AuditCategoryAccount, "invitation.accept", user.ID)
+	entry.Reason = "invitation " + invitation.ID.Hex()
+	entry.Changes = appendChange(nil, "roles", before, joinIDs(user.RoleIDs))
+	if reclaimed {
+		entry.Changes = append(entry.Changes, secretChange("password"), domain.AuditChange{Field: "emailVerified", Before: "false", After: "true"})
+	}
+	recordAudit(ctx, uc.audit, entry)
+	return user, created, nil
+}
+
+// signOut ends every session of a taken over account and removes its
+// passkeys, so whoever registered it first loses access.
+func (uc *InvitationUsecase) signOut(ctx context.Context, user *domain.User) error {
+	if _, err := uc.sessions.DeleteSessionsByUser(ctx, user.ID); err != nil {
+		return err
+	}
+	credentials, err := uc.credentials.GetCredentialsByUser(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	for _, credential := range credentials {
+		if err := uc.credentials.DeleteCredential(ctx, credential.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// send emails the invitation link, which carries a signed token naming the
+// invitation and its address.
+func (uc *InvitationUsecase) send(ctx context.Context, organization *domain.Organization, invitation *domain.Invitation) error {
+	token, err := security.SignToken(security.TokenUseInvitation, jwt.MapClaims{
+		"sub":   invitation.ID.Hex(),
+		"email": invitation.Email,
+	}, invitationTTL)
+	if err != nil {
+		return err
+	}
+
+	link := uc.baseURL + "/auth/invitations/accept?token=" + url.QueryEscape(token) + tenantQuery(ctx)
+	return uc.mailer.SendEmail(ctx, &domain.EmailMessage{
+		To:      invitation.Email,
+		Subject: "You have been invited to " + organization.Name,
+		TextBody: fmt.Sprintf("Hello,\n\nYou have been invited to join %s. Open the link below to accept the invitation:\n\n%s\n\nThe link expires in %d days and can only be used once. If you did not expect this invitation, you can ignore this message.\n",
+			organization.Name, link, int(invitationTTL.Hours()/24)),
+	})
+}
+
+// organizationRoles parses role IDs, checking that each is a role of the
+// organization ctx is scoped to.
+func (uc *InvitationUsecase) organizationRoles(ctx context.Context, ids []string) ([]primitive.ObjectID, error) {
+	var roleIDs []primitive.ObjectID
+	for _, id := range ids {
+		roleID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid role id %q", ErrInvalidInvitation, id)
+		}
+		if _, err := uc.roles.GetRole(ctx, roleID); err != nil {
+			return nil, fmt.Errorf("%w: role %s is not a role of the organization", ErrInvalidInvitation, id)
+		}
+		if !slices.Contains(roleIDs, roleID) {
+			roleIDs = append(roleIDs, roleID)
+		}
+	}
+	return roleIDs, nil
+}
+
+func joinIDs(ids []primitive.ObjectID) string {
+	hexes := make([]string, len(ids))
+	for i, id := range ids {
+		hexes[i] = id.Hex()
+	}
+	return strings.Join(hexes, ",")
+}
@@ -0,0 +1,412 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+	"veritas/core/domain"
+	"veritas/core/usecases"
+	"veritas/internal/adapters/mailer"
+	"veritas/internal/ports/output"
+	"veritas/internal/security"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeInvitations keeps invitations in memory, scoped to the tenant of the
+// context like the repository.
+type fakeInvitations struct {
+	invitations []*domain.Invitation
+}
+
+func (f *fakeInvitations) CreateInvitation(ctx context.Context, invitation *domain.Invitation) (primitive.ObjectID, error) {
+	tenantID, ok := domain.TenantFromContext(ctx)
+	if !ok {
+		return primitive.NilObjectID, errors.New("no tenant in context")
+	}
+	invitation.TenantID = tenantID
+	copied := *invitation
+	copied.ID = primitive.NewObjectID()
+	f.invitations = append(f.invitations, &copied)
+	return copied.ID, nil
+}
+
+func (f *fakeInvitations) GetInvitation(ctx context.Context, id primitive.ObjectID) (*domain.Invitation, error) {
+	if i := f.index(ctx, func(inv *domain.Invitation) bool { return inv.ID == id }); i >= 0 {
+		return f.invitations[i], nil
+	}
+	return nil, errors.New("invitation not found")
+}
+
+func (f *fakeInvitations) ConsumeInvitation(ctx context.Context, id primitive.ObjectID) (*domain.Invitation, error) {
+	invitation, err := f.GetInvitation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return invitation, f.DeleteInvitation(ctx, id)
+}
+
+func (f *fakeInvitations) DeleteInvitation(ctx context.Context, id primitive.ObjectID) error {
+	if i := f.index(ctx, func(inv *domain.Invitation) bool { return inv.ID == id }); i >= 0 {
+		f.invitations = append(f.invitations[:i], f.invitations[i+1:]...)
+	}
+	return nil
+}
+
+func (f *fakeInvitations) DeleteInvitationsByEmail(ctx context.Context, email string) error {
+	for i := f.index(ctx, func(inv *domain.Invitation) bool { return inv.Email == email }); i >= 0; i = f.index(ctx, func(inv *domain.Invitation) bool { return inv.Email == email }) {
+		f.invitations = append(f.invitations[:i], f.invitations[i+1:]...)
+	}
+	return nil
+}
+
+func (f *fakeInvitations) GetPendingInvitations(ctx context.Context) ([]*domain.Invitation, error) {
+	var pending []*domain.Invitation
+	tenantID, _ := domain.TenantFromContext(ctx)
+	for _, inv := range f.invitations {
+		if inv.TenantID == tenantID && inv.ExpiresAt.After(time.Now()) {
+			pending = append(pending, inv)
+		}
+	}
+	return pending, nil
+}
+
+func (f *fakeInvitations) index(ctx context.Context, match func(*domain.Invitation) bool) int {
+	tenantID, ok := domain.TenantFromContext(ctx)
+	if !ok {
+		return -1
+	}
+	for i, inv := range f.invitations {
+		if inv.TenantID == tenantID && match(inv) {
+			return i
+		}
+	}
+	return -1
+}
+
+// fakeTenantRoles only finds roles in the tenant of the context.
+type fakeTenantRoles struct {
+	output.RoleOutputPort
+	roles []*domain.Role
+}
+
+func (f *fakeTenantRoles) GetRole(ctx context.Context, id primitive.ObjectID) (*domain.Role, error) {
+	tenantID, _ := domain.TenantFromContext(ctx)
+	for _, role := range f.roles {
+		if role.ID == id && role.TenantID == tenantID {
+			return role, nil
+		}
+	}
+	return nil, errors.New("role not found")
+}
+
+// fakeCredentials keeps passkeys in memory.
+type fakeCredentials struct {
+	output.WebAuthnCredentialOutputPort
+	credentials []*domain.WebAuthnCredential
+}
+
+func (f *fakeCredentials) GetCredentialsByUser(ctx context.Context, userID primitive.ObjectID) ([]*domain.WebAuthnCredential, error) {
+	var credentials []*domain.WebAuthnCredential
+	for _, credential := range f.credentials {
+		if credential.UserID == userID {
+			credentials = append(credentials, credential)
+		}
+	}
+	return credentials, nil
+}
+
+func (f *fakeCredentials) DeleteCredential(ctx context.Context, id primitive.ObjectID) error {
+	f.credentials = slices.DeleteFunc(f.credentials, func(credential *domain.WebAuthnCredential) bool { return credential.ID == id })
+	return nil
+}
+
+type InvitationUseCaseTestSuite struct {
+	suite.Suite
+	organizations *fakeOrganizations
+	users         *fakeTenantUsers
+	roles         *fakeTenantRoles
+	invitations   *fakeInvitations
+	sessions      *fakeSessions
+	credentials   *fakeCredentials
+	mailer        *mailer.MemorySender
+	audit         *fakeAuditLog
+	useCase       *usecases.InvitationUsecase
+	defaultOrg    *domain.Organization
+	acme          *domain.Organization
+	platformAdmin *domain.User
+	acmeAdmin     *domain.User
+	acmeMember    *domain.User
+	acmeRole      *domain.Role
+	defaultRole   *domain.Role
+}
+
+func (s *InvitationUseCaseTestSuite) SetupTest() {
+	s.organizations = &fakeOrganizations{}
+	s.users = &fakeTenantUsers{}
+	s.invitations = &fakeInvitations{}
+	s.sessions = &fakeSessions{sessions: map[primitive.ObjectID]*domain.Session{}}
+	s.credentials = &fakeCredentials{}
+	s.mailer = mailer.NewMemorySender()
+	s.audit = &fakeAuditLog{}
+	organizationUseCase := usecases.NewOrganizationUsecase(s.organizations, s.users, s.audit)
+
+	var err error
+	s.defaultOrg, err = organizationUseCase.EnsureDefaultOrganization(context.Background())
+	s.Require().NoError(err)
+	s.acme = &domain.Organization{Name: "Acme", Slug: "acme"}
+	s.acme.ID, err = s.organizations.CreateOrganization(context.Background(), s.acme)
+	s.Require().NoError(err)
+
+	s.platformAdmin = &domain.User{ID: primitive.NewObjectID(), TenantID: s.defaultOrg.ID, Email: "root@example.com", EmailVerified: true}
+	s.acmeAdmin = &domain.User{ID: primitive.NewObjectID(), TenantID: s.acme.ID, Email: "admin@acme.example"}
+	s.acmeMember = &domain.User{ID: primitive.NewObjectID(), TenantID: s.acme.ID, Email: "member@acme.example", Status: domain.UserStatusActive, EmailVerified: true}
+	s.users.users = []*domain.User{s.platformAdmin, s.acmeAdmin, s.acmeMember}
+	s.Require().NoError(organizationUseCase.SeedAdmins(context.Background(), s.defaultOrg, []string{s.platformAdmin.Email}))
+	s.acme.AdminIDs = []primitive.ObjectID{s.acmeAdmin.ID}
+	s.Require().NoError(s.organizations.UpdateOrganization(context.Background(), s.acme.ID, s.acme))
+
+	s.acmeRole = &domain.Role{ID: primitive.NewObjectID(), TenantID: s.acme.ID, Name: "editor"}
+	s.defaultRole = &domain.Role{ID: primitive.NewObjectID(), TenantID: s.defaultOrg.ID, Name: "editor"}
+	s.roles = &fakeTenantRoles{roles: []*domain.Role{s.acmeRole, s.defaultRole}}
+	s.useCase = usecases.NewInvitationUsecase(s.invitations, organizationUseCase, s.users, s.roles, s.sessions, s.credentials, s.mailer, usecases.NewPasswordPolicyUsecase(security.DefaultPasswordPolicy(), nil), s.audit, "https://id.example.com")
+	s.audit.entries = nil
+}
+
+// as returns a context of a request made by user, in its tenant.
+func (s *InvitationUseCaseTestSuite) as(user *domain.User) context.Context {
+	return domain.WithTenant(context.Background(), user.TenantID)
+}
+
+// in returns a context of a request made in organization, such as one
+// opened from an invitation link.
+func (s *InvitationUseCaseTestSuite) in(organization *domain.Organization) context.Context {
+	return domain.WithTenant(context.Background(), organization.ID)
+}
+
+// lastLink returns the link of the last email sent.
+func (s *InvitationUseCaseTestSuite) lastLink() *url.URL {
+	messages := s.mailer.Messages()
+	s.Require().NotEmpty(messages)
+	body := messages[len(messages)-1].TextBody
+	link, err := url.Parse(strings.Fields(body[strings.Index(body, "https://"):])[0])
+	s.Require().NoError(err)
+	return link
+}
+
+// invite invites email to Acme as its admin and returns the link's token.
+func (s *InvitationUseCaseTestSuite) invite(email string, admin bool) string {
+	_, err := s.useCase.Invite(s.as(s.acmeAdmin), s.acmeAdmin.ID.Hex(), s.acme.ID.Hex(), usecases.CreateInvitationInput{
+		Email:   email,
+		RoleIDs: []string{s.acmeRole.ID.Hex()},
+		Admin:   admin,
+	})
+	s.Require().NoError(err)
+	return s.lastLink().Query().Get("token")
+}
+
+func (s *InvitationUseCaseTestSuite) TestInvite() {
+	// Test case 1: An organization admin invites an address with roles of the organization
+	invitation, err := s.useCase.Invite(s.as(s.acmeAdmin), s.acmeAdmin.ID.Hex(), s.acme.ID.Hex(), usecases.CreateInvitationInput{
+		Email:   " Ada@Example.com ",
+		RoleIDs: []string{s.acmeRole.ID.Hex()},
+	})
+	s.Require().NoError(err)
+	s.Equal("ada@example.com", invitation.Email)
+	s.Equal(s.acme.ID, invitation.TenantID)
+	s.Equal([]primitive.ObjectID{s.acmeRole.ID}, invitation.RoleIDs)
+	s.Equal(s.acmeAdmin.ID, invitation.InvitedBy)
+	s.True(invitation.ExpiresAt.After(time.Now()))
+	s.Equal([]string{"invitation.create:success"}, s.audit.actions())
+
+	// Test case 2: The emailed link names the organization and carries a signed token
+	message := s.mailer.Messages()[0]
+	s.Equal("ada@example.com", message.To)
+	s.Contains(message.Subject, "Acme")
+	link := s.lastLink()
+	s.Equal("/auth/invitations/accept", link.Path)
+	s.Equal(s.acme.ID.Hex(), link.Query().Get("tenant"))
+	_, err = security.ParseToken(link.Query().Get("token"), security.TokenUseInvitation)
+	s.NoError(err)
+
+	// Test case 3: Roles of another organization are refused
+	_, err = s.useCase.Invite(s.as(s.acmeAdmin), s.acmeAdmin.ID.Hex(), s.acme.ID.Hex(), usecases.CreateInvitationInput{
+		Email:   "bob@example.com",
+		RoleIDs: []string{s.defaultRole.ID.Hex()},
+	})
+	s.ErrorIs(err, usecases.ErrInvalidInvitation)
+
+	// Test case 4: Members who are not admins cannot invite
+	_, err = s.useCase.Invite(s.as(s.acmeMember), s.acmeMember.ID.Hex(), s.acme.ID.Hex(), usecases.CreateInvitationInput{Email: "bob@example.com"})
+	s.ErrorIs(err, usecases.ErrNotOrganizationAdmin)
+	s.Len(s.mailer.Messages(), 1)
+}
+
+func (s *InvitationUseCaseTestSuite) TestAcceptCreatesUser() {
+	token := s.invite("ada@example.com", false)
+	ctx := s.in(s.acme)
+
+	// Test case 1: The invitation names the organization and has no account yet
+	details, err := s.useCase.ReadInvitation(ctx, token)
+	s.Require().NoError(err)
+	s.Equal("Acme", details.Organization.Name)
+	s.False(details.HasAccount)
+
+	// Test case 2: A name and an acceptable password are required, without consuming the invitation
+	_, _, err = s.useCase.AcceptInvitation(ctx, usecases.AcceptInvitationInput{Token: token, Password: "Correct-Horse-Battery-9"})
+	s.ErrorIs(err, usecases.ErrInvitationNameRequired)
+	_, _, err = s.useCase.AcceptInvitation(ctx, usecases.AcceptInvitationInput{Token: token, Name: "Ada", Password: "password"})
+	s.ErrorIs(err, security.ErrPasswordPolicy)
+	s.Len(s.invitations.invitations, 1)
+
+	// Test case 3: Accepting creates a verified, active user with the roles
+	user, created, err := s.useCase.AcceptInvitation(ctx, usecases.AcceptInvitationInput{Token: token, Name: "Ada", Password: "Correct-Horse-Battery-9"})
+	s.Require().NoError(err)
+	s.True(created)
+	s.Equal(s.acme.ID, user.TenantID)
+	s.Equal("ada@example.com", user.Email)
+	s.True(user.EmailVerified)
+	s.Equal(domain.UserStatusActive, user.CurrentStatus())
+	s.Equal([]primitive.ObjectID{s.acmeRole.ID}, user.RoleIDs)
+	s.NotEqual("Correct-Horse-Battery-9", user.Password)
+
+	// Test case 4: The link can only be used once
+	_, _, err = s.useCase.AcceptInvitation(ctx, usecases.AcceptInvitationInput{Token: token, Name: "Ada", Password: "Correct-Horse-Battery-9"})
+	s.ErrorIs(err, usecases.ErrInvalidInvitationToken)
+	s.Contains(s.audit.actions(), "invitation.accept:success")
+}
+
+func (s *InvitationUseCaseTestSuite) TestAcceptLinksExistingUser() {
+	token := s.invite(s.acmeMember.Email, true)
+	details, err := s.useCase.ReadInvitation(s.in(s.acme), token)
+	s.Require().NoError(err)
+	s.True(details.HasAccount)
+
+	// Test case 1: The existing account of the verified address gets the roles and admin rights
+	user, created, err := s.useCase.AcceptInvitation(s.in(s.acme), usecases.AcceptInvitationInput{Token: token})
+	s.Require().NoError(err)
+	s.False(created)
+	s.Equal(s.acmeMember.ID, user.ID)
+	s.Equal([]primitive.ObjectID{s.acmeRole.ID}, s.acmeMember.RoleIDs)
+	acme, _ := s.organizations.GetOrganization(context.Background(), s.acme.ID)
+	s.True(acme.IsAdmin(s.acmeMember.ID))
+	s.Len(s.users.users, 3)
+}
+
+func (s *InvitationUseCaseTestSuite) TestAcceptTakesOverUnverifiedAccount() {
+	// Someone registered the invitee's address first, without verifying it.
+	squatter := &domain.User{ID: primitive.NewObjectID(), TenantID: s.acme.ID, Email: "ada@example.com", Password: "squatter-hash", TOTPEnabled: true, TOTPSecret: "secret"}
+	squatter.StatusHistory = []domain.UserStatusChange{{To: domain.UserStatusPending}}
+	s.users.users = append(s.users.users, squatter)
+	_, err := s.sessions.CreateSession(s.in(s.acme), &domain.Session{UserID: squatter.ID})
+	s.Require().NoError(err)
+	s.credentials.credentials = []*domain.WebAuthnCredential{{ID: primitive.NewObjectID(), UserID: squatter.ID}}
+	token := s.invite("ada@example.com", true)
+
+	// Test case 1: The invitation asks for a password as if there were no account
+	details, err := s.useCase.ReadInvitation(s.in(s.acme), token)
+	s.Require().NoError(err)
+	s.False(details.HasAccount)
+
+	// Test case 2: Without a new password nothing is granted and the invitation is kept
+	_, _, err = s.useCase.AcceptInvitation(s.in(s.acme), usecases.AcceptInvitationInput{Token: token})
+	s.ErrorIs(err, security.ErrPasswordPolicy)
+	s.Len(s.invitations.invitations, 1)
+	s.False(squatter.EmailVerified)
+	s.Empty(squatter.RoleIDs)
+	acme, _ := s.organizations.GetOrganization(context.Background(), s.acme.ID)
+	s.False(acme.IsAdmin(squatter.ID))
+
+	// Test case 3: Accepting takes the account over, locking out whoever registered it
+	user, created, err := s.useCase.AcceptInvitation(s.in(s.acme), usecases.AcceptInvitationInput{Token: token, Password: "Correct-Horse-Battery-9"})
+	s.Require().NoError(err)
+	s.False(created)
+	s.Equal(squatter.ID, user.ID)
+	s.NotEqual("squatter-hash", user.Password)
+	s.True(user.EmailVerified)
+	s.Equal(domain.UserStatusActive, user.CurrentStatus())
+	s.False(user.TOTPEnabled)
+	s.Empty(user.TOTPSecret)
+	s.False(user.TokensRevokedAt.IsZero())
+	s.Empty(s.sessions.sessions)
+	s.Empty(s.credentials.credentials)
+	s.Equal([]primitive.ObjectID{s.acmeRole.ID}, user.RoleIDs)
+	acme, _ = s.organizations.GetOrganization(context.Background(), s.acme.ID)
+	s.True(acme.IsAdmin(squatter.ID))
+}
+
+func (s *InvitationUseCaseTestSuite) TestInvalidLinks() {
+	token := s.invite("ada@example.com", false)
+
+	// Test case 1: A link opened in another organization is refused
+	_, err := s.useCase.ReadInvitation(s.in(s.defaultOrg), token)
+	s.ErrorIs(err, usecases.ErrInvalidInvitationToken)
+
+	// Test case 2: Inviting the address again replaces the earlier link
+	newToken := s.invite("ada@example.com", false)
+	_, err = s.useCase.ReadInvitation(s.in(s.acme), token)
+	s.ErrorIs(err, usecases.ErrInvalidInvitationToken)
+	_, err = s.useCase.ReadInvitation(s.in(s.acme), newToken)
+	s.NoError(err)
+
+	// Test case 3: Expired invitations are refused
+	s.invitations.invitations[0].ExpiresAt = time.Now().Add(-time.Minute)
+	_, err = s.useCase.ReadInvitation(s.in(s.acme), newToken)
+	s.ErrorIs(err, usecases.ErrInvalidInvitationToken)
+
+	// Test case 4: Tokens signed for another use are refused
+	other, err := security.SignToken(security.TokenUseVerifyEmail, map[string]interface{}{"sub": s.invitations.invitations[0].ID.Hex(), "email": "ada@example.com"}, time.Hour)
+	s.Require().NoError(err)
+	_, err = s.useCase.ReadInvitation(s.in(s.acme), other)
+	s.ErrorIs(err, usecases.ErrInvalidInvitationToken)
+}
+
+func (s *InvitationUseCaseTestSuite) TestListAndRevoke() {
+	token := s.invite("ada@example.com", false)
+	ctx := s.as(s.acmeAdmin)
+
+	// Test case 1: Pending invitations are listed
+	invitations, err := s.useCase.ListInvitations(ctx, s.acmeAdmin.ID.Hex(), s.acme.ID.Hex())
+	s.Require().NoError(err)
+	s.Require().Len(invitations, 1)
+
+	// Test case 2: Revoking an invitation stops its link from working
+	s.NoError(s.useCase.RevokeInvitation(ctx, s.acmeAdmin.ID.Hex(), s.acme.ID.Hex(), invitations[0].ID.Hex()))
+	_, err = s.useCase.ReadInvitation(s.in(s.acme), token)
+	s.ErrorIs(err, usecases.ErrInvalidInvitationToken)
+	invitations, err = s.useCase.ListInvitations(ctx, s.acmeAdmin.ID.Hex(), s.acme.ID.Hex())
+	s.NoError(err)
+	s.Empty(invitations)
+	s.Contains(s.audit.actions(), "invitation.revoke:success")
+
+	// Test case 3: Unknown invitations are not found
+	err = s.useCase.RevokeInvitation(ctx, s.acmeAdmin.ID.Hex(), s.acme.ID.Hex(), primitive.NewObjectID().Hex())
+	s.ErrorIs(err, usecases.ErrInvitationNotFound)
+}
+
+func (s *InvitationUseCaseTestSuite) TestFirstAdminOfNewOrganization() {
+	globex := &domain.Organization{Name: "Globex", Slug: "globex"}
+	globex.ID, _ = s.organizations.CreateOrganization(context.Background(), globex)
+
+	// Test case 1: A platform admin invites the first admin of an organization without members
+	_, err := s.useCase.Invite(s.as(s.platformAdmin), s.platformAdmin.ID.Hex(), globex.ID.Hex(), usecases.CreateInvitationInput{Email: "hank@globex.example", Admin: true})
+	s.Require().NoError(err)
+	token := s.lastLink().Query().Get("token")
+
+	// Test case 2: Accepting creates the user in the organization and makes them its admin
+	user, created, err := s.useCase.AcceptInvitation(s.in(globex), usecases.AcceptInvitationInput{Token: token, Name: "Hank", Password: "Correct-Horse-Battery-9"})
+	s.Require().NoError(err)
+	s.True(created)
+	s.Equal(globex.ID, user.TenantID)
+	globex, _ = s.organizations.GetOrganization(context.Background(), globex.ID)
+	s.Equal([]primitive.ObjectID{user.ID}, globex.AdminIDs)
+}
+
+func TestInvitationUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(InvitationUseCaseTestSuite))
+}
@@ -0,0 +1,182 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"veritas/core/domain"
+	"veritas/internal/ports/output"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// LoginProtectionPolicy configures brute-force protection.
+type LoginProtectionPolicy struct {
+	AccountThreshold int           // failures that lock an account
+	IPThreshold      int           // failures that lock a source IP
+	Window           time.Duration // how long a failure counts
+	LockoutDuration  time.Duration
+	BaseDelay        time.Duration // wait after the first failure, doubled after each further one
+	MaxDelay         time.Duration
+}
+
+// LoginThrottledError is returned while an account or IP must wait before
+// trying again.
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return "too many failed login attempts, try again later"
+}
+
+type LoginProtectionUsecase struct {
+	attempts output.LoginAttemptOutputPort
+	users    output.UserOutputPort
+	events   output.EventPublisher
+	audit    output.AuditLogger
+	policy   LoginProtectionPolicy
+}
+
+func NewLoginProtectionUsecase(attempts output.LoginAttemptOutputPort, users output.UserOutputPort, events output.EventPublisher, audit output.AuditLogger, policy LoginProtectionPolicy) *LoginProtectionUsecase {
+	return &LoginProtectionUsecase{attempts: attempts, users: users, events: events, audit: audit, policy: policy}
+}
+
+// Check returns a *LoginThrottledError when the account or the source IP is
+// locked or still has to wait after a failure. Accounts are tracked by email
+// address, so unknown addresses are treated exactly like registered ones.
+func (uc *LoginProtectionUsecase) Check(ctx context.Context, email string, ip string) error {
+	now := time.Now()
+	var wait time.Duration
+	for _, key := range []string{accountKey(ctx, email), ipKey(ip)} {
+		attempt, err := uc.attempts.GetAttempt(ctx, key)
+		if err != nil {
+			continue
+		}
+		if d := attempt.LockedUntil.Sub(now); d > wait {
+			wait = d
+		}
+		if attempt.Failures > 0 && now.Sub(attempt.LastFailureAt) < uc.policy.Window {
+			if d := attempt.LastFailureAt.Add(uc.delay(attempt.Failures)).Sub(now); d > wait {
+				wait = d
+			}
+		}
+	}
+
+	if wait > 0 {
+		return &LoginThrottledError{RetryAfter: wait}
+	}
+	return nil
+}
+
+// RecordFailure counts a failed login for the account and the source IP, and
+// locks either once it reaches its threshold.
+func (uc *LoginProtectionUsecase) RecordFailure(ctx context.Context, email string, ip string) error {
+	attempt, err := uc.attempts.RecordFailure(ctx, accountKey(ctx, email), uc.policy.Window)
+	if err != nil {
+		return err
+	}
+	if attempt.Failures >= uc.policy.AccountThreshold {
+		event := &domain.Event{Type: domain.EventAccountLocked, Data: map[string]string{"email": normalizeEmail(email), "ip": ip}}
+		entry := &domain.AuditEntry{Category: domain.AuditCategoryAuthentication, Action: "auth.lockout", TargetType: "email", TargetID: normalizeEmail(email)}
+		if user, err := uc.users.GetUserByEmail(ctx, email); err == nil {
+			event.UserID = user.ID.Hex()
+			entry.TargetType, entry.TargetID = "user", user.ID.Hex()
+		}
+		if err := uc.lock(ctx, attempt.Key, event, entry); err != nil {
+			return err
+		}
+	}
+
+	attempt, err = uc.attempts.RecordFailure(ctx, ipKey(ip), uc.policy.Window)
+	if err != nil {
+		return err
+	}
+	if attempt.Failures >= uc.policy.IPThreshold {
+		event := &domain.Event{Type: domain.EventIPLocked, Data: map[string]string{"ip": ip}}
+		entry := &domain.AuditEntry{Category: domain.AuditCategoryAuthentication, Action: "auth.lockout", TargetType: "ip", TargetID: ip}
+		if err := uc.lock(ctx, attempt.Key, event, entry); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RecordSuccess clears the failures of the account. Failures of the source
+// IP are kept, so one valid account cannot be used to reset them.
+func (uc *LoginProtectionUsecase) RecordSuccess(ctx context.Context, email string) error {
+	return uc.attempts.DeleteAttempt(ctx, accountKey(ctx, email))
+}
+
+// Unlock lifts a lockout of a user's account, for administrators.
+func (uc *LoginProtectionUsecase) Unlock(ctx context.Context, userID string) error {
+	user, err := uc.userByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := uc.attempts.DeleteAttempt(ctx, accountKey(ctx, user.Email)); err != nil {
+		return err
+	}
+	recordAudit(ctx, uc.audit, userAuditEntry(domain.AuditCategoryAdmin, "user.unlock", user.ID))
+	return nil
+}
+
+func (uc *LoginProtectionUsecase) lock(ctx context.Context, key string, event *domain.Event, entry *domain.AuditEntry) error {
+	until := time.Now().Add(uc.policy.LockoutDuration)
+	if err := uc.attempts.Lock(ctx, key, until); err != nil {
+		return err
+	}
+	entry.Reason = "locked until " + until.UTC().Format(time.RFC3339)
+	recordAudit(ctx, uc.audit, entry)
+
+	event.Time = time.Now()
+	event.Data["lockedUntil"] = until.UTC().Format(time.RFC3339)
+	if err := uc.events.Publish(ctx, event); err != nil {
+		log.Printf("failed to publish %s event: %v", event.Type, err)
+	}
+	return nil
+}
+
+// delay returns the progressive wait after the given number of failures.
+func (uc *LoginProtectionUsecase) delay(failures int) time.Duration {
+	delay := uc.policy.BaseDelay
+	for i := 1; i < failures && delay < uc.policy.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > uc.policy.MaxDelay {
+		delay = uc.policy.MaxDelay
+	}
+	return delay
+}
+
+func (uc *LoginProtectionUsecase) userByID(ctx context.Context, id string) (*domain.User, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id: %w", err)
+	}
+	user, err := uc.users.GetUser(ctx, objectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user, nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// accountKey tracks an email address within the organization of ctx, so the
+// same address in another organization is a different account.
+func accountKey(ctx context.Context, email string) string {
+	if tenantID, ok := domain.TenantFromContext(ctx); ok {
+		return "account:" + tenantID.Hex() + ":" + normalizeEmail(email)
+	}
+	return "account:" + normalizeEmail(email)
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
@@ -0,0 +1,148 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"veritas/core/domain"
+	"veritas/core/usecases"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeLoginAttempts keeps login attempts in memory.
+type fakeLoginAttempts struct {
+	attempts map[string]*domain.LoginAttempt
+}
+
+func (f *fakeLoginAttempts) GetAttempt(ctx context.Context, key string) (*domain.LoginAttempt, error) {
+	attempt, ok := f.attempts[key]
+	if !ok {
+		return nil, errors.New("login attempt not found")
+	}
+	copied := *attempt
+	return &copied, nil
+}
+
+func (f *fakeLoginAttempts) RecordFailure(ctx context.Context, key string, window time.Duration) (*domain.LoginAttempt, error) {
+	attempt, ok := f.attempts[key]
+	if !ok {
+		attempt = &domain.LoginAttempt{Key: key}
+		f.attempts[key] = attempt
+	}
+	if time.Since(attempt.LastFailureAt) > window {
+		attempt.Failures = 0
+	}
+	attempt.Failures++
+	attempt.LastFailureAt = time.Now()
+	copied := *attempt
+	return &copied, nil
+}
+
+func (f *fakeLoginAttempts) Lock(ctx context.Context, key string, until time.Time) error {
+	f.attempts[key].LockedUntil = until
+	f.attempts[key].Failures = 0
+	return nil
+}
+
+func (f *fakeLoginAttempts) DeleteAttempt(ctx context.Context, key string) error {
+	delete(f.attempts, key)
+	return nil
+}
+
+type MockEventPublisher struct {
+	mock.Mock
+}
+
+func (m *MockEventPublisher) Publish(ctx context.Context, event *domain.Event) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+type LoginProtectionUseCaseTestSuite struct {
+	suite.Suite
+	mockOutputPort *MockUserOutputPort
+	mockEvents     *MockEventPublisher
+	attempts       *fakeLoginAttempts
+	useCase        *usecases.LoginProtectionUsecase
+	audit          *fakeAuditLog
+	ctx            context.Context
+	user           *domain.User
+}
+
+func (s *LoginProtectionUseCaseTestSuite) SetupTest() {
+	s.audit = &fakeAuditLog{}
+	s.mockOutputPort = new(MockUserOutputPort)
+	s.mockEvents = new(MockEventPublisher)
+	s.attempts = &fakeLoginAttempts{attempts: map[string]*domain.LoginAttempt{}}
+	s.useCase = usecases.NewLoginProtectionUsecase(s.attempts, s.mockOutputPort, s.mockEvents, s.audit, usecases.LoginProtectionPolicy{
+		AccountThreshold: 3,
+		IPThreshold:      5,
+		Window:           time.Minute,
+		LockoutDuration:  time.Hour,
+		BaseDelay:        time.Second,
+		MaxDelay:         4 * time.Second,
+	})
+	s.ctx = context.Background()
+	s.user = &domain.User{ID: primitive.NewObjectID(), Email: "test@example.com"}
+	s.mockOutputPort.On("GetUserByEmail", s.ctx, s.user.Email).Return(s.user, nil)
+	s.mockOutputPort.On("GetUser", s.ctx, s.user.ID).Return(s.user, nil)
+}
+
+func (s *LoginProtectionUseCaseTestSuite) TestProgressiveDelayAndLockout() {
+	// Test case 1: No failures, no delay
+	s.NoError(s.useCase.Check(s.ctx, s.user.Email, "192.0.2.1"))
+
+	// Test case 2: A failure imposes a delay
+	s.NoError(s.useCase.RecordFailure(s.ctx, s.user.Email, "192.0.2.1"))
+	var throttled *usecases.LoginThrottledError
+	s.Require().ErrorAs(s.useCase.Check(s.ctx, "TEST@example.com", "198.51.100.1"), &throttled)
+	s.InDelta(time.Second, throttled.RetryAfter, float64(100*time.Millisecond))
+
+	// Test case 3: Reaching the threshold locks the account and emits an event
+	s.mockEvents.On("Publish", s.ctx, mock.MatchedBy(func(event *domain.Event) bool {
+		return event.Type == domain.EventAccountLocked && event.UserID == s.user.ID.Hex()
+	})).Return(nil).Once()
+	s.NoError(s.useCase.RecordFailure(s.ctx, s.user.Email, "192.0.2.1"))
+	s.NoError(s.useCase.RecordFailure(s.ctx, s.user.Email, "192.0.2.1"))
+	s.mockEvents.AssertExpectations(s.T())
+	s.Require().ErrorAs(s.useCase.Check(s.ctx, s.user.Email, "198.51.100.1"), &throttled)
+	s.Greater(throttled.RetryAfter, 59*time.Minute)
+
+	// Test case 4: An administrator can unlock the account
+	s.NoError(s.useCase.Unlock(s.ctx, s.user.ID.Hex()))
+	s.NoError(s.useCase.Check(s.ctx, s.user.Email, "198.51.100.1"))
+
+	// Test case 5: The lockout and the unlock are audited
+	s.Equal([]string{"auth.lockout:success", "user.unlock:success"}, s.audit.actions())
+	s.Equal(s.user.ID.Hex(), s.audit.entries[0].TargetID)
+}
+
+func (s *LoginProtectionUseCaseTestSuite) TestIPLockout() {
+	s.mockOutputPort.On("GetUserByEmail", s.ctx, mock.Anything).Return(nil, errors.New("user not found"))
+	s.mockEvents.On("Publish", s.ctx, mock.MatchedBy(func(event *domain.Event) bool {
+		return event.Type == domain.EventIPLocked
+	})).Return(nil).Once()
+
+	// Test case 1: Failures across many accounts lock the source IP
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com", "d@example.com", "e@example.com"} {
+		s.NoError(s.useCase.RecordFailure(s.ctx, email, "192.0.2.1"))
+	}
+	s.mockEvents.AssertExpectations(s.T())
+	var throttled *usecases.LoginThrottledError
+	s.ErrorAs(s.useCase.Check(s.ctx, "f@example.com", "192.0.2.1"), &throttled)
+
+	// Test case 2: Other IPs are not affected
+	s.NoError(s.useCase.Check(s.ctx, "f@example.com", "198.51.100.1"))
+
+	// Test case 3: A successful login does not clear the IP failures
+	s.NoError(s.useCase.RecordSuccess(s.ctx, "f@example.com"))
+	s.ErrorAs(s.useCase.Check(s.ctx, "f@example.com", "192.0.2.1"), &throttled)
+}
+
+func TestLoginProtectionUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(LoginProtectionUseCaseTestSuite))
+}
@@ -0,0 +1,238 @@
+package usecases
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"veritas/core/domain"
+	"veritas/internal/ports/output"
+	"veritas/internal/security"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const recoveryCodeCount = 10
+
+var (
+	ErrTOTPAlreadyEnabled = errors.New("totp is already enabled")
+	ErrTOTPNotEnrolled    = errors.New("totp enrollment has not been started")
+	ErrInvalidMFACode     = errors.New("invalid verification code")
+)
+
+type MFAUsecase struct {
+	repo          output.UserOutputPort
+	audit         output.AuditLogger
+	encryptionKey []byte
+	issuer        string
+}
+
+func NewMFAUsecase(repo output.UserOutputPort, audit output.AuditLogger, encryptionKey []byte, issuer string) *MFAUsecase {
+	return &MFAUsecase{repo: repo, audit: audit, encryptionKey: encryptionKey, issuer: issuer}
+}
+
+type TOTPEnrollment struct {
+	Secret string
+	URI    string
+}
+
+// EnrollTOTP generates a new secret for the user. It is not used for login
+// until confirmed with ConfirmTOTP.
+func (uc *MFAUsecase) EnrollTOTP(ctx context.Context, userID string) (*TOTPEnrollment, error) {
+	objectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id: %w", err)
+	}
+
+	user, err := uc.repo.GetUser(ctx, objectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.TOTPEnabled {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+
+	secret, err := security.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	user.TOTPSecret, err = security.Encrypt(uc.encryptionKey, secret)
+	if err != nil {
+		return nil, err
+	}
+	user.UpdatedAt = time.Now()
+
+	if err := uc.repo.UpdateUser(ctx, objectID, user); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, uc.audit, userAuditEntry(domain.AuditCategoryAccount, "mfa.totp_enroll", user.ID))
+
+	return &TOTPEnrollment{
+		Secret: secret,
+		URI:    security.TOTPURI(uc.issuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmTOTP enables TOTP once the user proves their authenticator works and
+// returns a fresh set of single-use recovery codes.
+func (uc *MFAUsecase) ConfirmTOTP(ctx context.Context, userID string, code string) ([]string, error) {
+	objectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id: %w", err)
+	}
+
+	user, err := uc.repo.GetUser(ctx, objectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.TOTPEnabled {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, ErrTOTPNotEnrolled
+	}
+
+	entry := userAuditEntry(domain.AuditCategoryAccount, "mfa.totp_confirm", user.ID)
+	if err := uc.checkTOTP(user, code); err != nil {
+		recordAudit(ctx, uc.audit, failed(entry, err.Error()))
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	user.TOTPEnabled = true
+	user.RecoveryCodes = hashes
+	user.UpdatedAt = time.Now()
+
+	if err := uc.repo.UpdateUser(ctx, objectID, user); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, uc.audit, entry)
+
+	return codes, nil
+}
+
+// VerifyTOTP checks a second-factor code during login. Each time step is
+// accepted only once.
+func (uc *MFAUsecase) VerifyTOTP(ctx context.Context, user *domain.User, code string) error {
+	entry := userAuditEntry(domain.AuditCategoryAuthentication, "auth.totp", user.ID)
+	if !user.TOTPEnabled {
+		recordAudit(ctx, uc.audit, failed(entry, ErrInvalidMFACode.Error()))
+		return ErrInvalidMFACode
+	}
+	if err := uc.checkTOTP(user, code); err != nil {
+		recordAudit(ctx, uc.audit, failed(entry, err.Error()))
+		return err
+	}
+	user.UpdatedAt = time.Now()
+	if err := uc.repo.UpdateUser(ctx, user.ID, user); err != nil {
+		return err
+	}
+	recordAudit(ctx, uc.audit, entry)
+	return nil
+}
+
+// VerifyRecoveryCode consumes one of the user's recovery codes.
+func (uc *MFAUsecase) VerifyRecoveryCode(ctx context.Context, user *domain.User, code string) error {
+	hash := security.HashToken(normalizeRecoveryCode(code))
+
+	match := -1
+	for i, stored := range user.RecoveryCodes {
+		if subtle.ConstantTimeCompare([]byte(stored), []byte(hash)) == 1 {
+			match = i
+		}
+	}
+	entry := userAuditEntry(domain.AuditCategoryAuthentication, "auth.recovery_code", user.ID)
+	if match < 0 {
+		recordAudit(ctx, uc.audit, failed(entry, ErrInvalidMFACode.Error()))
+		return ErrInvalidMFACode
+	}
+
+	user.RecoveryCodes = append(user.RecoveryCodes[:match], user.RecoveryCodes[match+1:]...)
+	user.UpdatedAt = time.Now()
+	if err := uc.repo.UpdateUser(ctx, user.ID, user); err != nil {
+		return err
+	}
+	recordAudit(ctx, uc.audit, entry)
+	return nil
+}
+
+// ResetMFA removes every second factor from the user, for administrators
+// helping someone who lost their device and recovery codes.
+func (uc *MFAUsecase) ResetMFA(ctx context.Context, userID string) error {
+	objectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return fmt.Errorf("invalid id: %w", err)
+	}
+
+	user, err := uc.repo.GetUser(ctx, objectID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	clearSecondFactors(user)
+	user.UpdatedAt = time.Now()
+
+	if err := uc.repo.UpdateUser(ctx, objectID, user); err != nil {
+		return err
+	}
+	entry := userAuditEntry(domain.AuditCategoryAdmin, "mfa.reset", user.ID)
+	recordAudit(ctx, uc.audit, entry)
+	return nil
+}
+
+// clearSecondFactors removes the TOTP authenticator, recovery codes and
+// phone second factor of a user.
+func clearSecondFactors(user *domain.User) {
+	user.TOTPSecret = ""
+	user.TOTPEnabled = false
+	user.TOTPLastUsedStep = 0
+	user.RecoveryCodes = nil
+	user.PhoneVerified = false
+}
+
+// RequiresMFA reports whether the user must complete a second factor after
+// their password.
+func (uc *MFAUsecase) RequiresMFA(user *domain.User) bool {
+	return user.TOTPEnabled
+}
+
+func (uc *MFAUsecase) checkTOTP(user *domain.User, code string) error {
+	secret, err := security.Decrypt(uc.encryptionKey, user.TOTPSecret)
+	if err != nil {
+		return fmt.Errorf("failed to read totp secret: %w", err)
+	}
+
+	step, ok := security.ValidateTOTP(secret, code, time.Now())
+	if !ok || step <= user.TOTPLastUsedStep {
+		return ErrInvalidMFACode
+	}
+	user.TOTPLastUsedStep = step
+	return nil
+}
+
+// generateRecoveryCodes returns codes formatted for display together with the
+// hashes that are stored.
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw, err := security.RandomBytes(6)
+		if err != nil {
+			return nil, nil, err
+		}
+		code := fmt.Sprintf("%x", raw)
+		codes = append(codes, code[:4]+"-"+code[4:8]+"-"+code[8:])
+		hashes = append(hashes, security.HashToken(code))
+	}
+	return codes, hashes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.ReplaceAll(code, "-", "")
+}
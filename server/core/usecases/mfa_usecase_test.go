@@ -0,0 +1,100 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"veritas/core/domain"
+	"veritas/core/usecases"
+	"veritas/internal/security"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type MFAUseCaseTestSuite struct {
+	suite.Suite
+	mockOutputPort *MockUserOutputPort
+	mfaUseCase     *usecases.MFAUsecase
+	audit          *fakeAuditLog
+	ctx            context.Context
+	user           *domain.User
+}
+
+func (s *MFAUseCaseTestSuite) SetupTest() {
+	s.audit = &fakeAuditLog{}
+	s.mockOutputPort = new(MockUserOutputPort)
+	s.mfaUseCase = usecases.NewMFAUsecase(s.mockOutputPort, s.audit, make([]byte, 32), "Veritas")
+	s.ctx = context.Background()
+	s.user = &domain.User{
+		ID:    primitive.NewObjectID(),
+		Email: "test@example.com",
+	}
+}
+
+func (s *MFAUseCaseTestSuite) TestEnrollAndConfirmTOTP() {
+	s.mockOutputPort.On("GetUser", s.ctx, s.user.ID).Return(s.user, nil)
+	s.mockOutputPort.On("UpdateUser", s.ctx, s.user.ID, s.user).Return(nil)
+
+	// Test case 1: Enrollment stores an encrypted secret without enabling it
+	enrollment, err := s.mfaUseCase.EnrollTOTP(s.ctx, s.user.ID.Hex())
+	s.NoError(err)
+	s.Contains(enrollment.URI, "otpauth://totp/Veritas:test@example.com")
+	s.NotEqual(enrollment.Secret, s.user.TOTPSecret)
+	s.False(s.user.TOTPEnabled)
+
+	// Test case 2: A wrong code does not enable TOTP
+	_, err = s.mfaUseCase.ConfirmTOTP(s.ctx, s.user.ID.Hex(), "000000")
+	s.ErrorIs(err, usecases.ErrInvalidMFACode)
+	s.False(s.user.TOTPEnabled)
+
+	// Test case 3: The current code enables TOTP and returns recovery codes
+	code, err := security.TOTPCode(enrollment.Secret, time.Now())
+	s.Require().NoError(err)
+	codes, err := s.mfaUseCase.ConfirmTOTP(s.ctx, s.user.ID.Hex(), code)
+	s.NoError(err)
+	s.Len(codes, 10)
+	s.True(s.user.TOTPEnabled)
+	s.True(s.mfaUseCase.RequiresMFA(s.user))
+
+	// Test case 4: The same code cannot be replayed at login
+	err = s.mfaUseCase.VerifyTOTP(s.ctx, s.user, code)
+	s.ErrorIs(err, usecases.ErrInvalidMFACode)
+
+	// Test case 5: Recovery codes are single use
+	err = s.mfaUseCase.VerifyRecoveryCode(s.ctx, s.user, codes[0])
+	s.NoError(err)
+	s.Len(s.user.RecoveryCodes, 9)
+	err = s.mfaUseCase.VerifyRecoveryCode(s.ctx, s.user, codes[0])
+	s.ErrorIs(err, usecases.ErrInvalidMFACode)
+
+	// Test case 6: Enrollment and every verification attempt are audited
+	s.Equal([]string{
+		"mfa.totp_enroll:success",
+		"mfa.totp_confirm:failure",
+		"mfa.totp_confirm:success",
+		"auth.totp:failure",
+		"auth.recovery_code:success",
+		"auth.recovery_code:failure",
+	}, s.audit.actions())
+}
+
+func (s *MFAUseCaseTestSuite) TestResetMFA() {
+	s.user.TOTPEnabled = true
+	s.user.TOTPSecret = "secret"
+	s.user.RecoveryCodes = []string{"hash"}
+	s.mockOutputPort.On("GetUser", s.ctx, s.user.ID).Return(s.user, nil).Once()
+	s.mockOutputPort.On("UpdateUser", s.ctx, s.user.ID, mock.AnythingOfType("*domain.User")).Return(nil).Once()
+
+	err := s.mfaUseCase.ResetMFA(s.ctx, s.user.ID.Hex())
+	s.NoError(err)
+	s.False(s.user.TOTPEnabled)
+	s.Empty(s.user.TOTPSecret)
+	s.Empty(s.user.RecoveryCodes)
+	s.mockOutputPort.AssertExpectations(s.T())
+}
+
+func TestMFAUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(MFAUseCaseTestSuite))
+}
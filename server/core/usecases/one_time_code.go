@@ -0,0 +1,37 @@
+package usecases
+
+import (
+	"context"
+	"crypto/subtle"
+	"time"
+	"veritas/core/domain"
+	"veritas/internal/ports/output"
+)
+
+// maxCodeAttempts is how many times a one-time code can be tried before it
+// is discarded.
+const maxCodeAttempts = 5
+
+// redeemOneTimeToken checks hash against a stored token and consumes it on a
+// match. Every call counts as an attempt, and the token is discarded once the
+// attempts are used up. It reports whether the token was redeemed.
+func redeemOneTimeToken(ctx context.Context, tokens output.OneTimeTokenOutputPort, stored *domain.OneTimeToken, hash string) bool {
+	if time.Now().After(stored.ExpiresAt) {
+		return false
+	}
+
+	attempts, err := tokens.IncrementAttempts(ctx, stored.ID)
+	if err != nil {
+		return false
+	}
+	if attempts > maxCodeAttempts || subtle.ConstantTimeCompare([]byte(hash), []byte(stored.TokenHash)) != 1 {
+		if attempts >= maxCodeAttempts {
+			tokens.ConsumeToken(ctx, stored.TokenHash, stored.Purpose)
+		}
+		return false
+	}
+
+	// Consuming is atomic, so concurrent requests cannot both succeed.
+	_, err = tokens.ConsumeToken(ctx, stored.TokenHash, stored.Purpose)
+	return err == nil
+}
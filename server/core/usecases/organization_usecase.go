@@ -0,0 +1,442 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"slices"
+	"strings"
+	"veritas/core/domain"
+	"veritas/internal/ports/output"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrOrganizationNotFound  = errors.New("organization not found")
+	ErrOrganizationExists    = errors.New("organization slug or domain is already in use")
+	ErrInvalidOrganization   = errors.New("invalid organization")
+	ErrNotOrganizationAdmin  = errors.New("organization admin rights required")
+	ErrNotOrganizationMember = errors.New("user is not a member of the organization")
+	ErrLastOrganizationAdmin = errors.New("an organization must keep at least one admin")
+)
+
+var (
+	slugPattern   = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+	domainPattern = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)*[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+)
+
+const (
+	maxOrganizationNameLength = 100
+	maxOrganizationDomains    = 20
+)
+
+type OrganizationUsecase struct {
+	repo  output.OrganizationOutputPort
+	users output.UserOutputPort
+	audit output.AuditLogger
+}
+
+func NewOrganizationUsecase(repo output.OrganizationOutputPort, users output.UserOutputPort, audit output.AuditLogger) *OrganizationUsecase {
+	return &OrganizationUsecase{repo: repo, users: users, audit: audit}
+}
+
+// EnsureDefaultOrganization returns the default organization, creating it
+// on first start.
+func (uc *OrganizationUsecase) EnsureDefaultOrganization(ctx context.Context) (*domain.Organization, error) {
+	organization, err := uc.repo.GetOrganizationBySlug(ctx, domain.DefaultOrganizationSlug)
+	if err != nil || organization != nil {
+		return organization, err
+	}
+
+	organization = &domain.Organization{Name: "Default", Slug: domain.DefaultOrganizationSlug}
+	id, err := uc.repo.CreateOrganization(ctx, organization)
+	if err != nil {
+		return nil, err
+	}
+	organization.ID = id
+	return organization, nil
+}
+
+// SeedAdmins makes the members of an organization with the given email
+// addresses its admins. Addresses without an account are skipped, so they
+// can be listed before the users sign up, and so are accounts whose address
+// is not verified: anyone can sign up with an address they do not own.
+func (uc *OrganizationUsecase) SeedAdmins(ctx context.Context, organization *domain.Organization, emails []string) error {
+	tenantCtx := domain.WithTenant(ctx, organization.ID)
+	for _, email := range emails {
+		user, err := uc.users.GetUserByEmail(tenantCtx, normalizeEmail(email))
+		if err != nil {
+			continue
+		}
+		if !user.EmailVerified {
+			log.Printf("not making %s an admin of %s: email address not verified", user.Email, organization.Slug)
+			continue
+		}
+		if err := uc.addAdmin(ctx, organization, user.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ResolveTenant finds the organization a request is made in. ref, from the
+// request, is an organization's ID or slug. Without it the organization
+// serving host is used, and failing that the default organization.
+func (uc *OrganizationUsecase) ResolveTenant(ctx context.Context, ref string, host string) (*domain.Organization, error) {
+	var organization *domain.Organization
+	var err error
+	switch {
+	case ref != "":
+		if id, idErr := primitive.ObjectIDFromHex(ref); idErr == nil {
+			organization, err = uc.repo.GetOrganization(ctx, id)
+		} else {
+			organization, err = uc.repo.GetOrganizationBySlug(ctx, strings.ToLower(ref))
+		}
+	default:
+		if host != "" {
+			organization, err = uc.repo.GetOrganizationByDomain(ctx, strings.ToLower(host))
+		}
+		if err == nil && organization == nil {
+			organization, err = uc.repo.GetOrganizationBySlug(ctx, domain.DefaultOrganizationSlug)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if organization == nil {
+		return nil, ErrOrganizationNotFound
+	}
+	return organization, nil
+}
+
+// CurrentOrganization returns the organization ctx is scoped to.
+func (uc *OrganizationUsecase) CurrentOrganization(ctx context.Context) (*domain.Organization, error) {
+	tenantID, ok := domain.TenantFromContext(ctx)
+	if !ok {
+		return nil, ErrOrganizationNotFound
+	}
+	return uc.organization(ctx, tenantID)
+}
+
+// CheckAdmin returns ErrNotOrganizationAdmin unless the actor, a member of
+// the organization ctx is scoped to, may manage organization: either as
+// one of its admins, or as an admin of the default organization.
+func (uc *OrganizationUsecase) CheckAdmin(ctx context.Context, actorID string, organization *domain.Organization) error {
+	actor, err := primitive.ObjectIDFromHex(actorID)
+	if err != nil {
+		return ErrNotOrganizationAdmin
+	}
+	tenantID, _ := domain.TenantFromContext(ctx)
+	if tenantID == organization.ID && organization.IsAdmin(actor) {
+		return nil
+	}
+	return uc.checkPlatformAdmin(ctx, actorID)
+}
+
+type CreateOrganizationInput struct {
+	Name    string
+	Slug    string
+	Domains []string
+}
+
+// CreateOrganization creates an organization, for admins of the default
+// organization. It has no members until users sign up or are invited to it.
+func (uc *OrganizationUsecase) CreateOrganization(ctx context.Context, actorID string, input CreateOrganizationInput) (*domain.Organization, error) {
+	if err := uc.checkPlatformAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	organization := &domain.Organization{
+		Name:    strings.TrimSpace(input.Name),
+		Slug:    strings.ToLower(strings.TrimSpace(input.Slug)),
+		Domains: normalizeDomains(input.Domains),
+	}
+	if err := validateOrganization(organization); err != nil {
+		return nil, err
+	}
+	if err := uc.checkAvailable(ctx, organization); err != nil {
+		return nil, err
+	}
+
+	id, err := uc.repo.CreateOrganization(ctx, organization)
+	if err != nil {
+		return nil, err
+	}
+	organization.ID = id
+
+	recordAudit(ctx, uc.audit, &domain.AuditEntry{
+		Category:   domain.AuditCategoryAdmin,
+		Action:     "organization.create",
+		TargetType: "organization",
+		TargetID:   id.Hex(),
+		Changes: []domain.AuditChange{
+			{Field: "name", After: organization.Name},
+			{Field: "slug", After: organization.Slug},
+			{Field: "domains", After: strings.Join(organization.Domains, ",")},
+		},
+	})
+	return organization, nil
+}
+
+// ListOrganizations returns every organization, for admins of the default
+// organization.
+func (uc *OrganizationUsecase) ListOrganizations(ctx context.Context, actorID string) ([]*domain.Organization, error) {
+	if err := uc.checkPlatformAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+	return uc.repo.GetAllOrganizations(ctx)
+}
+
+// ReadOrganization returns an organization the actor may manage.
+func (uc *OrganizationUsecase) ReadOrganization(ctx context.Context, actorID string, id string) (*domain.Organization, error) {
+	organization, err := uc.organizationByHex(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.CheckAdmin(ctx, actorID, organization); err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+type UpdateOrganizationInput struct {
+	Name    string
+	Domains []string
+}
+
+// UpdateOrganization renames an organization and replaces its domains. The
+// slug cannot change, since clients name the organization by it. Only
+// platform admins change domains: unauthenticated requests are resolved to
+// an organization by host, so a domain claimed by one organization would
+// take over signup, login and password reset on that host.
+func (uc *OrganizationUsecase) UpdateOrganization(ctx context.Context, actorID string, id string, input UpdateOrganizationInput) (*domain.Organization, error) {
+	organization, err := uc.ReadOrganization(ctx, actorID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *organization
+	organization.Name = strings.TrimSpace(input.Name)
+	organization.Domains = normalizeDomains(input.Domains)
+	if !slices.Equal(before.Domains, organization.Domains) {
+		if err := uc.checkPlatformAdmin(ctx, actorID); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateOrganization(organization); err != nil {
+		return nil, err
+	}
+	if err := uc.checkAvailable(ctx, organization); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.UpdateOrganization(ctx, organization.ID, organization); err != nil {
+		return nil, err
+	}
+
+	var changes []domain.AuditChange
+	changes = appendChange(changes, "name", before.Name, organization.Name)
+	changes = appendChange(changes, "domains", strings.Join(before.Domains, ","), strings.Join(organization.Domains, ","))
+	recordAudit(ctx, uc.audit, &domain.AuditEntry{
+		Category:   domain.AuditCategoryAdmin,
+		Action:     "organization.update",
+		TargetType: "organization",
+		TargetID:   organization.ID.Hex(),
+		Changes:    changes,
+	})
+	return organization, nil
+}
+
+// AddAdmin makes a member of an organization one of its admins.
+func (uc *OrganizationUsecase) AddAdmin(ctx context.Context, actorID string, id string, userID string) (*domain.Organization, error) {
+	organization, err := uc.ReadOrganization(ctx, actorID, id)
+	if err != nil {
+		return nil, err
+	}
+	user, err := uc.member(ctx, organization, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.addAdmin(ctx, organization, user.ID); err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+// RemoveAdmin takes an organization's admin rights away from a user. The
+// last admin cannot be removed.
+func (uc *OrganizationUsecase) RemoveAdmin(ctx context.Context, actorID string, id string, userID string) (*domain.Organization, error) {
+	organization, err := uc.ReadOrganization(ctx, actorID, id)
+	if err != nil {
+		return nil, err
+	}
+	adminID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id: %w", err)
+	}
+	if !organization.IsAdmin(adminID) {
+		return organization, nil
+	}
+	if len(organization.AdminIDs) == 1 {
+		return nil, ErrLastOrganizationAdmin
+	}
+
+	admins := make([]primitive.ObjectID, 0, len(organization.AdminIDs)-1)
+	for _, id := range organization.AdminIDs {
+		if id != adminID {
+			admins = append(admins, id)
+		}
+	}
+	organization.AdminIDs = admins
+	if err := uc.repo.UpdateOrganization(ctx, organization.ID, organization); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, uc.audit, organizationAdminEntry("organization.admin_remove", organization, adminID.Hex(), ""))
+	return organization, nil
+}
+
+// addAdmin adds a member to the admins of organization, unless they are
+// one already.
+func (uc *OrganizationUsecase) addAdmin(ctx context.Context, organization *domain.Organization, userID primitive.ObjectID) error {
+	if organization.IsAdmin(userID) {
+		return nil
+	}
+	organization.AdminIDs = append(organization.AdminIDs, userID)
+	if err := uc.repo.UpdateOrganization(ctx, organization.ID, organization); err != nil {
+		return err
+	}
+	recordAudit(ctx, uc.audit, organizationAdminEntry("organization.admin_add", organization, "", userID.Hex()))
+	return nil
+}
+
+// checkPlatformAdmin returns ErrNotOrganizationAdmin unless the actor is an
+// admin of the default organization, and ctx is scoped to it.
+func (uc *OrganizationUsecase) checkPlatformAdmin(ctx context.Context, actorID string) error {
+	actor, err := primitive.ObjectIDFromHex(actorID)
+	if err != nil {
+		return ErrNotOrganizationAdmin
+	}
+	tenantID, ok := domain.TenantFromContext(ctx)
+	if !ok {
+		return ErrNotOrganizationAdmin
+	}
+	organization, err := uc.repo.GetOrganization(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	if organization == nil || !organization.IsDefault() || !organization.IsAdmin(actor) {
+		return ErrNotOrganizationAdmin
+	}
+	return nil
+}
+
+// checkAvailable returns ErrOrganizationExists when another organization
+// uses the slug or one of the domains of organization.
+func (uc *OrganizationUsecase) checkAvailable(ctx context.Context, organization *domain.Organization) error {
+	existing, err := uc.repo.GetOrganizationBySlug(ctx, organization.Slug)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != organization.ID {
+		return ErrOrganizationExists
+	}
+	for _, host := range organization.Domains {
+		existing, err := uc.repo.GetOrganizationByDomain(ctx, host)
+		if err != nil {
+			return err
+		}
+		if existing != nil && existing.ID != organization.ID {
+			return fmt.Errorf("%w: %s", ErrOrganizationExists, host)
+		}
+	}
+	return nil
+}
+
+// member returns a user of the organization, looked up in its tenant.
+func (uc *OrganizationUsecase) member(ctx context.Context, organization *domain.Organization, userID string) (*domain.User, error) {
+	objectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id: %w", err)
+	}
+	user, err := uc.users.GetUser(domain.WithTenant(ctx, organization.ID), objectID)
+	if err != nil {
+		return nil, ErrNotOrganizationMember
+	}
+	return user, nil
+}
+
+func (uc *OrganizationUsecase) organizationByHex(ctx context.Context, id string) (*domain.Organization, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id: %w", err)
+	}
+	return uc.organization(ctx, objectID)
+}
+
+func (uc *OrganizationUsecase) organization(ctx context.Context, id primitive.ObjectID) (*domain.Organization, error) {
+	organization, err := uc.repo.GetOrganization(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if organization == nil {
+		return nil, ErrOrganizationNotFound
+	}
+	return organization, nil
+}
+
+func validateOrganization(organization *domain.Organization) error {
+	if organization.Name == "" || len(organization.Name) > maxOrganizationNameLength {
+		return fmt.Errorf("%w: name must be 1 to %d characters", ErrInvalidOrganization, maxOrganizationNameLength)
+	}
+	if !slugPattern.MatchString(organization.Slug) {
+		return fmt.Errorf("%w: slug must be lowercase letters, digits and hyphens", ErrInvalidOrganization)
+	}
+	if len(organization.Domains) > maxOrganizationDomains {
+		return fmt.Errorf("%w: more than %d domains", ErrInvalidOrganization, maxOrganizationDomains)
+	}
+	for _, host := range organization.Domains {
+		if len(host) > 253 || !domainPattern.MatchString(host) {
+			return fmt.Errorf("%w: %q is not a host name", ErrInvalidOrganization, host)
+		}
+	}
+	return nil
+}
+
+// normalizeDomains lowercases the domains and drops blanks and duplicates.
+func normalizeDomains(domains []string) []string {
+	var normalized []string
+	seen := map[string]bool{}
+	for _, host := range domains {
+		host = strings.ToLower(strings.TrimSpace(host))
+		if host != "" && !seen[host] {
+			seen[host] = true
+			normalized = append(normalized, host)
+		}
+	}
+	return normalized
+}
+
+// organizationAdminEntry records a change to the admins of organization,
+// with the user ID as the before or after value.
+func organizationAdminEntry(action string, organization *domain.Organization, before, after string) *domain.AuditEntry {
+	return &domain.AuditEntry{
+		Category:   domain.AuditCategoryAdmin,
+		Action:     action,
+		TargetType: "organization",
+		TargetID:   organization.ID.Hex(),
+		Changes:    []domain.AuditChange{{Field: "admins", Before: before, After: after}},
+	}
+}
+
+// tenantQuery returns the query parameter naming the organization of ctx,
+// for links opened outside of a request made in it, such as those sent by
+// email.
+func tenantQuery(ctx context.Context) string {
+	if tenantID, ok := domain.TenantFromContext(ctx); ok {
+		return "&tenant=" + tenantID.Hex()
+	}
+	return ""
+}
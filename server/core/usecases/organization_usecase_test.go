@@ -0,0 +1,301 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"veritas/core/domain"
+	"veritas/core/usecases"
+	"veritas/internal/ports/output"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeOrganizations keeps organizations in memory.
+type fakeOrganizations struct {
+	organizations []*domain.Organization
+}
+
+func (f *fakeOrganizations) CreateOrganization(ctx context.Context, organization *domain.Organization) (primitive.ObjectID, error) {
+	copied := *organization
+	copied.ID = primitive.NewObjectID()
+	f.organizations = append(f.organizations, &copied)
+	return copied.ID, nil
+}
+
+func (f *fakeOrganizations) GetOrganization(ctx context.Context, id primitive.ObjectID) (*domain.Organization, error) {
+	return f.find(func(o *domain.Organization) bool { return o.ID == id }), nil
+}
+
+func (f *fakeOrganizations) GetOrganizationBySlug(ctx context.Context, slug string) (*domain.Organization, error) {
+	return f.find(func(o *domain.Organization) bool { return o.Slug == slug }), nil
+}
+
+func (f *fakeOrganizations) GetOrganizationByDomain(ctx context.Context, host string) (*domain.Organization, error) {
+	return f.find(func(o *domain.Organization) bool {
+		for _, d := range o.Domains {
+			if d == host {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+func (f *fakeOrganizations) UpdateOrganization(ctx context.Context, id primitive.ObjectID, organization *domain.Organization) error {
+	for i, o := range f.organizations {
+		if o.ID == id {
+			copied := *organization
+			f.organizations[i] = &copied
+		}
+	}
+	return nil
+}
+
+func (f *fakeOrganizations) GetAllOrganizations(ctx context.Context) ([]*domain.Organization, error) {
+	return f.organizations, nil
+}
+
+func (f *fakeOrganizations) find(match func(*domain.Organization) bool) *domain.Organization {
+	for _, o := range f.organizations {
+		if match(o) {
+			copied := *o
+			copied.AdminIDs = append([]primitive.ObjectID(nil), o.AdminIDs...)
+			return &copied
+		}
+	}
+	return nil
+}
+
+// fakeTenantUsers only finds users in the tenant of the context, like the
+// repository.
+type fakeTenantUsers struct {
+	output.UserOutputPort
+	users []*domain.User
+}
+
+func (f *fakeTenantUsers) GetUser(ctx context.Context, id primitive.ObjectID) (*domain.User, error) {
+	return f.find(ctx, func(u *domain.User) bool { return u.ID == id })
+}
+
+func (f *fakeTenantUsers) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return f.find(ctx, func(u *domain.User) bool { return u.Email == email })
+}
+
+func (f *fakeTenantUsers) CreateUser(ctx context.Context, user *domain.User) (primitive.ObjectID, error) {
+	tenantID, ok := domain.TenantFromContext(ctx)
+	if !ok {
+		return primitive.NilObjectID, errors.New("no tenant in context")
+	}
+	user.ID = primitive.NewObjectID()
+	user.TenantID = tenantID
+	f.users = append(f.users, user)
+	return user.ID, nil
+}
+
+func (f *fakeTenantUsers) UpdateUser(ctx context.Context, id primitive.ObjectID, user *domain.User) error {
+	_, err := f.GetUser(ctx, id)
+	return err
+}
+
+func (f *fakeTenantUsers) find(ctx context.Context, match func(*domain.User) bool) (*domain.User, error) {
+	tenantID, ok := domain.TenantFromContext(ctx)
+	if !ok {
+		return nil, errors.New("no tenant in context")
+	}
+	for _, u := range f.users {
+		if u.TenantID == tenantID && match(u) {
+			return u, nil
+		}
+	}
+	return nil, errors.New("user not found")
+}
+
+type OrganizationUseCaseTestSuite struct {
+	suite.Suite
+	organizations *fakeOrganizations
+	users         *fakeTenantUsers
+	audit         *fakeAuditLog
+	useCase       *usecases.OrganizationUsecase
+	defaultOrg    *domain.Organization
+	acme          *domain.Organization
+	platformAdmin *domain.User
+	acmeAdmin     *domain.User
+	acmeMember    *domain.User
+}
+
+func (s *OrganizationUseCaseTestSuite) SetupTest() {
+	s.organizations = &fakeOrganizations{}
+	s.users = &fakeTenantUsers{}
+	s.audit = &fakeAuditLog{}
+	s.useCase = usecases.NewOrganizationUsecase(s.organizations, s.users, s.audit)
+
+	var err error
+	s.defaultOrg, err = s.useCase.EnsureDefaultOrganization(context.Background())
+	s.Require().NoError(err)
+	s.acme = &domain.Organization{Name: "Acme", Slug: "acme", Domains: []string{"login.acme.example"}}
+	s.acme.ID, err = s.organizations.CreateOrganization(context.Background(), s.acme)
+	s.Require().NoError(err)
+
+	s.platformAdmin = &domain.User{ID: primitive.NewObjectID(), TenantID: s.defaultOrg.ID, Email: "root@example.com", EmailVerified: true}
+	s.acmeAdmin = &domain.User{ID: primitive.NewObjectID(), TenantID: s.acme.ID, Email: "admin@acme.example"}
+	s.acmeMember = &domain.User{ID: primitive.NewObjectID(), TenantID: s.acme.ID, Email: "member@acme.example"}
+	s.users.users = []*domain.User{s.platformAdmin, s.acmeAdmin, s.acmeMember}
+
+	s.Require().NoError(s.useCase.SeedAdmins(context.Background(), s.defaultOrg, []string{"Root@example.com"}))
+	s.acme.AdminIDs = []primitive.ObjectID{s.acmeAdmin.ID}
+	s.Require().NoError(s.organizations.UpdateOrganization(context.Background(), s.acme.ID, s.acme))
+	s.audit.entries = nil
+}
+
+// as returns a context of a request made by user, in its tenant.
+func (s *OrganizationUseCaseTestSuite) as(user *domain.User) context.Context {
+	return domain.WithTenant(context.Background(), user.TenantID)
+}
+
+func (s *OrganizationUseCaseTestSuite) TestDefaultOrganization() {
+	// Test case 1: The default organization is only created once
+	organization, err := s.useCase.EnsureDefaultOrganization(context.Background())
+	s.NoError(err)
+	s.Equal(s.defaultOrg.ID, organization.ID)
+	s.Len(s.organizations.organizations, 2)
+
+	// Test case 2: Seeded admins are looked up in the default organization only
+	s.NoError(s.useCase.SeedAdmins(context.Background(), organization, []string{"admin@acme.example", "unknown@example.com"}))
+	organization, _ = s.organizations.GetOrganization(context.Background(), s.defaultOrg.ID)
+	s.Equal([]primitive.ObjectID{s.platformAdmin.ID}, organization.AdminIDs)
+	s.True(organization.IsDefault())
+	s.Equal(domain.DefaultBrandingID, organization.BrandingID())
+
+	// Test case 3: An account whose address is not verified is not made an admin
+	squatter := &domain.User{ID: primitive.NewObjectID(), TenantID: s.defaultOrg.ID, Email: "owner@example.com"}
+	s.users.users = append(s.users.users, squatter)
+	s.NoError(s.useCase.SeedAdmins(context.Background(), organization, []string{"owner@example.com"}))
+	organization, _ = s.organizations.GetOrganization(context.Background(), s.defaultOrg.ID)
+	s.False(organization.IsAdmin(squatter.ID))
+}
+
+func (s *OrganizationUseCaseTestSuite) TestResolveTenant() {
+	ctx := context.Background()
+
+	// Test case 1: The request names the organization by ID or slug
+	organization, err := s.useCase.ResolveTenant(ctx, s.acme.ID.Hex(), "")
+	s.NoError(err)
+	s.Equal(s.acme.ID, organization.ID)
+	organization, err = s.useCase.ResolveTenant(ctx, "ACME", "id.example.com")
+	s.NoError(err)
+	s.Equal(s.acme.ID, organization.ID)
+
+	// Test case 2: Otherwise the organization serving the host is used
+	organization, err = s.useCase.ResolveTenant(ctx, "", "Login.Acme.Example")
+	s.NoError(err)
+	s.Equal(s.acme.ID, organization.ID)
+
+	// Test case 3: And failing that the default organization
+	organization, err = s.useCase.ResolveTenant(ctx, "", "id.example.com")
+	s.NoError(err)
+	s.Equal(s.defaultOrg.ID, organization.ID)
+
+	// Test case 4: Unknown organizations are not replaced by the default one
+	_, err = s.useCase.ResolveTenant(ctx, "globex", "")
+	s.ErrorIs(err, usecases.ErrOrganizationNotFound)
+	_, err = s.useCase.ResolveTenant(ctx, primitive.NewObjectID().Hex(), "")
+	s.ErrorIs(err, usecases.ErrOrganizationNotFound)
+}
+
+func (s *OrganizationUseCaseTestSuite) TestCreateOrganization() {
+	input := usecases.CreateOrganizationInput{Name: " Globex ", Slug: "Globex", Domains: []string{"Login.Globex.Example", "login.globex.example", ""}}
+
+	// Test case 1: Only admins of the default organization create organizations
+	_, err := s.useCase.CreateOrganization(s.as(s.acmeAdmin), s.acmeAdmin.ID.Hex(), input)
+	s.ErrorIs(err, usecases.ErrNotOrganizationAdmin)
+	_, err = s.useCase.CreateOrganization(s.as(s.acmeMember), s.platformAdmin.ID.Hex(), input)
+	s.ErrorIs(err, usecases.ErrNotOrganizationAdmin)
+
+	// Test case 2: Names, slugs and domains are normalized and audited
+	organization, err := s.useCase.CreateOrganization(s.as(s.platformAdmin), s.platformAdmin.ID.Hex(), input)
+	s.NoError(err)
+	s.Equal("Globex", organization.Name)
+	s.Equal("globex", organization.Slug)
+	s.Equal([]string{"login.globex.example"}, organization.Domains)
+	s.Empty(organization.AdminIDs)
+	s.Equal([]string{"organization.create:success"}, s.audit.actions())
+
+	// Test case 3: Slugs and domains are unique
+	_, err = s.useCase.CreateOrganization(s.as(s.platformAdmin), s.platformAdmin.ID.Hex(), usecases.CreateOrganizationInput{Name: "Acme 2", Slug: "acme"})
+	s.ErrorIs(err, usecases.ErrOrganizationExists)
+	_, err = s.useCase.CreateOrganization(s.as(s.platformAdmin), s.platformAdmin.ID.Hex(), usecases.CreateOrganizationInput{Name: "Acme 2", Slug: "acme-2", Domains: []string{"login.acme.example"}})
+	s.ErrorIs(err, usecases.ErrOrganizationExists)
+
+	// Test case 4: Invalid slugs and domains are rejected
+	for _, invalid := range []usecases.CreateOrganizationInput{
+		{Name: "Initech", Slug: "-initech"},
+		{Name: "Initech", Slug: "init tech"},
+		{Name: "Initech", Slug: "initech", Domains: []string{"https://initech.example"}},
+		{Name: "", Slug: "initech"},
+	} {
+		_, err = s.useCase.CreateOrganization(s.as(s.platformAdmin), s.platformAdmin.ID.Hex(), invalid)
+		s.ErrorIs(err, usecases.ErrInvalidOrganization, invalid)
+	}
+}
+
+func (s *OrganizationUseCaseTestSuite) TestOrganizationAdmins() {
+	// Test case 1: Organization admins manage their own organization only
+	organization, err := s.useCase.UpdateOrganization(s.as(s.acmeAdmin), s.acmeAdmin.ID.Hex(), s.acme.ID.Hex(), usecases.UpdateOrganizationInput{Name: "Acme Corp", Domains: []string{"login.acme.example"}})
+	s.NoError(err)
+	s.Equal("Acme Corp", organization.Name)
+	s.Equal("acme", organization.Slug)
+	_, err = s.useCase.ReadOrganization(s.as(s.acmeAdmin), s.acmeAdmin.ID.Hex(), s.defaultOrg.ID.Hex())
+	s.ErrorIs(err, usecases.ErrNotOrganizationAdmin)
+	_, err = s.useCase.ReadOrganization(s.as(s.acmeMember), s.acmeMember.ID.Hex(), s.acme.ID.Hex())
+	s.ErrorIs(err, usecases.ErrNotOrganizationAdmin)
+	_, err = s.useCase.ListOrganizations(s.as(s.acmeAdmin), s.acmeAdmin.ID.Hex())
+	s.ErrorIs(err, usecases.ErrNotOrganizationAdmin)
+
+	// Test case 2: Admins can only be chosen among the organization's members
+	_, err = s.useCase.AddAdmin(s.as(s.acmeAdmin), s.acmeAdmin.ID.Hex(), s.acme.ID.Hex(), s.platformAdmin.ID.Hex())
+	s.ErrorIs(err, usecases.ErrNotOrganizationMember)
+	organization, err = s.useCase.AddAdmin(s.as(s.acmeAdmin), s.acmeAdmin.ID.Hex(), s.acme.ID.Hex(), s.acmeMember.ID.Hex())
+	s.NoError(err)
+	s.True(organization.IsAdmin(s.acmeMember.ID))
+
+	// Test case 3: The last admin cannot be removed
+	organization, err = s.useCase.RemoveAdmin(s.as(s.acmeMember), s.acmeMember.ID.Hex(), s.acme.ID.Hex(), s.acmeAdmin.ID.Hex())
+	s.NoError(err)
+	s.Equal([]primitive.ObjectID{s.acmeMember.ID}, organization.AdminIDs)
+	_, err = s.useCase.RemoveAdmin(s.as(s.acmeMember), s.acmeMember.ID.Hex(), s.acme.ID.Hex(), s.acmeMember.ID.Hex())
+	s.ErrorIs(err, usecases.ErrLastOrganizationAdmin)
+
+	// Test case 4: Admins of the default organization manage every organization
+	organization, err = s.useCase.AddAdmin(s.as(s.platformAdmin), s.platformAdmin.ID.Hex(), s.acme.ID.Hex(), s.acmeAdmin.ID.Hex())
+	s.NoError(err)
+	s.True(organization.IsAdmin(s.acmeAdmin.ID))
+	organizations, err := s.useCase.ListOrganizations(s.as(s.platformAdmin), s.platformAdmin.ID.Hex())
+	s.NoError(err)
+	s.Len(organizations, 2)
+	s.Equal([]string{"organization.update:success", "organization.admin_add:success", "organization.admin_remove:success", "organization.admin_add:success"}, s.audit.actions())
+}
+
+func (s *OrganizationUseCaseTestSuite) TestDomains() {
+	// Test case 1: Organization admins cannot claim a host
+	_, err := s.useCase.UpdateOrganization(s.as(s.acmeAdmin), s.acmeAdmin.ID.Hex(), s.acme.ID.Hex(), usecases.UpdateOrganizationInput{Name: "Acme", Domains: []string{"login.acme.example", "id.example.com"}})
+	s.ErrorIs(err, usecases.ErrNotOrganizationAdmin)
+
+	// Test case 2: Nor remove one
+	_, err = s.useCase.UpdateOrganization(s.as(s.acmeAdmin), s.acmeAdmin.ID.Hex(), s.acme.ID.Hex(), usecases.UpdateOrganizationInput{Name: "Acme"})
+	s.ErrorIs(err, usecases.ErrNotOrganizationAdmin)
+	organization, err := s.organizations.GetOrganization(context.Background(), s.acme.ID)
+	s.Require().NoError(err)
+	s.Equal([]string{"login.acme.example"}, organization.Domains)
+
+	// Test case 3: Platform admins set the domains of any organization
+	organization, err = s.useCase.UpdateOrganization(s.as(s.platformAdmin), s.platformAdmin.ID.Hex(), s.acme.ID.Hex(), usecases.UpdateOrganizationInput{Name: "Acme", Domains: []string{"Login.Acme.example", "id.acme.example"}})
+	s.NoError(err)
+	s.Equal([]string{"login.acme.example", "id.acme.example"}, organization.Domains)
+	s.Equal([]string{"organization.update:success"}, s.audit.actions())
+}
+
+func TestOrganizationUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(OrganizationUseCaseTestSuite))
+}
@@ -0,0 +1,107 @@
+package usecases
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"strings"
+	"veritas/core/domain"
+	"veritas/internal/ports/output"
+	"veritas/internal/security"
+)
+
+// breachPrefixLength is how many hex digits of a SHA-1 hash are disclosed
+// when looking up breached passwords.
+const breachPrefixLength = 5
+
+type PasswordPolicyUsecase struct {
+	policy   security.PasswordPolicy
+	breached output.BreachedPasswordOutputPort
+}
+
+// NewPasswordPolicyUsecase returns a usecase enforcing policy. breached may
+// be nil when no breached password corpus is configured.
+func NewPasswordPolicyUsecase(policy security.PasswordPolicy, breached output.BreachedPasswordOutputPort) *PasswordPolicyUsecase {
+	return &PasswordPolicyUsecase{policy: policy, breached: breached}
+}
+
+// CheckPassword returns a *security.PasswordPolicyError listing every rule
+// the password breaks for the user. The user may not be stored yet.
+func (uc *PasswordPolicyUsecase) CheckPassword(ctx context.Context, user *domain.User, password string) error {
+	violations := uc.policy.Check(password, user.Username, user.Email)
+
+	breached, err := uc.isBreached(ctx, password)
+	if err != nil {
+		return err
+	}
+	if breached {
+		violations = append(violations, security.PasswordViolation{Code: security.PasswordBreached, Message: "has appeared in a data breach"})
+	}
+
+	if uc.recentlyUsed(user, password) {
+		violations = append(violations, security.PasswordViolation{Code: security.PasswordRecentlyUsed, Message: "must not be one of your recent passwords"})
+	}
+
+	if len(violations) > 0 {
+		return &security.PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// SetPassword checks the password and stores its hash in user, keeping the
+// replaced hash in the password history. The user is not saved.
+func (uc *PasswordPolicyUsecase) SetPassword(ctx context.Context, user *domain.User, password string) error {
+	if err := uc.CheckPassword(ctx, user, password); err != nil {
+		return err
+	}
+	hash, err := security.HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	// Legacy plaintext passwords are not kept.
+	if security.IsPasswordHash(user.Password) {
+		user.PasswordHistory = append([]string{user.Password}, user.PasswordHistory...)
+	}
+	// The current password counts towards the history size.
+	if keep := max(uc.policy.HistorySize-1, 0); len(user.PasswordHistory) > keep {
+		user.PasswordHistory = user.PasswordHistory[:keep]
+	}
+	user.Password = hash
+	return nil
+}
+
+func (uc *PasswordPolicyUsecase) isBreached(ctx context.Context, password string) (bool, error) {
+	if uc.breached == nil {
+		return false, nil
+	}
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	suffixes, err := uc.breached.FindHashSuffixes(ctx, hash[:breachPrefixLength])
+	if err != nil {
+		return false, err
+	}
+	for _, suffix := range suffixes {
+		if strings.EqualFold(suffix, hash[breachPrefixLength:]) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (uc *PasswordPolicyUsecase) recentlyUsed(user *domain.User, password string) bool {
+	if uc.policy.HistorySize <= 0 {
+		return false
+	}
+	recent := append([]string{user.Password}, user.PasswordHistory...)
+	if len(recent) > uc.policy.HistorySize {
+		recent = recent[:uc.policy.HistorySize]
+	}
+	for _, stored := range recent {
+		if stored != "" && security.CheckPassword(stored, password) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package usecases_test
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+	"veritas/core/domain"
+	"veritas/core/usecases"
+	"veritas/internal/security"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeBreachedPasswords answers prefix lookups from a list of passwords and
+// records the prefixes it was asked for.
+type fakeBreachedPasswords struct {
+	hashes   []string
+	prefixes []string
+}
+
+func newFakeBreachedPasswords(passwords ...string) *fakeBreachedPasswords {
+	f := &fakeBreachedPasswords{}
+	for _, password := range passwords {
+		sum := sha1.Sum([]byte(password))
+		f.hashes = append(f.hashes, strings.ToUpper(hex.EncodeToString(sum[:])))
+	}
+	return f
+}
+
+func (f *fakeBreachedPasswords) FindHashSuffixes(ctx context.Context, prefix string) ([]string, error) {
+	f.prefixes = append(f.prefixes, prefix)
+	var suffixes []string
+	for _, hash := range f.hashes {
+		if strings.HasPrefix(hash, prefix) {
+			suffixes = append(suffixes, hash[len(prefix):])
+		}
+	}
+	return suffixes, nil
+}
+
+type PasswordPolicyUseCaseTestSuite struct {
+	suite.Suite
+	breached *fakeBreachedPasswords
+	useCase  *usecases.PasswordPolicyUsecase
+	ctx      context.Context
+	user     *domain.User
+}
+
+func (s *PasswordPolicyUseCaseTestSuite) SetupTest() {
+	s.breached = newFakeBreachedPasswords("lantern-orbit-42")
+	policy := security.DefaultPasswordPolicy()
+	policy.HistorySize = 3
+	s.useCase = usecases.NewPasswordPolicyUsecase(policy, s.breached)
+	s.ctx = context.Background()
+	s.user = &domain.User{Username: "jane", Email: "jane@example.com"}
+}
+
+func violationCodes(err error) []string {
+	var policyErr *security.PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		return nil
+	}
+	var codes []string
+	for _, violation := range policyErr.Violations {
+		codes = append(codes, violation.Code)
+	}
+	return codes
+}
+
+func (s *PasswordPolicyUseCaseTestSuite) TestCheckPassword() {
+	// Test case 1: Breached passwords are rejected, disclosing only a prefix
+	err := s.useCase.CheckPassword(s.ctx, s.user, "lantern-orbit-42")
+	s.ErrorIs(err, security.ErrPasswordPolicy)
+	s.Equal([]string{security.PasswordBreached}, violationCodes(err))
+	s.Require().Len(s.breached.prefixes, 1)
+	s.Len(s.breached.prefixes[0], 5)
+
+	// Test case 2: Every violation is reported
+	err = s.useCase.CheckPassword(s.ctx, s.user, "jane1")
+	s.Equal([]string{security.PasswordTooShort, security.PasswordPersonalInfo, security.PasswordTooWeak}, violationCodes(err))
+
+	// Test case 3: Acceptable password
+	s.NoError(s.useCase.CheckPassword(s.ctx, s.user, "copper-meadow-17"))
+}
+
+func (s *PasswordPolicyUseCaseTestSuite) TestPasswordHistory() {
+	passwords := []string{"copper-meadow-17", "velvet-canyon-58", "silent-harbor-93", "amber-glacier-26"}
+	for _, password := range passwords {
+		s.Require().NoError(s.useCase.SetPassword(s.ctx, s.user, password))
+	}
+	s.True(security.CheckPassword(s.user.Password, "amber-glacier-26"))
+	s.Len(s.user.PasswordHistory, 2)
+
+	// Test case 1: The current and recent passwords cannot be reused
+	for _, password := range passwords[1:] {
+		err := s.useCase.SetPassword(s.ctx, s.user, password)
+		s.Equal([]string{security.PasswordRecentlyUsed}, violationCodes(err), password)
+	}
+
+	// Test case 2: Passwords older than the history can be used again
+	s.NoError(s.useCase.SetPassword(s.ctx, s.user, passwords[0]))
+}
+
+func TestPasswordPolicyUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(PasswordPolicyUseCaseTestSuite))
+}
@@ -0,0 +1,116 @@
+package usecases
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"veritas/core/domain"
+	"veritas/internal/ports/output"
+	"veritas/internal/security"
+)
+
+const passwordResetTTL = 30 * time.Minute
+
+var ErrInvalidResetToken = errors.New("invalid or expired reset token")
+
+type PasswordResetUsecase struct {
+	users     output.UserOutputPort
+	tokens    output.OneTimeTokenOutputPort
+	sessions  output.SessionOutputPort
+	mailer    output.EmailSender
+	passwords *PasswordPolicyUsecase
+	audit     output.AuditLogger
+	baseURL   string
+}
+
+func NewPasswordResetUsecase(users output.UserOutputPort, tokens output.OneTimeTokenOutputPort, sessions output.SessionOutputPort, mailer output.EmailSender, passwords *PasswordPolicyUsecase, audit output.AuditLogger, baseURL string) *PasswordResetUsecase {
+	return &PasswordResetUsecase{users: users, tokens: tokens, sessions: sessions, mailer: mailer, passwords: passwords, audit: audit, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// RequestReset emails a reset link to the account with the given address.
+// Unknown addresses are ignored so the caller cannot learn which are
+// registered. Earlier reset tokens of the user are invalidated.
+func (uc *PasswordResetUsecase) RequestReset(ctx context.Context, email string) error {
+	user, err := uc.users.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil
+	}
+
+	raw, err := security.RandomBytes(32)
+	if err != nil {
+		return err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	if err := uc.tokens.DeleteTokensByUser(ctx, user.ID, domain.TokenPurposePasswordReset); err != nil {
+		return err
+	}
+	_, err = uc.tokens.CreateToken(ctx, &domain.OneTimeToken{
+		UserID:    user.ID,
+		Purpose:   domain.TokenPurposePasswordReset,
+		TokenHash: security.HashToken(token),
+		ExpiresAt: time.Now().Add(passwordResetTTL),
+	})
+	if err != nil {
+		return err
+	}
+
+	link := uc.baseURL + "/auth/password/reset?token=" + url.QueryEscape(token) + tenantQuery(ctx)
+	err = uc.mailer.SendEmail(ctx, &domain.EmailMessage{
+		To:      user.Email,
+		Subject: "Reset your password",
+		TextBody: fmt.Sprintf("Hello %s,\n\nA password reset was requested for your account. Open the link below to choose a new password:\n\n%s\n\nThe link expires in %d minutes and can only be used once. If you did not request a reset, you can ignore this message.\n",
+			user.Username, link, int(passwordResetTTL.Minutes())),
+	})
+	if err != nil {
+		return err
+	}
+
+	recordAudit(ctx, uc.audit, userAuditEntry(domain.AuditCategoryAccount, "password.reset_request", user.ID))
+	return nil
+}
+
+// ResetPassword sets a new password with a token from RequestReset. Every
+// token issued to the user before the reset is revoked.
+func (uc *PasswordResetUsecase) ResetPassword(ctx context.Context, token string, password string) error {
+	tokenHash := security.HashToken(token)
+	stored, err := uc.tokens.GetTokenByHash(ctx, tokenHash, domain.TokenPurposePasswordReset)
+	if err != nil || time.Now().After(stored.ExpiresAt) {
+		return ErrInvalidResetToken
+	}
+
+	user, err := uc.users.GetUser(ctx, stored.UserID)
+	if err != nil {
+		return ErrInvalidResetToken
+	}
+
+	// Check the policy before consuming the token so a rejected password
+	// does not burn it.
+	if err := uc.passwords.SetPassword(ctx, user, password); err != nil {
+		return err
+	}
+	if _, err := uc.tokens.ConsumeToken(ctx, tokenHash, domain.TokenPurposePasswordReset); err != nil {
+		return ErrInvalidResetToken
+	}
+
+	// The reset link was delivered to the address, which proves control of it.
+	markEmailVerified(user)
+	user.TokensRevokedAt = time.Now()
+	user.UpdatedAt = time.Now()
+	if err := uc.users.UpdateUser(ctx, user.ID, user); err != nil {
+		return err
+	}
+	// Whoever knew the old password is signed out everywhere.
+	if _, err := uc.sessions.DeleteSessionsByUser(ctx, user.ID); err != nil {
+		return err
+	}
+
+	entry := userAuditEntry(domain.AuditCategoryAccount, "password.reset", user.ID)
+	entry.Changes = []domain.AuditChange{secretChange("password")}
+	recordAudit(ctx, uc.audit, entry)
+	return uc.tokens.DeleteTokensByUser(ctx, user.ID, domain.TokenPurposePasswordReset)
+}
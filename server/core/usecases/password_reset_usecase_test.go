@@ -0,0 +1,175 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+	"veritas/core/domain"
+	"veritas/core/usecases"
+	"veritas/internal/adapters/mailer"
+	"veritas/internal/security"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type MockOneTimeTokenOutputPort struct {
+	mock.Mock
+}
+
+func (m *MockOneTimeTokenOutputPort) CreateToken(ctx context.Context, token *domain.OneTimeToken) (primitive.ObjectID, error) {
+	args := m.Called(ctx, token)
+	return args.Get(0).(primitive.ObjectID), args.Error(1)
+}
+
+func (m *MockOneTimeTokenOutputPort) ConsumeToken(ctx context.Context, tokenHash string, purpose string) (*domain.OneTimeToken, error) {
+	args := m.Called(ctx, tokenHash, purpose)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OneTimeToken), args.Error(1)
+}
+
+func (m *MockOneTimeTokenOutputPort) GetTokenByHash(ctx context.Context, tokenHash string, purpose string) (*domain.OneTimeToken, error) {
+	args := m.Called(ctx, tokenHash, purpose)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OneTimeToken), args.Error(1)
+}
+
+func (m *MockOneTimeTokenOutputPort) GetTokenByUser(ctx context.Context, userID primitive.ObjectID, purpose string) (*domain.OneTimeToken, error) {
+	args := m.Called(ctx, userID, purpose)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OneTimeToken), args.Error(1)
+}
+
+func (m *MockOneTimeTokenOutputPort) GetTokenByBinding(ctx context.Context, bindingHash string, purpose string) (*domain.OneTimeToken, error) {
+	args := m.Called(ctx, bindingHash, purpose)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OneTimeToken), args.Error(1)
+}
+
+func (m *MockOneTimeTokenOutputPort) IncrementAttempts(ctx context.Context, id primitive.ObjectID) (int, error) {
+	args := m.Called(ctx, id)
+	if fn, ok := args.Get(0).(func(context.Context, primitive.ObjectID) int); ok {
+		return fn(ctx, id), args.Error(1)
+	}
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockOneTimeTokenOutputPort) DeleteTokensByUser(ctx context.Context, userID primitive.ObjectID, purpose string) error {
+	args := m.Called(ctx, userID, purpose)
+	return args.Error(0)
+}
+
+type PasswordResetUseCaseTestSuite struct {
+	suite.Suite
+	mockOutputPort *MockUserOutputPort
+	mockTokens     *MockOneTimeTokenOutputPort
+	sessions       *fakeSessions
+	mailer         *mailer.MemorySender
+	useCase        *usecases.PasswordResetUsecase
+	audit          *fakeAuditLog
+	ctx            context.Context
+	user           *domain.User
+}
+
+func (s *PasswordResetUseCaseTestSuite) SetupTest() {
+	s.audit = &fakeAuditLog{}
+	s.mockOutputPort = new(MockUserOutputPort)
+	s.mockTokens = new(MockOneTimeTokenOutputPort)
+	s.sessions = &fakeSessions{sessions: map[primitive.ObjectID]*domain.Session{}}
+	s.mailer = mailer.NewMemorySender()
+	s.useCase = usecases.NewPasswordResetUsecase(s.mockOutputPort, s.mockTokens, s.sessions, s.mailer, usecases.NewPasswordPolicyUsecase(security.DefaultPasswordPolicy(), nil), s.audit, "https://id.example.com")
+	s.ctx = context.Background()
+	s.user = &domain.User{
+		ID:       primitive.NewObjectID(),
+		Username: "test",
+		Email:    "test@example.com",
+		Password: "old-password",
+	}
+}
+
+func (s *PasswordResetUseCaseTestSuite) TestRequestReset() {
+	// Test case 1: Unknown addresses are ignored
+	s.mockOutputPort.On("GetUserByEmail", s.ctx, "unknown@example.com").Return(nil, errors.New("user not found")).Once()
+	err := s.useCase.RequestReset(s.ctx, "unknown@example.com")
+	s.NoError(err)
+	s.Empty(s.mailer.Messages())
+
+	// Test case 2: Only the hash of the emailed token is stored
+	var stored *domain.OneTimeToken
+	s.mockOutputPort.On("GetUserByEmail", s.ctx, s.user.Email).Return(s.user, nil).Once()
+	s.mockTokens.On("DeleteTokensByUser", s.ctx, s.user.ID, domain.TokenPurposePasswordReset).Return(nil).Once()
+	s.mockTokens.On("CreateToken", s.ctx, mock.AnythingOfType("*domain.OneTimeToken")).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*domain.OneTimeToken)
+	}).Return(primitive.NewObjectID(), nil).Once()
+
+	err = s.useCase.RequestReset(s.ctx, s.user.Email)
+	s.NoError(err)
+	s.Require().Len(s.mailer.Messages(), 1)
+	body := s.mailer.Messages()[0].TextBody
+	link, err := url.Parse(strings.Fields(body[strings.Index(body, "https://"):])[0])
+	s.Require().NoError(err)
+	token := link.Query().Get("token")
+	s.NotEmpty(token)
+	s.Equal(security.HashToken(token), stored.TokenHash)
+	s.True(stored.ExpiresAt.After(time.Now()))
+	s.mockTokens.AssertExpectations(s.T())
+}
+
+func (s *PasswordResetUseCaseTestSuite) TestResetPassword() {
+	token := "reset-token"
+	stored := &domain.OneTimeToken{
+		UserID:    s.user.ID,
+		Purpose:   domain.TokenPurposePasswordReset,
+		TokenHash: security.HashToken(token),
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+
+	// Test case 1: Weak passwords are rejected without consuming the token
+	s.mockTokens.On("GetTokenByHash", s.ctx, stored.TokenHash, domain.TokenPurposePasswordReset).Return(stored, nil).Once()
+	s.mockOutputPort.On("GetUser", s.ctx, s.user.ID).Return(s.user, nil).Once()
+	err := s.useCase.ResetPassword(s.ctx, token, "short")
+	s.ErrorIs(err, security.ErrPasswordPolicy)
+	s.mockTokens.AssertNotCalled(s.T(), "ConsumeToken", mock.Anything, mock.Anything, mock.Anything)
+
+	// Test case 2: Valid token sets a hashed password and revokes tokens and sessions
+	_, err = s.sessions.CreateSession(s.ctx, &domain.Session{UserID: s.user.ID})
+	s.Require().NoError(err)
+	s.mockTokens.On("GetTokenByHash", s.ctx, stored.TokenHash, domain.TokenPurposePasswordReset).Return(stored, nil).Once()
+	s.mockTokens.On("ConsumeToken", s.ctx, stored.TokenHash, domain.TokenPurposePasswordReset).Return(stored, nil).Once()
+	s.mockOutputPort.On("GetUser", s.ctx, s.user.ID).Return(s.user, nil).Once()
+	s.mockOutputPort.On("UpdateUser", s.ctx, s.user.ID, s.user).Return(nil).Once()
+	s.mockTokens.On("DeleteTokensByUser", s.ctx, s.user.ID, domain.TokenPurposePasswordReset).Return(nil).Once()
+	err = s.useCase.ResetPassword(s.ctx, token, "lantern-orbit-42")
+	s.NoError(err)
+	s.True(security.CheckPassword(s.user.Password, "lantern-orbit-42"))
+	s.False(security.CheckPassword(s.user.Password, "old-password"))
+	s.False(s.user.TokensRevokedAt.IsZero())
+	s.Empty(s.sessions.sessions)
+
+	// Test case 3: Used tokens cannot be replayed
+	s.mockTokens.On("GetTokenByHash", s.ctx, stored.TokenHash, domain.TokenPurposePasswordReset).Return(nil, errors.New("token not found")).Once()
+	err = s.useCase.ResetPassword(s.ctx, token, "another-password")
+	s.ErrorIs(err, usecases.ErrInvalidResetToken)
+
+	// Test case 4: Expired tokens are rejected
+	stored.ExpiresAt = time.Now().Add(-time.Minute)
+	s.mockTokens.On("GetTokenByHash", s.ctx, stored.TokenHash, domain.TokenPurposePasswordReset).Return(stored, nil).Once()
+	err = s.useCase.ResetPassword(s.ctx, token, "another-password")
+	s.ErrorIs(err, usecases.ErrInvalidResetToken)
+}
+
+func TestPasswordResetUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(PasswordResetUseCaseTestSuite))
+}
@@ -0,0 +1,215 @@
+package usecases
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+	"veritas/core/domain"
+	"veritas/internal/ports/output"
+	"veritas/internal/security"
+)
+
+const (
+	magicLinkTTL                = 15 * time.Minute
+	emailOTPTTL                 = 10 * time.Minute
+	emailOTPDigits              = 6
+	passwordlessRateLimitWindow = time.Hour
+)
+
+var (
+	ErrInvalidLoginCode        = errors.New("invalid or expired login code")
+	ErrPasswordlessRateLimited = errors.New("too many sign-in emails sent to this address, try again later")
+)
+
+type PasswordlessUsecase struct {
+	users     output.UserOutputPort
+	tokens    output.OneTimeTokenOutputPort
+	mailer    output.EmailSender
+	limits    output.RateLimitOutputPort
+	lockout   *LoginProtectionUsecase
+	audit     output.AuditLogger
+	baseURL   string
+	rateLimit int
+}
+
+// NewPasswordlessUsecase creates a PasswordlessUsecase. rateLimit is the
+// number of links and codes an address may be sent per hour, and failed
+// verifications count towards the lockouts of lockout.
+func NewPasswordlessUsecase(users output.UserOutputPort, tokens output.OneTimeTokenOutputPort, mailer output.EmailSender, limits output.RateLimitOutputPort, lockout *LoginProtectionUsecase, audit output.AuditLogger, baseURL string, rateLimit int) *PasswordlessUsecase {
+	return &PasswordlessUsecase{users: users, tokens: tokens, mailer: mailer, limits: limits, lockout: lockout, audit: audit, baseURL: strings.TrimRight(baseURL, "/"), rateLimit: rateLimit}
+}
+
+// PasswordlessRequest identifies a pending login. RequestID must be kept by
+// the browser or device that started the login and presented again with the
+// link or code.
+type PasswordlessRequest struct {
+	RequestID string
+	ExpiresIn time.Duration
+}
+
+// StartMagicLink emails a single-use login link to the account with the
+// given address. A request ID is returned for unknown addresses too, so the
+// caller cannot learn which are registered.
+func (uc *PasswordlessUsecase) StartMagicLink(ctx context.Context, email string) (*PasswordlessRequest, error) {
+	raw, err := security.RandomBytes(32)
+	if err != nil {
+		return nil, err
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+
+	request, user, err := uc.start(ctx, email, domain.TokenPurposeMagicLink, token, magicLinkTTL)
+	if err != nil || user == nil {
+		return request, err
+	}
+
+	link := uc.baseURL + "/auth/magic-link/verify?token=" + url.QueryEscape(token) + tenantQuery(ctx)
+	err = uc.mailer.SendEmail(ctx, &domain.EmailMessage{
+		To:      user.Email,
+		Subject: "Your sign-in link",
+		TextBody: fmt.Sprintf("Hello %s,\n\nOpen the link below in the same browser you requested it from to sign in:\n\n%s\n\nThe link expires in %d minutes and can only be used once. If you did not try to sign in, you can ignore this message.\n",
+			user.Username, link, int(magicLinkTTL.Minutes())),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// StartEmailOTP emails a six-digit login code to the account with the given
+// address, like StartMagicLink.
+func (uc *PasswordlessUsecase) StartEmailOTP(ctx context.Context, email string) (*PasswordlessRequest, error) {
+	code, err := security.RandomDigits(emailOTPDigits)
+	if err != nil {
+		return nil, err
+	}
+
+	request, user, err := uc.start(ctx, email, domain.TokenPurposeEmailOTP, code, emailOTPTTL)
+	if err != nil || user == nil {
+		return request, err
+	}
+
+	err = uc.mailer.SendEmail(ctx, &domain.EmailMessage{
+		To:      user.Email,
+		Subject: "Your sign-in code",
+		TextBody: fmt.Sprintf("Hello %s,\n\nYour sign-in code is %s\n\nIt expires in %d minutes. If you did not try to sign in, you can ignore this message.\n",
+			user.Username, code, int(emailOTPTTL.Minutes())),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// VerifyMagicLink exchanges a link token for the user it was sent to.
+func (uc *PasswordlessUsecase) VerifyMagicLink(ctx context.Context, requestID string, token string) (*domain.User, error) {
+	return uc.verify(ctx, domain.TokenPurposeMagicLink, requestID, token)
+}
+
+// VerifyEmailOTP exchanges a login code for the user it was sent to.
+func (uc *PasswordlessUsecase) VerifyEmailOTP(ctx context.Context, requestID string, code string) (*domain.User, error) {
+	return uc.verify(ctx, domain.TokenPurposeEmailOTP, requestID, code)
+}
+
+// start counts the request against the per-address limit before looking the
+// account up, so unknown addresses are limited exactly like registered ones.
+func (uc *PasswordlessUsecase) start(ctx context.Context, email string, purpose string, secret string, ttl time.Duration) (*PasswordlessRequest, *domain.User, error) {
+	counter, err := uc.limits.Hit(ctx, "passwordless:"+accountKey(ctx, email), passwordlessRateLimitWindow)
+	if err != nil {
+		return nil, nil, err
+	}
+	if counter.Count > uc.rateLimit {
+		return nil, nil, ErrPasswordlessRateLimited
+	}
+
+	raw, err := security.RandomBytes(32)
+	if err != nil {
+		return nil, nil, err
+	}
+	request := &PasswordlessRequest{
+		RequestID: base64.RawURLEncoding.EncodeToString(raw),
+		ExpiresIn: ttl,
+	}
+
+	user, err := uc.users.GetUserByEmail(ctx, email)
+	if err != nil {
+		return request, nil, nil
+	}
+
+	_, err = uc.tokens.CreateToken(ctx, &domain.OneTimeToken{
+		UserID:      user.ID,
+		Purpose:     purpose,
+		TokenHash:   passwordlessHash(request.RequestID, secret),
+		BindingHash: security.HashToken(request.RequestID),
+		ExpiresAt:   time.Now().Add(ttl),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	recordAudit(ctx, uc.audit, userAuditEntry(domain.AuditCategoryAuthentication, "auth."+purpose+"_sent", user.ID))
+	return request, user, nil
+}
+
+// verify looks the pending login up by its request ID, so a link or code is
+// only accepted from the browser that asked for it. Wrong links and codes
+// count as failed logins of the account, which keeps a six-digit code from
+// being guessed across many requests.
+func (uc *PasswordlessUsecase) verify(ctx context.Context, purpose string, requestID string, secret string) (*domain.User, error) {
+	if requestID == "" || secret == "" {
+		return nil, ErrInvalidLoginCode
+	}
+
+	stored, err := uc.tokens.GetTokenByBinding(ctx, security.HashToken(requestID), purpose)
+	if err != nil {
+		recordAudit(ctx, uc.audit, failed(&domain.AuditEntry{Category: domain.AuditCategoryAuthentication, Action: "auth." + purpose}, "unknown login request"))
+		return nil, ErrInvalidLoginCode
+	}
+
+	user, err := uc.users.GetUser(ctx, stored.UserID)
+	if err != nil {
+		return nil, ErrInvalidLoginCode
+	}
+
+	var ip string
+	if info := domain.RequestInfoFromContext(ctx); info != nil {
+		ip = info.IP
+	}
+	if err := uc.lockout.Check(ctx, user.Email, ip); err != nil {
+		return nil, err
+	}
+	if !redeemOneTimeToken(ctx, uc.tokens, stored, passwordlessHash(requestID, secret)) {
+		recordAudit(ctx, uc.audit, failed(userAuditEntry(domain.AuditCategoryAuthentication, "auth."+purpose, stored.UserID), ErrInvalidLoginCode.Error()))
+		if err := uc.lockout.RecordFailure(ctx, user.Email, ip); err != nil {
+			log.Printf("failed to record login failure: %v", err)
+		}
+		return nil, ErrInvalidLoginCode
+	}
+	if err := uc.lockout.RecordSuccess(ctx, user.Email); err != nil {
+		return nil, err
+	}
+
+	// Receiving the link or code proves control of the address.
+	if !user.EmailVerified {
+		markEmailVerified(user)
+		user.UpdatedAt = time.Now()
+		if err := uc.users.UpdateUser(ctx, user.ID, user); err != nil {
+			return nil, err
+		}
+	}
+
+	recordAudit(ctx, uc.audit, userAuditEntry(domain.AuditCategoryAuthentication, "auth."+purpose, user.ID))
+	return user, nil
+}
+
+// passwordlessHash binds a link token or code to the request ID. Six-digit
+// codes alone have too little entropy to store as a plain hash.
+func passwordlessHash(requestID string, secret string) string {
+	return security.HashToken(requestID + ":" + secret)
+}
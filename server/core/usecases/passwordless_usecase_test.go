@@ -0,0 +1,197 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+	"veritas/core/domain"
+	"veritas/core/usecases"
+	"veritas/internal/adapters/mailer"
+	"veritas/internal/security"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PasswordlessUseCaseTestSuite struct {
+	suite.Suite
+	mockOutputPort *MockUserOutputPort
+	mockTokens     *MockOneTimeTokenOutputPort
+	mockLimits     *MockRateLimitOutputPort
+	mockEvents     *MockEventPublisher
+	attempts       *fakeLoginAttempts
+	mailer         *mailer.MemorySender
+	useCase        *usecases.PasswordlessUsecase
+	audit          *fakeAuditLog
+	ctx            context.Context
+	user           *domain.User
+	stored         *domain.OneTimeToken
+}
+
+func (s *PasswordlessUseCaseTestSuite) SetupTest() {
+	s.audit = &fakeAuditLog{}
+	s.mockOutputPort = new(MockUserOutputPort)
+	s.mockTokens = new(MockOneTimeTokenOutputPort)
+	s.mockLimits = new(MockRateLimitOutputPort)
+	s.mockEvents = new(MockEventPublisher)
+	s.attempts = &fakeLoginAttempts{attempts: map[string]*domain.LoginAttempt{}}
+	s.mailer = mailer.NewMemorySender()
+	s.ctx = context.Background()
+	s.useCase = s.newUseCase(10)
+	s.mockLimits.On("Hit", s.ctx, mock.Anything, time.Hour)
+	s.mockEvents.On("Publish", s.ctx, mock.Anything).Return(nil)
+	s.user = &domain.User{
+		ID:       primitive.NewObjectID(),
+		Username: "test",
+		Email:    "test@example.com",
+	}
+	s.mockOutputPort.On("GetUserByEmail", s.ctx, s.user.Email).Return(s.user, nil)
+	s.mockOutputPort.On("GetUserByEmail", s.ctx, mock.Anything).Return(nil, errors.New("user not found"))
+	s.mockOutputPort.On("GetUser", s.ctx, s.user.ID).Return(s.user, nil)
+	s.mockOutputPort.On("UpdateUser", s.ctx, s.user.ID, s.user).Return(nil)
+	s.mockTokens.On("CreateToken", s.ctx, mock.AnythingOfType("*domain.OneTimeToken")).Run(func(args mock.Arguments) {
+		s.stored = args.Get(1).(*domain.OneTimeToken)
+		s.stored.ID = primitive.NewObjectID()
+	}).Return(primitive.NewObjectID(), nil)
+}
+
+// newUseCase returns a PasswordlessUsecase that sends three emails per
+// address and hour and locks accounts after accountThreshold failures.
+func (s *PasswordlessUseCaseTestSuite) newUseCase(accountThreshold int) *usecases.PasswordlessUsecase {
+	lockout := usecases.NewLoginProtectionUsecase(s.attempts, s.mockOutputPort, s.mockEvents, s.audit, usecases.LoginProtectionPolicy{
+		AccountThreshold: accountThreshold,
+		IPThreshold:      100,
+		Window:           time.Minute,
+		LockoutDuration:  time.Hour,
+	})
+	return usecases.NewPasswordlessUsecase(s.mockOutputPort, s.mockTokens, s.mailer, s.mockLimits, lockout, s.audit, "https://id.example.com", 3)
+}
+
+func (s *PasswordlessUseCaseTestSuite) sentCode() string {
+	messages := s.mailer.Messages()
+	s.Require().NotEmpty(messages)
+	return regexp.MustCompile(`\b\d{6}\b`).FindString(messages[len(messages)-1].TextBody)
+}
+
+func (s *PasswordlessUseCaseTestSuite) expectLookup(requestID string) {
+	s.mockTokens.On("GetTokenByBinding", s.ctx, security.HashToken(requestID), domain.TokenPurposeEmailOTP).Return(s.stored, nil)
+	s.mockTokens.On("IncrementAttempts", s.ctx, s.stored.ID).Return(func(ctx context.Context, id primitive.ObjectID) int {
+		s.stored.Attempts++
+		return s.stored.Attempts
+	}, nil)
+	s.mockTokens.On("ConsumeToken", s.ctx, s.stored.TokenHash, domain.TokenPurposeEmailOTP).Return(s.stored, nil)
+}
+
+func (s *PasswordlessUseCaseTestSuite) TestEmailOTP() {
+	// Test case 1: Unknown addresses get a request ID but no email
+	request, err := s.useCase.StartEmailOTP(s.ctx, "unknown@example.com")
+	s.NoError(err)
+	s.NotEmpty(request.RequestID)
+	s.Empty(s.mailer.Messages())
+
+	// Test case 2: The code is bound to the request ID
+	request, err = s.useCase.StartEmailOTP(s.ctx, s.user.Email)
+	s.NoError(err)
+	code := s.sentCode()
+	s.Require().Len(code, 6)
+	s.NotContains(s.stored.TokenHash, code)
+	s.expectLookup(request.RequestID)
+	s.mockTokens.On("GetTokenByBinding", s.ctx, mock.Anything, domain.TokenPurposeEmailOTP).Return(nil, errors.New("token not found"))
+
+	_, err = s.useCase.VerifyEmailOTP(s.ctx, "another-request", code)
+	s.ErrorIs(err, usecases.ErrInvalidLoginCode)
+
+	// Test case 3: A wrong code counts as an attempt
+	_, err = s.useCase.VerifyEmailOTP(s.ctx, request.RequestID, "000000x")
+	s.ErrorIs(err, usecases.ErrInvalidLoginCode)
+	s.Equal(1, s.stored.Attempts)
+
+	// Test case 4: The right code signs the user in and verifies the address
+	user, err := s.useCase.VerifyEmailOTP(s.ctx, request.RequestID, code)
+	s.NoError(err)
+	s.Equal(s.user.ID, user.ID)
+	s.True(user.EmailVerified)
+}
+
+func (s *PasswordlessUseCaseTestSuite) TestAttemptLimit() {
+	request, err := s.useCase.StartEmailOTP(s.ctx, s.user.Email)
+	s.Require().NoError(err)
+	code := s.sentCode()
+	s.expectLookup(request.RequestID)
+
+	// Test case 1: Five wrong attempts discard the code
+	for i := 0; i < 5; i++ {
+		_, err = s.useCase.VerifyEmailOTP(s.ctx, request.RequestID, "wrong")
+		s.ErrorIs(err, usecases.ErrInvalidLoginCode)
+	}
+	s.mockTokens.AssertCalled(s.T(), "ConsumeToken", s.ctx, s.stored.TokenHash, domain.TokenPurposeEmailOTP)
+
+	// Test case 2: The right code is no longer accepted
+	_, err = s.useCase.VerifyEmailOTP(s.ctx, request.RequestID, code)
+	s.ErrorIs(err, usecases.ErrInvalidLoginCode)
+}
+
+func (s *PasswordlessUseCaseTestSuite) TestIssuanceLimit() {
+	// Test case 1: An address is sent at most three codes per hour
+	for i := 0; i < 3; i++ {
+		_, err := s.useCase.StartEmailOTP(s.ctx, s.user.Email)
+		s.Require().NoError(err)
+	}
+	_, err := s.useCase.StartEmailOTP(s.ctx, s.user.Email)
+	s.ErrorIs(err, usecases.ErrPasswordlessRateLimited)
+	s.Len(s.mailer.Messages(), 3)
+
+	// Test case 2: Magic links and other spellings of the address count against the same limit
+	_, err = s.useCase.StartMagicLink(s.ctx, " TEST@example.com")
+	s.ErrorIs(err, usecases.ErrPasswordlessRateLimited)
+
+	// Test case 3: Unknown addresses are limited like registered ones
+	for i := 0; i < 3; i++ {
+		_, err = s.useCase.StartEmailOTP(s.ctx, "unknown@example.com")
+		s.Require().NoError(err)
+	}
+	_, err = s.useCase.StartEmailOTP(s.ctx, "unknown@example.com")
+	s.ErrorIs(err, usecases.ErrPasswordlessRateLimited)
+	s.Len(s.mailer.Messages(), 3)
+}
+
+func (s *PasswordlessUseCaseTestSuite) TestFailedCodesLockAccount() {
+	s.useCase = s.newUseCase(3)
+	request, err := s.useCase.StartEmailOTP(s.ctx, s.user.Email)
+	s.Require().NoError(err)
+	code := s.sentCode()
+	s.expectLookup(request.RequestID)
+
+	// Test case 1: Wrong codes count as failed logins of the account
+	for i := 0; i < 3; i++ {
+		_, err = s.useCase.VerifyEmailOTP(s.ctx, request.RequestID, "wrong")
+		s.ErrorIs(err, usecases.ErrInvalidLoginCode)
+	}
+	s.Contains(s.audit.actions(), "auth.lockout:success")
+
+	// Test case 2: The locked account cannot sign in, even with the right code
+	var throttled *usecases.LoginThrottledError
+	_, err = s.useCase.VerifyEmailOTP(s.ctx, request.RequestID, code)
+	s.Require().ErrorAs(err, &throttled)
+	s.Greater(throttled.RetryAfter, 59*time.Minute)
+	s.Equal(3, s.stored.Attempts)
+}
+
+func (s *PasswordlessUseCaseTestSuite) TestMagicLinkExpiry() {
+	request, err := s.useCase.StartMagicLink(s.ctx, s.user.Email)
+	s.Require().NoError(err)
+	s.Equal(domain.TokenPurposeMagicLink, s.stored.Purpose)
+	s.Contains(s.mailer.Messages()[0].TextBody, "https://id.example.com/auth/magic-link/verify?token=")
+
+	s.stored.ExpiresAt = time.Now().Add(-time.Second)
+	s.mockTokens.On("GetTokenByBinding", s.ctx, security.HashToken(request.RequestID), domain.TokenPurposeMagicLink).Return(s.stored, nil)
+	_, err = s.useCase.VerifyMagicLink(s.ctx, request.RequestID, "token")
+	s.ErrorIs(err, usecases.ErrInvalidLoginCode)
+}
+
+func TestPasswordlessUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(PasswordlessUseCaseTestSuite))
+}
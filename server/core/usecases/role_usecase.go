@@ -11,11 +11,12 @@ import (
 )
 
 type RoleUsecase struct {
-	repo output.RoleOutputPort
+	repo  output.RoleOutputPort
+	audit output.AuditLogger
 }
 
-func NewRoleUsecase(repo output.RoleOutputPort) *RoleUsecase {
-	return &RoleUsecase{repo: repo}
+func NewRoleUsecase(repo output.RoleOutputPort, audit output.AuditLogger) *RoleUsecase {
+	return &RoleUsecase{repo: repo, audit: audit}
 }
 
 type CreateRoleInput struct {
@@ -30,7 +31,22 @@ func (uc *RoleUsecase) CreateRole(ctx context.Context, input CreateRoleInput) (p
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
-	return uc.repo.CreateRole(ctx, role)
+	id, err := uc.repo.CreateRole(ctx, role)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+
+	recordAudit(ctx, uc.audit, &domain.AuditEntry{
+		Category:   domain.AuditCategoryAdmin,
+		Action:     "role.create",
+		TargetType: "role",
+		TargetID:   id.Hex(),
+		Changes: []domain.AuditChange{
+			{Field: "name", After: role.Name},
+			{Field: "description", After: role.Description},
+		},
+	})
+	return id, nil
 }
 
 func (uc *RoleUsecase) ReadRole(ctx context.Context, id string) (*domain.Role, error) {
@@ -57,10 +73,13 @@ func (uc *RoleUsecase) UpdateRole(ctx context.Context, id string, input UpdateRo
 		return nil, fmt.Errorf("failed to get role: %w", err)
 	}
 
+	var changes []domain.AuditChange
 	if input.Name != "" {
+		changes = appendChange(changes, "name", existingRole.Name, input.Name)
 		existingRole.Name = input.Name
 	}
 	if input.Description != "" {
+		changes = appendChange(changes, "description", existingRole.Description, input.Description)
 		existingRole.Description = input.Description
 	}
 
@@ -71,6 +90,13 @@ func (uc *RoleUsecase) UpdateRole(ctx context.Context, id string, input UpdateRo
 		return nil, err
 	}
 
+	recordAudit(ctx, uc.audit, &domain.AuditEntry{
+		Category:   domain.AuditCategoryAdmin,
+		Action:     "role.update",
+		TargetType: "role",
+		TargetID:   id,
+		Changes:    changes,
+	})
 	return existingRole, nil
 }
 
@@ -79,7 +105,17 @@ func (uc *RoleUsecase) DeleteRole(ctx context.Context, id string) error {
 	if err != nil {
 		return fmt.Errorf("invalid id: %w", err)
 	}
-	return uc.repo.DeleteRole(ctx, objectID)
+	if err := uc.repo.DeleteRole(ctx, objectID); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, uc.audit, &domain.AuditEntry{
+		Category:   domain.AuditCategoryAdmin,
+		Action:     "role.delete",
+		TargetType: "role",
+		TargetID:   id,
+	})
+	return nil
 }
 
 func (uc *RoleUsecase) GetAllRoles(ctx context.Context) ([]*domain.Role, error) {
@@ -0,0 +1,239 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"veritas/core/domain"
+	"veritas/internal/ports/output"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrSessionNotFound = errors.New("session not found")
+	ErrSessionExpired  = errors.New("session has expired")
+)
+
+// sessionTouchInterval limits how often a session's last use is written.
+const sessionTouchInterval = time.Minute
+
+// SessionPolicy configures how long sessions last and how many a user may
+// have.
+type SessionPolicy struct {
+	IdleTimeout     time.Duration // ends a session unused for this long; zero disables it
+	AbsoluteTimeout time.Duration // ends a session this long after login
+	MaxSessions     int           // per user, ending the least recently used beyond it; zero is unlimited
+}
+
+type SessionUsecase struct {
+	sessions output.SessionOutputPort
+	audit    output.AuditLogger
+	policy   SessionPolicy
+}
+
+func NewSessionUsecase(sessions output.SessionOutputPort, audit output.AuditLogger, policy SessionPolicy) *SessionUsecase {
+	return &SessionUsecase{sessions: sessions, audit: audit, policy: policy}
+}
+
+// CreateSession starts a session for a user who just authenticated with the
+// given methods, on the device making the request in ctx.
+func (uc *SessionUsecase) CreateSession(ctx context.Context, user *domain.User, amr []string) (*domain.Session, error) {
+	if uc.policy.MaxSessions > 0 {
+		existing, err := uc.activeSessions(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		// Sessions are sorted most recently used first.
+		for i := len(existing) - 1; i >= uc.policy.MaxSessions-1; i-- {
+			if err := uc.sessions.DeleteSession(ctx, existing[i].ID); err != nil {
+				return nil, err
+			}
+			entry := userAuditEntry(domain.AuditCategoryAuthentication, "session.evict", user.ID)
+			entry.Reason = fmt.Sprintf("session %s exceeded the limit of %d", existing[i].ID.Hex(), uc.policy.MaxSessions)
+			recordAudit(ctx, uc.audit, entry)
+		}
+	}
+
+	now := time.Now()
+	session := &domain.Session{
+		UserID:      user.ID,
+		AuthMethods: amr,
+		CreatedAt:   now,
+		LastSeenAt:  now,
+		ExpiresAt:   now.Add(uc.policy.AbsoluteTimeout),
+	}
+	if info := domain.RequestInfoFromContext(ctx); info != nil {
+		session.UserAgent = info.UserAgent
+		session.IP = info.IP
+	}
+	session.Device = describeDevice(session.UserAgent)
+
+	id, err := uc.sessions.CreateSession(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	session.ID = id
+
+	return session, nil
+}
+
+// ValidateSession returns the session with the given ID when it belongs to
+// the user and has not timed out, and records that it was used.
+func (uc *SessionUsecase) ValidateSession(ctx context.Context, sessionID string, userID string) (*domain.Session, error) {
+	objectID, err := primitive.ObjectIDFromHex(sessionID)
+	if err != nil {
+		return nil, ErrSessionNotFound
+	}
+	session, err := uc.sessions.GetSession(ctx, objectID)
+	if err != nil || session.UserID.Hex() != userID {
+		return nil, ErrSessionNotFound
+	}
+
+	now := time.Now()
+	if uc.expired(session, now) {
+		if err := uc.sessions.DeleteSession(ctx, session.ID); err != nil {
+			return nil, err
+		}
+		return nil, ErrSessionExpired
+	}
+
+	ip := session.IP
+	if info := domain.RequestInfoFromContext(ctx); info != nil {
+		ip = info.IP
+	}
+	if now.Sub(session.LastSeenAt) >= sessionTouchInterval || ip != session.IP {
+		if err := uc.sessions.TouchSession(ctx, session.ID, now, ip); err != nil {
+			return nil, err
+		}
+		session.LastSeenAt, session.IP = now, ip
+	}
+
+	return session, nil
+}
+
+// ListSessions returns the active sessions of a user, most recently used
+// first.
+func (uc *SessionUsecase) ListSessions(ctx context.Context, userID string) ([]*domain.Session, error) {
+	objectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id: %w", err)
+	}
+	return uc.activeSessions(ctx, objectID)
+}
+
+// RevokeSession ends one session of a user, signing that device out.
+func (uc *SessionUsecase) RevokeSession(ctx context.Context, userID string, sessionID string) error {
+	objectID, err := primitive.ObjectIDFromHex(sessionID)
+	if err != nil {
+		return ErrSessionNotFound
+	}
+	session, err := uc.sessions.GetSession(ctx, objectID)
+	if err != nil || session.UserID.Hex() != userID {
+		return ErrSessionNotFound
+	}
+
+	if err := uc.sessions.DeleteSession(ctx, session.ID); err != nil {
+		return err
+	}
+
+	entry := userAuditEntry(sessionAuditCategory(ctx, userID), "session.revoke", session.UserID)
+	entry.Reason = "session " + session.ID.Hex()
+	recordAudit(ctx, uc.audit, entry)
+	return nil
+}
+
+// RevokeAllSessions ends every session of a user and returns how many there
+// were.
+func (uc *SessionUsecase) RevokeAllSessions(ctx context.Context, userID string) (int64, error) {
+	objectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid id: %w", err)
+	}
+
+	count, err := uc.sessions.DeleteSessionsByUser(ctx, objectID)
+	if err != nil {
+		return 0, err
+	}
+
+	entry := userAuditEntry(sessionAuditCategory(ctx, userID), "session.revoke_all", objectID)
+	entry.Reason = fmt.Sprintf("%d sessions ended", count)
+	recordAudit(ctx, uc.audit, entry)
+	return count, nil
+}
+
+func (uc *SessionUsecase) activeSessions(ctx context.Context, userID primitive.ObjectID) ([]*domain.Session, error) {
+	sessions, err := uc.sessions.GetSessionsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	active := []*domain.Session{}
+	for _, session := range sessions {
+		if !uc.expired(session, now) {
+			active = append(active, session)
+		}
+	}
+	return active, nil
+}
+
+func (uc *SessionUsecase) expired(session *domain.Session, now time.Time) bool {
+	if !now.Before(session.ExpiresAt) {
+		return true
+	}
+	return uc.policy.IdleTimeout > 0 && now.Sub(session.LastSeenAt) > uc.policy.IdleTimeout
+}
+
+// sessionAuditCategory tells users ending their own sessions apart from
+// administrators ending someone else's.
+func sessionAuditCategory(ctx context.Context, userID string) string {
+	if info := domain.RequestInfoFromContext(ctx); info != nil && info.ActorID != "" && info.ActorID != userID {
+		return domain.AuditCategoryAdmin
+	}
+	return domain.AuditCategoryAccount
+}
+
+// describeDevice names the browser and operating system in a user agent,
+// such as "Firefox on Windows".
+func describeDevice(userAgent string) string {
+	browser := firstMatch(userAgent, [][2]string{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"Firefox/", "Firefox"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+		{"curl/", "curl"},
+	})
+	os := firstMatch(userAgent, [][2]string{
+		{"iPhone", "iOS"},
+		{"iPad", "iPadOS"},
+		{"Android", "Android"},
+		{"Windows", "Windows"},
+		{"Mac OS X", "macOS"},
+		{"CrOS", "ChromeOS"},
+		{"Linux", "Linux"},
+	})
+
+	switch {
+	case browser != "" && os != "":
+		return browser + " on " + os
+	case browser != "":
+		return browser
+	case os != "":
+		return os
+	default:
+		return "Unknown device"
+	}
+}
+
+func firstMatch(s string, patterns [][2]string) string {
+	for _, pattern := range patterns {
+		if strings.Contains(s, pattern[0]) {
+			return pattern[1]
+		}
+	}
+	return ""
+}
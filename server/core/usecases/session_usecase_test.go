@@ -0,0 +1,182 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+	"veritas/core/domain"
+	"veritas/core/usecases"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeSessions keeps sessions in memory.
+type fakeSessions struct {
+	sessions map[primitive.ObjectID]*domain.Session
+	touches  int
+}
+
+func (f *fakeSessions) CreateSession(ctx context.Context, session *domain.Session) (primitive.ObjectID, error) {
+	copied := *session
+	copied.ID = primitive.NewObjectID()
+	f.sessions[copied.ID] = &copied
+	return copied.ID, nil
+}
+
+func (f *fakeSessions) GetSession(ctx context.Context, id primitive.ObjectID) (*domain.Session, error) {
+	session, ok := f.sessions[id]
+	if !ok {
+		return nil, errors.New("session not found")
+	}
+	copied := *session
+	return &copied, nil
+}
+
+func (f *fakeSessions) GetSessionsByUser(ctx context.Context, userID primitive.ObjectID) ([]*domain.Session, error) {
+	var sessions []*domain.Session
+	for _, session := range f.sessions {
+		if session.UserID == userID {
+			copied := *session
+			sessions = append(sessions, &copied)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt) })
+	return sessions, nil
+}
+
+func (f *fakeSessions) TouchSession(ctx context.Context, id primitive.ObjectID, lastSeenAt time.Time, ip string) error {
+	f.sessions[id].LastSeenAt = lastSeenAt
+	f.sessions[id].IP = ip
+	f.touches++
+	return nil
+}
+
+func (f *fakeSessions) DeleteSession(ctx context.Context, id primitive.ObjectID) error {
+	delete(f.sessions, id)
+	return nil
+}
+
+func (f *fakeSessions) DeleteSessionsByUser(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	var count int64
+	for id, session := range f.sessions {
+		if session.UserID == userID {
+			delete(f.sessions, id)
+			count++
+		}
+	}
+	return count, nil
+}
+
+type SessionUseCaseTestSuite struct {
+	suite.Suite
+	sessions *fakeSessions
+	audit    *fakeAuditLog
+	useCase  *usecases.SessionUsecase
+	ctx      context.Context
+	user     *domain.User
+}
+
+func (s *SessionUseCaseTestSuite) SetupTest() {
+	s.sessions = &fakeSessions{sessions: map[primitive.ObjectID]*domain.Session{}}
+	s.audit = &fakeAuditLog{}
+	s.useCase = usecases.NewSessionUsecase(s.sessions, s.audit, usecases.SessionPolicy{
+		IdleTimeout:     time.Hour,
+		AbsoluteTimeout: 24 * time.Hour,
+		MaxSessions:     2,
+	})
+	s.user = &domain.User{ID: primitive.NewObjectID(), Email: "test@example.com"}
+	s.ctx = domain.WithRequestInfo(context.Background(), &domain.RequestInfo{
+		ActorID:   s.user.ID.Hex(),
+		IP:        "192.0.2.1",
+		UserAgent: "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_5) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.5 Safari/605.1.15",
+	})
+}
+
+func (s *SessionUseCaseTestSuite) TestCreateAndValidateSession() {
+	// Test case 1: Login starts a session describing the device
+	session, err := s.useCase.CreateSession(s.ctx, s.user, []string{"pwd", "otp"})
+	s.Require().NoError(err)
+	s.Equal("Safari on macOS", session.Device)
+	s.Equal("192.0.2.1", session.IP)
+	s.Equal([]string{"pwd", "otp"}, session.AuthMethods)
+	s.WithinDuration(time.Now().Add(24*time.Hour), session.ExpiresAt, time.Second)
+
+	// Test case 2: The session is valid for its user only
+	_, err = s.useCase.ValidateSession(s.ctx, session.ID.Hex(), s.user.ID.Hex())
+	s.NoError(err)
+	_, err = s.useCase.ValidateSession(s.ctx, session.ID.Hex(), primitive.NewObjectID().Hex())
+	s.ErrorIs(err, usecases.ErrSessionNotFound)
+	_, err = s.useCase.ValidateSession(s.ctx, "", s.user.ID.Hex())
+	s.ErrorIs(err, usecases.ErrSessionNotFound)
+
+	// Test case 3: A new address is recorded without waiting for the touch interval
+	moved := domain.WithRequestInfo(context.Background(), &domain.RequestInfo{IP: "198.51.100.7"})
+	_, err = s.useCase.ValidateSession(moved, session.ID.Hex(), s.user.ID.Hex())
+	s.NoError(err)
+	s.Equal("198.51.100.7", s.sessions.sessions[session.ID].IP)
+	s.Equal(1, s.sessions.touches)
+
+	// Test case 4: Idle sessions end
+	s.sessions.sessions[session.ID].LastSeenAt = time.Now().Add(-2 * time.Hour)
+	_, err = s.useCase.ValidateSession(s.ctx, session.ID.Hex(), s.user.ID.Hex())
+	s.ErrorIs(err, usecases.ErrSessionExpired)
+	s.Empty(s.sessions.sessions)
+
+	// Test case 5: Sessions end at the absolute timeout however much they are used
+	session, err = s.useCase.CreateSession(s.ctx, s.user, []string{"pwd"})
+	s.Require().NoError(err)
+	s.sessions.sessions[session.ID].ExpiresAt = time.Now().Add(-time.Second)
+	_, err = s.useCase.ValidateSession(s.ctx, session.ID.Hex(), s.user.ID.Hex())
+	s.ErrorIs(err, usecases.ErrSessionExpired)
+}
+
+func (s *SessionUseCaseTestSuite) TestMaxSessions() {
+	first, err := s.useCase.CreateSession(s.ctx, s.user, []string{"pwd"})
+	s.Require().NoError(err)
+	s.sessions.sessions[first.ID].LastSeenAt = time.Now().Add(-time.Minute)
+	second, err := s.useCase.CreateSession(s.ctx, s.user, []string{"pwd"})
+	s.Require().NoError(err)
+
+	// Test case 1: Going beyond the limit ends the least recently used session
+	third, err := s.useCase.CreateSession(s.ctx, s.user, []string{"pwd"})
+	s.Require().NoError(err)
+	sessions, err := s.useCase.ListSessions(s.ctx, s.user.ID.Hex())
+	s.NoError(err)
+	s.Len(sessions, 2)
+	s.NotContains(s.sessions.sessions, first.ID)
+	s.Contains(s.sessions.sessions, second.ID)
+	s.Contains(s.sessions.sessions, third.ID)
+	s.Equal([]string{"session.evict:success"}, s.audit.actions())
+}
+
+func (s *SessionUseCaseTestSuite) TestRevokeSessions() {
+	session, err := s.useCase.CreateSession(s.ctx, s.user, []string{"pwd"})
+	s.Require().NoError(err)
+
+	// Test case 1: Sessions of other users cannot be revoked through this user
+	err = s.useCase.RevokeSession(s.ctx, primitive.NewObjectID().Hex(), session.ID.Hex())
+	s.ErrorIs(err, usecases.ErrSessionNotFound)
+
+	// Test case 2: Revoking a session ends it
+	s.NoError(s.useCase.RevokeSession(s.ctx, s.user.ID.Hex(), session.ID.Hex()))
+	_, err = s.useCase.ValidateSession(s.ctx, session.ID.Hex(), s.user.ID.Hex())
+	s.ErrorIs(err, usecases.ErrSessionNotFound)
+	s.Equal(domain.AuditCategoryAccount, s.audit.entries[0].Category)
+
+	// Test case 3: Administrators can end every session of a user
+	_, err = s.useCase.CreateSession(s.ctx, s.user, []string{"pwd"})
+	s.Require().NoError(err)
+	admin := domain.WithRequestInfo(context.Background(), &domain.RequestInfo{ActorID: primitive.NewObjectID().Hex()})
+	count, err := s.useCase.RevokeAllSessions(admin, s.user.ID.Hex())
+	s.NoError(err)
+	s.Equal(int64(1), count)
+	s.Empty(s.sessions.sessions)
+	s.Equal(domain.AuditCategoryAdmin, s.audit.entries[1].Category)
+}
+
+func TestSessionUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(SessionUseCaseTestSuite))
+}
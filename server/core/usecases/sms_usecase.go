@@ -0,0 +1,204 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+	"veritas/core/domain"
+	"veritas/internal/ports/output"
+	"veritas/internal/security"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	smsCodeTTL         = 5 * time.Minute
+	smsCodeDigits      = 6
+	smsRateLimitWindow = time.Hour
+)
+
+var (
+	ErrInvalidPhoneNumber = errors.New("phone number must be in E.164 format")
+	ErrPhoneNotVerified   = errors.New("phone number is not verified")
+	ErrSMSRateLimited     = errors.New("too many messages sent to this phone number, try again later")
+)
+
+var e164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+type SMSUsecase struct {
+	users     output.UserOutputPort
+	tokens    output.OneTimeTokenOutputPort
+	sender    output.SMSSender
+	limits    output.RateLimitOutputPort
+	audit     output.AuditLogger
+	codeKey   []byte
+	rateLimit int
+}
+
+// NewSMSUsecase creates an SMSUsecase. codeKey keys the hashes of stored
+// codes, and rateLimit is the number of messages a phone number may receive
+// per hour.
+func NewSMSUsecase(users output.UserOutputPort, tokens output.OneTimeTokenOutputPort, sender output.SMSSender, limits output.RateLimitOutputPort, audit output.AuditLogger, codeKey []byte, rateLimit int) *SMSUsecase {
+	return &SMSUsecase{users: users, tokens: tokens, sender: sender, limits: limits, audit: audit, codeKey: codeKey, rateLimit: rateLimit}
+}
+
+// SetPhoneNumber stores an unverified phone number for the user and texts it
+// a verification code.
+func (uc *SMSUsecase) SetPhoneNumber(ctx context.Context, userID string, phoneNumber string) error {
+	if !e164Pattern.MatchString(phoneNumber) {
+		return ErrInvalidPhoneNumber
+	}
+
+	user, err := uc.userByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	entry := userAuditEntry(domain.AuditCategoryAccount, "phone.set", user.ID)
+	entry.Changes = appendChange(nil, "phoneNumber", user.PhoneNumber, phoneNumber)
+	user.PhoneNumber = phoneNumber
+	user.PhoneVerified = false
+	user.UpdatedAt = time.Now()
+	if err := uc.users.UpdateUser(ctx, user.ID, user); err != nil {
+		return err
+	}
+	recordAudit(ctx, uc.audit, entry)
+
+	return uc.sendCode(ctx, user, domain.TokenPurposePhoneVerify, "Your verification code is %s")
+}
+
+// VerifyPhone marks the user's phone number as verified with a code from
+// SetPhoneNumber.
+func (uc *SMSUsecase) VerifyPhone(ctx context.Context, userID string, code string) error {
+	user, err := uc.userByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	entry := userAuditEntry(domain.AuditCategoryAccount, "phone.verify", user.ID)
+	if user.PhoneNumber == "" || !uc.checkCode(ctx, user, domain.TokenPurposePhoneVerify, code) {
+		recordAudit(ctx, uc.audit, failed(entry, ErrInvalidMFACode.Error()))
+		return ErrInvalidMFACode
+	}
+
+	user.PhoneVerified = true
+	user.UpdatedAt = time.Now()
+	if err := uc.users.UpdateUser(ctx, user.ID, user); err != nil {
+		return err
+	}
+	recordAudit(ctx, uc.audit, entry)
+	return nil
+}
+
+// RemovePhoneNumber removes the user's phone number, and with it SMS as a
+// second factor.
+func (uc *SMSUsecase) RemovePhoneNumber(ctx context.Context, userID string) error {
+	user, err := uc.userByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	entry := userAuditEntry(domain.AuditCategoryAccount, "phone.remove", user.ID)
+	entry.Changes = appendChange(nil, "phoneNumber", user.PhoneNumber, "")
+	user.PhoneNumber = ""
+	user.PhoneVerified = false
+	user.UpdatedAt = time.Now()
+	if err := uc.users.UpdateUser(ctx, user.ID, user); err != nil {
+		return err
+	}
+	recordAudit(ctx, uc.audit, entry)
+	return nil
+}
+
+// SendLoginCode texts a second-factor code to the user's verified phone.
+func (uc *SMSUsecase) SendLoginCode(ctx context.Context, user *domain.User) error {
+	if !uc.HasSMS(user) {
+		return ErrPhoneNotVerified
+	}
+	return uc.sendCode(ctx, user, domain.TokenPurposeSMSLogin, "Your sign-in code is %s")
+}
+
+// VerifyLoginCode checks a second-factor code sent by SendLoginCode.
+func (uc *SMSUsecase) VerifyLoginCode(ctx context.Context, user *domain.User, code string) error {
+	entry := userAuditEntry(domain.AuditCategoryAuthentication, "auth.sms", user.ID)
+	if !uc.HasSMS(user) || !uc.checkCode(ctx, user, domain.TokenPurposeSMSLogin, code) {
+		recordAudit(ctx, uc.audit, failed(entry, ErrInvalidMFACode.Error()))
+		return ErrInvalidMFACode
+	}
+	recordAudit(ctx, uc.audit, entry)
+	return nil
+}
+
+// HasSMS reports whether SMS codes are offered as a second factor.
+func (uc *SMSUsecase) HasSMS(user *domain.User) bool {
+	return user.PhoneNumber != "" && user.PhoneVerified
+}
+
+// sendCode replaces any pending code of the same purpose. Messages count
+// against the per-number limit whether or not they are delivered, to keep
+// the cost of SMS pumping bounded.
+func (uc *SMSUsecase) sendCode(ctx context.Context, user *domain.User, purpose string, format string) error {
+	counter, err := uc.limits.Hit(ctx, "sms:"+user.PhoneNumber, smsRateLimitWindow)
+	if err != nil {
+		return err
+	}
+	if counter.Count > uc.rateLimit {
+		return ErrSMSRateLimited
+	}
+
+	code, err := security.RandomDigits(smsCodeDigits)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.tokens.DeleteTokensByUser(ctx, user.ID, purpose); err != nil {
+		return err
+	}
+	_, err = uc.tokens.CreateToken(ctx, &domain.OneTimeToken{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		TokenHash: uc.codeHash(user, code),
+		ExpiresAt: time.Now().Add(smsCodeTTL),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = uc.sender.SendSMS(ctx, &domain.SMSMessage{
+		To:   user.PhoneNumber,
+		Body: fmt.Sprintf(format, code),
+	})
+	if err != nil {
+		return err
+	}
+
+	recordAudit(ctx, uc.audit, userAuditEntry(domain.AuditCategoryAuthentication, "sms."+purpose+"_sent", user.ID))
+	return nil
+}
+
+func (uc *SMSUsecase) checkCode(ctx context.Context, user *domain.User, purpose string, code string) bool {
+	stored, err := uc.tokens.GetTokenByUser(ctx, user.ID, purpose)
+	if err != nil {
+		return false
+	}
+	return redeemOneTimeToken(ctx, uc.tokens, stored, uc.codeHash(user, code))
+}
+
+// codeHash also covers the phone number, so a code sent to a previous number
+// cannot verify a new one.
+func (uc *SMSUsecase) codeHash(user *domain.User, code string) string {
+	return security.HashCode(uc.codeKey, user.ID.Hex()+":"+user.PhoneNumber+":"+code)
+}
+
+func (uc *SMSUsecase) userByID(ctx context.Context, id string) (*domain.User, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id: %w", err)
+	}
+	user, err := uc.users.GetUser(ctx, objectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user, nil
+}
@@ -0,0 +1,147 @@
+package usecases_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+	"veritas/core/domain"
+	"veritas/core/usecases"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type MockSMSSender struct {
+	mock.Mock
+}
+
+func (m *MockSMSSender) SendSMS(ctx context.Context, message *domain.SMSMessage) error {
+	args := m.Called(ctx, message)
+	return args.Error(0)
+}
+
+type MockRateLimitOutputPort struct {
+	mock.Mock
+	counts map[string]int
+}
+
+func (m *MockRateLimitOutputPort) Hit(ctx context.Context, key string, window time.Duration) (*domain.RateLimitCounter, error) {
+	m.Called(ctx, key, window)
+	if m.counts == nil {
+		m.counts = map[string]int{}
+	}
+	m.counts[key]++
+	return &domain.RateLimitCounter{Key: key, Count: m.counts[key], ResetAt: time.Now().Add(window)}, nil
+}
+
+type SMSUseCaseTestSuite struct {
+	suite.Suite
+	mockOutputPort *MockUserOutputPort
+	mockTokens     *MockOneTimeTokenOutputPort
+	mockSender     *MockSMSSender
+	mockLimits     *MockRateLimitOutputPort
+	smsUseCase     *usecases.SMSUsecase
+	audit          *fakeAuditLog
+	ctx            context.Context
+	user           *domain.User
+	stored         *domain.OneTimeToken
+	sent           []string
+}
+
+func (s *SMSUseCaseTestSuite) SetupTest() {
+	s.audit = &fakeAuditLog{}
+	s.mockOutputPort = new(MockUserOutputPort)
+	s.mockTokens = new(MockOneTimeTokenOutputPort)
+	s.mockSender = new(MockSMSSender)
+	s.mockLimits = new(MockRateLimitOutputPort)
+	s.smsUseCase = usecases.NewSMSUsecase(s.mockOutputPort, s.mockTokens, s.mockSender, s.mockLimits, s.audit, make([]byte, 32), 3)
+	s.ctx = context.Background()
+	s.user = &domain.User{ID: primitive.NewObjectID(), Email: "test@example.com"}
+	s.sent = nil
+
+	s.mockOutputPort.On("GetUser", s.ctx, s.user.ID).Return(s.user, nil)
+	s.mockOutputPort.On("UpdateUser", s.ctx, s.user.ID, s.user).Return(nil)
+	s.mockLimits.On("Hit", s.ctx, mock.Anything, time.Hour)
+	s.mockTokens.On("DeleteTokensByUser", s.ctx, s.user.ID, mock.Anything).Return(nil)
+	s.mockTokens.On("CreateToken", s.ctx, mock.AnythingOfType("*domain.OneTimeToken")).Run(func(args mock.Arguments) {
+		s.stored = args.Get(1).(*domain.OneTimeToken)
+		s.stored.ID = primitive.NewObjectID()
+	}).Return(primitive.NewObjectID(), nil)
+	s.mockSender.On("SendSMS", s.ctx, mock.AnythingOfType("*domain.SMSMessage")).Run(func(args mock.Arguments) {
+		message := args.Get(1).(*domain.SMSMessage)
+		s.sent = append(s.sent, regexp.MustCompile(`\d{6}`).FindString(message.Body))
+	}).Return(nil)
+}
+
+func (s *SMSUseCaseTestSuite) expectRedeem() {
+	s.mockTokens.On("GetTokenByUser", s.ctx, s.user.ID, s.stored.Purpose).Return(s.stored, nil)
+	s.mockTokens.On("IncrementAttempts", s.ctx, s.stored.ID).Return(1, nil)
+	s.mockTokens.On("ConsumeToken", s.ctx, s.stored.TokenHash, s.stored.Purpose).Return(s.stored, nil)
+}
+
+func (s *SMSUseCaseTestSuite) TestPhoneVerification() {
+	// Test case 1: Numbers must be in E.164 format
+	err := s.smsUseCase.SetPhoneNumber(s.ctx, s.user.ID.Hex(), "555-1234")
+	s.ErrorIs(err, usecases.ErrInvalidPhoneNumber)
+
+	// Test case 2: A new number is unverified until the texted code is confirmed
+	err = s.smsUseCase.SetPhoneNumber(s.ctx, s.user.ID.Hex(), "+15551234567")
+	s.NoError(err)
+	s.Equal("+15551234567", s.user.PhoneNumber)
+	s.False(s.smsUseCase.HasSMS(s.user))
+	s.Require().Len(s.sent, 1)
+	s.expectRedeem()
+
+	err = s.smsUseCase.VerifyPhone(s.ctx, s.user.ID.Hex(), s.sent[0])
+	s.NoError(err)
+	s.True(s.smsUseCase.HasSMS(s.user))
+
+	// Test case 3: A verification code cannot be used as a login code
+	err = s.smsUseCase.SendLoginCode(s.ctx, s.user)
+	s.NoError(err)
+	s.Equal(domain.TokenPurposeSMSLogin, s.stored.Purpose)
+	s.NotEqual(s.sent[0], s.sent[1])
+}
+
+func (s *SMSUseCaseTestSuite) TestLoginCode() {
+	// Test case 1: Unverified numbers do not receive login codes
+	s.user.PhoneNumber = "+15551234567"
+	err := s.smsUseCase.SendLoginCode(s.ctx, s.user)
+	s.ErrorIs(err, usecases.ErrPhoneNotVerified)
+
+	// Test case 2: The texted code is accepted once
+	s.user.PhoneVerified = true
+	err = s.smsUseCase.SendLoginCode(s.ctx, s.user)
+	s.NoError(err)
+	s.expectRedeem()
+	err = s.smsUseCase.VerifyLoginCode(s.ctx, s.user, "000000x")
+	s.ErrorIs(err, usecases.ErrInvalidMFACode)
+	err = s.smsUseCase.VerifyLoginCode(s.ctx, s.user, s.sent[0])
+	s.NoError(err)
+
+	// Test case 3: A code sent to a previous number is rejected
+	s.user.PhoneNumber = "+15557654321"
+	err = s.smsUseCase.VerifyLoginCode(s.ctx, s.user, s.sent[0])
+	s.ErrorIs(err, usecases.ErrInvalidMFACode)
+}
+
+func (s *SMSUseCaseTestSuite) TestRateLimit() {
+	s.user.PhoneNumber = "+15551234567"
+	s.user.PhoneVerified = true
+
+	// Test case 1: Messages are sent up to the limit
+	for i := 0; i < 3; i++ {
+		s.NoError(s.smsUseCase.SendLoginCode(s.ctx, s.user))
+	}
+
+	// Test case 2: Further messages to the number are refused
+	err := s.smsUseCase.SendLoginCode(s.ctx, s.user)
+	s.ErrorIs(err, usecases.ErrSMSRateLimited)
+	s.Len(s.sent, 3)
+}
+
+func TestSMSUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(SMSUseCaseTestSuite))
+}
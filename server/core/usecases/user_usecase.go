@@ -2,20 +2,42 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 	"veritas/core/domain"
 	"veritas/internal/ports/output"
+	"veritas/internal/security"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+var (
+	ErrIncorrectPassword       = errors.New("current password is incorrect")
+	ErrAccountInactive         = errors.New("account is not active")
+	ErrInvalidStatusTransition = errors.New("invalid status transition")
+	ErrEmailTaken              = errors.New("email address is already in use")
+)
+
+// userStatusTransitions lists the statuses each status can change to.
+var userStatusTransitions = map[domain.UserStatus][]domain.UserStatus{
+	domain.UserStatusPending:   {domain.UserStatusActive, domain.UserStatusSuspended, domain.UserStatusDisabled},
+	domain.UserStatusActive:    {domain.UserStatusSuspended, domain.UserStatusDisabled, domain.UserStatusLocked},
+	domain.UserStatusSuspended: {domain.UserStatusActive, domain.UserStatusDisabled},
+	domain.UserStatusLocked:    {domain.UserStatusActive, domain.UserStatusDisabled},
+	domain.UserStatusDisabled:  {domain.UserStatusActive},
+}
+
 type UserUsecase struct {
-	repo output.UserOutputPort
+	repo      output.UserOutputPort
+	passwords *PasswordPolicyUsecase
+	audit     output.AuditLogger
 }
 
-func NewUserUsecase(repo output.UserOutputPort) *UserUsecase {
-	return &UserUsecase{repo: repo}
+func NewUserUsecase(repo output.UserOutputPort, passwords *PasswordPolicyUsecase, audit output.AuditLogger) *UserUsecase {
+	return &UserUsecase{repo: repo, passwords: passwords, audit: audit}
 }
 
 type CreateUserInput struct {
@@ -24,15 +46,41 @@ type CreateUserInput struct {
 	Password string
 }
 
+// CreateUser signs up a user. It returns ErrEmailTaken when the address
+// already belongs to a user of the organization.
 func (uc *UserUsecase) CreateUser(ctx context.Context, input CreateUserInput) (primitive.ObjectID, error) {
+	if _, err := uc.repo.GetUserByEmail(ctx, input.Email); err == nil {
+		return primitive.NilObjectID, ErrEmailTaken
+	}
+
+	// Accounts are pending until the email address is verified.
 	user := &domain.User{
 		Username:  input.Name,
 		Email:     input.Email,
-		Password:  input.Password, // TODO: hash and salt this!
+		Status:    domain.UserStatusPending,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	return uc.repo.CreateUser(ctx, user)
+	user.StatusHistory = []domain.UserStatusChange{{To: domain.UserStatusPending, Reason: "account created", ChangedAt: user.CreatedAt}}
+	if err := uc.passwords.SetPassword(ctx, user, input.Password); err != nil {
+		return primitive.NilObjectID, err
+	}
+	id, err := uc.repo.CreateUser(ctx, user)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+
+	recordAudit(ctx, uc.audit, &domain.AuditEntry{
+		Category:   domain.AuditCategoryAccount,
+		Action:     "user.create",
+		TargetType: "user",
+		TargetID:   id.Hex(),
+		Changes: []domain.AuditChange{
+			{Field: "name", After: user.Username},
+			{Field: "email", After: user.Email},
+		},
+	})
+	return id, nil
 }
 
 func (uc *UserUsecase) ReadUser(ctx context.Context, id string) (*domain.User, error) {
@@ -60,14 +108,24 @@ func (uc *UserUsecase) UpdateUser(ctx context.Context, id string, input UpdateUs
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
+	var changes []domain.AuditChange
 	if input.Name != "" {
+		changes = appendChange(changes, "name", existingUser.Username, input.Name)
 		existingUser.Username = input.Name
 	}
-	if input.Email != "" {
+	if input.Email != "" && input.Email != existingUser.Email {
+		if other, err := uc.repo.GetUserByEmail(ctx, input.Email); err == nil && other.ID != existingUser.ID {
+			return nil, ErrEmailTaken
+		}
+		changes = appendChange(changes, "email", existingUser.Email, input.Email)
 		existingUser.Email = input.Email
+		existingUser.EmailVerified = false
 	}
 	if input.Password != "" {
-		existingUser.Password = input.Password // TODO: hash and salt this!
+		if err := uc.passwords.SetPassword(ctx, existingUser, input.Password); err != nil {
+			return nil, err
+		}
+		changes = append(changes, secretChange("password"))
 	}
 	existingUser.UpdatedAt = time.Now()
 
@@ -76,15 +134,68 @@ func (uc *UserUsecase) UpdateUser(ctx context.Context, id string, input UpdateUs
 		return nil, err
 	}
 
+	recordAudit(ctx, uc.audit, &domain.AuditEntry{
+		Category:   domain.AuditCategoryAdmin,
+		Action:     "user.update",
+		TargetType: "user",
+		TargetID:   id,
+		Changes:    changes,
+	})
 	return existingUser, nil
 }
 
+// ChangePassword replaces the password of a user who knows the current one.
+func (uc *UserUsecase) ChangePassword(ctx context.Context, id string, currentPassword, newPassword string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("invalid id: %w", err)
+	}
+
+	user, err := uc.repo.GetUser(ctx, objectID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	entry := &domain.AuditEntry{
+		Category:   domain.AuditCategoryAccount,
+		Action:     "user.password_change",
+		TargetType: "user",
+		TargetID:   id,
+	}
+	if !security.CheckPassword(user.Password, currentPassword) {
+		recordAudit(ctx, uc.audit, failed(entry, ErrIncorrectPassword.Error()))
+		return ErrIncorrectPassword
+	}
+
+	if err := uc.passwords.SetPassword(ctx, user, newPassword); err != nil {
+		recordAudit(ctx, uc.audit, failed(entry, err.Error()))
+		return err
+	}
+	user.UpdatedAt = time.Now()
+	if err := uc.repo.UpdateUser(ctx, objectID, user); err != nil {
+		return err
+	}
+
+	entry.Changes = []domain.AuditChange{secretChange("password")}
+	recordAudit(ctx, uc.audit, entry)
+	return nil
+}
+
 func (uc *UserUsecase) DeleteUser(ctx context.Context, id string) error {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return fmt.Errorf("invalid id: %w", err)
 	}
-	return uc.repo.DeleteUser(ctx, objectID)
+	if err := uc.repo.DeleteUser(ctx, objectID); err != nil {
+		return err
+	}
+
+	recordAudit(ctx, uc.audit, &domain.AuditEntry{
+		Category:   domain.AuditCategoryAdmin,
+		Action:     "user.delete",
+		TargetType: "user",
+		TargetID:   id,
+	})
+	return nil
 }
 
 func (uc *UserUsecase) GetAllUsers(ctx context.Context) ([]*domain.User, error) {
@@ -92,14 +203,119 @@ func (uc *UserUsecase) GetAllUsers(ctx context.Context) ([]*domain.User, error)
 }
 
 func (uc *UserUsecase) VerifyUser(ctx context.Context, email, password string) (*domain.User, error) {
+	entry := &domain.AuditEntry{
+		Category: domain.AuditCategoryAuthentication,
+		Action:   "auth.password",
+		Outcome:  domain.AuditOutcomeFailure,
+	}
+
 	user, err := uc.repo.GetUserByEmail(ctx, email)
 	if err != nil {
+		security.SimulatePasswordCheck(password)
+		entry.TargetType, entry.TargetID, entry.Reason = "email", email, "unknown email"
+		recordAudit(ctx, uc.audit, entry)
 		return nil, fmt.Errorf("invalid credentials")
 	}
 
-	if user.Password != password { // TODO: Compare hashed passwords
+	entry.TargetType, entry.TargetID = "user", user.ID.Hex()
+	if !security.CheckPassword(user.Password, password) {
+		entry.Reason = "invalid password"
+		recordAudit(ctx, uc.audit, entry)
 		return nil, fmt.Errorf("invalid credentials")
 	}
+	if err := uc.CheckAccountStatus(user); err != nil {
+		entry.Reason = err.Error()
+		recordAudit(ctx, uc.audit, entry)
+		return nil, err
+	}
+	entry.Outcome = domain.AuditOutcomeSuccess
+	recordAudit(ctx, uc.audit, entry)
+
+	// Accounts created before passwords were hashed are upgraded on their
+	// next successful login.
+	if !security.IsPasswordHash(user.Password) {
+		if user.Password, err = security.HashPassword(password); err != nil {
+			return nil, err
+		}
+		if err := uc.repo.UpdateUser(ctx, user.ID, user); err != nil {
+			return nil, err
+		}
+	}
 
 	return user, nil
 }
+
+// RecordLogin records that the user was issued an access token after
+// authenticating with the given methods.
+func (uc *UserUsecase) RecordLogin(ctx context.Context, user *domain.User, amr []string) {
+	recordAudit(ctx, uc.audit, &domain.AuditEntry{
+		Category:   domain.AuditCategoryAuthentication,
+		Action:     "auth.login",
+		Reason:     "methods: " + strings.Join(amr, ", "),
+		ActorID:    user.ID.Hex(),
+		TargetType: "user",
+		TargetID:   user.ID.Hex(),
+	})
+}
+
+// CheckAccountStatus returns an error wrapping ErrAccountInactive when the
+// user may not sign in.
+func (uc *UserUsecase) CheckAccountStatus(user *domain.User) error {
+	if !user.CanSignIn() {
+		return fmt.Errorf("%w: %s", ErrAccountInactive, user.CurrentStatus())
+	}
+	return nil
+}
+
+// ChangeStatus moves a user to another status, recording who changed it and
+// why. Users who can no longer sign in lose access with their next request.
+func (uc *UserUsecase) ChangeStatus(ctx context.Context, id string, status domain.UserStatus, reason, actorID string) (*domain.User, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id: %w", err)
+	}
+
+	user, err := uc.repo.GetUser(ctx, objectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	from := user.CurrentStatus()
+	if err := transitionStatus(user, status, reason, actorID); err != nil {
+		return nil, err
+	}
+	user.UpdatedAt = time.Now()
+
+	if err := uc.repo.UpdateUser(ctx, objectID, user); err != nil {
+		return nil, err
+	}
+
+	recordAudit(ctx, uc.audit, &domain.AuditEntry{
+		Category:   domain.AuditCategoryAdmin,
+		Action:     "user.status_change",
+		Reason:     reason,
+		ActorID:    actorID,
+		TargetType: "user",
+		TargetID:   id,
+		Changes:    []domain.AuditChange{{Field: "status", Before: string(from), After: string(status)}},
+	})
+	return user, nil
+}
+
+// transitionStatus changes the status of user if the state machine allows
+// it. The user is not saved.
+func transitionStatus(user *domain.User, status domain.UserStatus, reason, actorID string) error {
+	from := user.CurrentStatus()
+	if !slices.Contains(userStatusTransitions[from], status) {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidStatusTransition, from, status)
+	}
+
+	user.Status = status
+	user.StatusHistory = append(user.StatusHistory, domain.UserStatusChange{
+		From:      from,
+		To:        status,
+		Reason:    reason,
+		ActorID:   actorID,
+		ChangedAt: time.Now(),
+	})
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"veritas/core/domain"
 	"veritas/core/usecases"
+	"veritas/internal/security"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -39,8 +40,8 @@ func (m *MockUserOutputPort) DeleteUser(ctx context.Context, id primitive.Object
 	return args.Error(0)
 }
 
-func (m *MockUserOutputPort) GetUserByUsername(ctx context.Context, username string) (*domain.User, error) {
-	args := m.Called(ctx, username)
+func (m *MockUserOutputPort) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	args := m.Called(ctx, email)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -59,12 +60,14 @@ type UserUseCaseTestSuite struct {
 	suite.Suite
 	mockOutputPort *MockUserOutputPort
 	userUseCase    *usecases.UserUsecase
+	audit          *fakeAuditLog
 	ctx            context.Context
 }
 
 func (s *UserUseCaseTestSuite) SetupTest() {
+	s.audit = &fakeAuditLog{}
 	s.mockOutputPort = new(MockUserOutputPort)
-	s.userUseCase = usecases.NewUserUsecase(s.mockOutputPort)
+	s.userUseCase = usecases.NewUserUsecase(s.mockOutputPort, usecases.NewPasswordPolicyUsecase(security.DefaultPasswordPolicy(), nil), s.audit)
 	s.ctx = context.Background()
 }
 
@@ -72,11 +75,12 @@ func (s *UserUseCaseTestSuite) TestCreateUser() {
 	createUserInput := usecases.CreateUserInput{
 		Name:     "testuser",
 		Email:    "test@example.com",
-		Password: "password123",
+		Password: "lantern-orbit-42",
 	}
 	expectedID := primitive.NewObjectID()
 
 	// Test case 1: Successful user creation
+	s.mockOutputPort.On("GetUserByEmail", s.ctx, createUserInput.Email).Return(nil, errors.New("user not found")).Once()
 	s.mockOutputPort.On("CreateUser", s.ctx, mock.AnythingOfType("*domain.User")).Return(expectedID, nil).Once()
 	id, err := s.userUseCase.CreateUser(s.ctx, createUserInput)
 	s.NoError(err)
@@ -86,12 +90,146 @@ func (s *UserUseCaseTestSuite) TestCreateUser() {
 	// Test case 2: Error during user creation
 	s.SetupTest() // Reset mock for new test case
 	expectedError := errors.New("failed to create user")
+	s.mockOutputPort.On("GetUserByEmail", s.ctx, createUserInput.Email).Return(nil, errors.New("user not found")).Once()
 	s.mockOutputPort.On("CreateUser", s.ctx, mock.AnythingOfType("*domain.User")).Return(primitive.NilObjectID, expectedError).Once()
 	id, err = s.userUseCase.CreateUser(s.ctx, createUserInput)
 	s.Error(err)
 	s.Equal(primitive.NilObjectID, id)
 	s.Equal(expectedError, err)
 	s.mockOutputPort.AssertExpectations(s.T())
+
+	// Test case 3: Address already used by another user
+	s.SetupTest() // Reset mock for new test case
+	s.mockOutputPort.On("GetUserByEmail", s.ctx, createUserInput.Email).Return(&domain.User{ID: primitive.NewObjectID(), Email: createUserInput.Email}, nil).Once()
+	id, err = s.userUseCase.CreateUser(s.ctx, createUserInput)
+	s.ErrorIs(err, usecases.ErrEmailTaken)
+	s.Equal(primitive.NilObjectID, id)
+	s.mockOutputPort.AssertExpectations(s.T())
+
+	// Test case 4: Password rejected by the policy
+	s.SetupTest() // Reset mock for new test case
+	createUserInput.Password = "short"
+	s.mockOutputPort.On("GetUserByEmail", s.ctx, createUserInput.Email).Return(nil, errors.New("user not found")).Once()
+	id, err = s.userUseCase.CreateUser(s.ctx, createUserInput)
+	s.ErrorIs(err, security.ErrPasswordPolicy)
+	s.Equal(primitive.NilObjectID, id)
+	s.mockOutputPort.AssertExpectations(s.T())
+}
+
+func (s *UserUseCaseTestSuite) TestVerifyUser() {
+	hash, err := security.HashPassword("password123")
+	s.Require().NoError(err)
+	existingUser := &domain.User{
+		ID:       primitive.NewObjectID(),
+		Email:    "test@example.com",
+		Password: hash,
+	}
+
+	// Test case 1: Correct password
+	s.mockOutputPort.On("GetUserByEmail", s.ctx, existingUser.Email).Return(existingUser, nil).Once()
+	user, err := s.userUseCase.VerifyUser(s.ctx, existingUser.Email, "password123")
+	s.NoError(err)
+	s.Equal(existingUser, user)
+
+	// Test case 2: Wrong password
+	s.mockOutputPort.On("GetUserByEmail", s.ctx, existingUser.Email).Return(existingUser, nil).Once()
+	user, err = s.userUseCase.VerifyUser(s.ctx, existingUser.Email, "wrong-password")
+	s.Error(err)
+	s.Nil(user)
+
+	// Test case 3: Suspended users cannot log in
+	existingUser.Status = domain.UserStatusSuspended
+	s.mockOutputPort.On("GetUserByEmail", s.ctx, existingUser.Email).Return(existingUser, nil).Once()
+	user, err = s.userUseCase.VerifyUser(s.ctx, existingUser.Email, "password123")
+	s.ErrorIs(err, usecases.ErrAccountInactive)
+	s.Nil(user)
+	existingUser.Status = domain.UserStatusActive
+
+	// Test case 4: Legacy plaintext password is rehashed on login
+	existingUser.Password = "password123"
+	s.mockOutputPort.On("GetUserByEmail", s.ctx, existingUser.Email).Return(existingUser, nil).Once()
+	s.mockOutputPort.On("UpdateUser", s.ctx, existingUser.ID, existingUser).Return(nil).Once()
+	user, err = s.userUseCase.VerifyUser(s.ctx, existingUser.Email, "password123")
+	s.NoError(err)
+	s.True(security.IsPasswordHash(user.Password))
+	s.mockOutputPort.AssertExpectations(s.T())
+}
+
+func (s *UserUseCaseTestSuite) TestChangePassword() {
+	hash, err := security.HashPassword("copper-meadow-17")
+	s.Require().NoError(err)
+	existingUser := &domain.User{
+		ID:       primitive.NewObjectID(),
+		Username: "testuser",
+		Email:    "test@example.com",
+		Password: hash,
+	}
+
+	// Test case 1: Wrong current password
+	s.mockOutputPort.On("GetUser", s.ctx, existingUser.ID).Return(existingUser, nil).Once()
+	err = s.userUseCase.ChangePassword(s.ctx, existingUser.ID.Hex(), "wrong-password", "velvet-canyon-58")
+	s.ErrorIs(err, usecases.ErrIncorrectPassword)
+
+	// Test case 2: Reusing the current password
+	s.mockOutputPort.On("GetUser", s.ctx, existingUser.ID).Return(existingUser, nil).Once()
+	err = s.userUseCase.ChangePassword(s.ctx, existingUser.ID.Hex(), "copper-meadow-17", "copper-meadow-17")
+	s.ErrorIs(err, security.ErrPasswordPolicy)
+
+	// Test case 3: Successful change keeps the old hash in the history
+	s.mockOutputPort.On("GetUser", s.ctx, existingUser.ID).Return(existingUser, nil).Once()
+	s.mockOutputPort.On("UpdateUser", s.ctx, existingUser.ID, existingUser).Return(nil).Once()
+	err = s.userUseCase.ChangePassword(s.ctx, existingUser.ID.Hex(), "copper-meadow-17", "velvet-canyon-58")
+	s.NoError(err)
+	s.True(security.CheckPassword(existingUser.Password, "velvet-canyon-58"))
+	s.Equal([]string{hash}, existingUser.PasswordHistory)
+	s.mockOutputPort.AssertExpectations(s.T())
+
+	// Test case 4: Every attempt is audited without the passwords
+	s.Equal([]string{"user.password_change:failure", "user.password_change:failure", "user.password_change:success"}, s.audit.actions())
+	s.Equal(usecases.ErrIncorrectPassword.Error(), s.audit.entries[0].Reason)
+	s.NotContains(s.audit.entries[2].Changes[0].After, "velvet")
+}
+
+func (s *UserUseCaseTestSuite) TestChangeStatus() {
+	adminID := primitive.NewObjectID().Hex()
+	existingUser := &domain.User{
+		ID:       primitive.NewObjectID(),
+		Username: "testuser",
+		Email:    "test@example.com",
+	}
+	s.mockOutputPort.On("GetUser", s.ctx, existingUser.ID).Return(existingUser, nil)
+	s.mockOutputPort.On("UpdateUser", s.ctx, existingUser.ID, existingUser).Return(nil)
+
+	// Test case 1: Users without a status are active and can be suspended
+	s.Equal(domain.UserStatusActive, existingUser.CurrentStatus())
+	user, err := s.userUseCase.ChangeStatus(s.ctx, existingUser.ID.Hex(), domain.UserStatusSuspended, "abuse report", adminID)
+	s.NoError(err)
+	s.Equal(domain.UserStatusSuspended, user.Status)
+	s.False(user.CanSignIn())
+	s.ErrorIs(s.userUseCase.CheckAccountStatus(user), usecases.ErrAccountInactive)
+	s.Require().Len(user.StatusHistory, 1)
+	s.Equal(domain.UserStatusActive, user.StatusHistory[0].From)
+	s.Equal("abuse report", user.StatusHistory[0].Reason)
+	s.Equal(adminID, user.StatusHistory[0].ActorID)
+	s.False(user.StatusHistory[0].ChangedAt.IsZero())
+
+	// Test case 2: Transitions outside the state machine are rejected
+	_, err = s.userUseCase.ChangeStatus(s.ctx, existingUser.ID.Hex(), domain.UserStatusLocked, "", adminID)
+	s.ErrorIs(err, usecases.ErrInvalidStatusTransition)
+	s.Equal(domain.UserStatusSuspended, existingUser.Status)
+
+	// Test case 3: Reactivation
+	user, err = s.userUseCase.ChangeStatus(s.ctx, existingUser.ID.Hex(), domain.UserStatusActive, "appeal accepted", adminID)
+	s.NoError(err)
+	s.True(user.CanSignIn())
+	s.Len(user.StatusHistory, 2)
+
+	// Test case 4: Status changes are audited with the actor and the diff
+	s.Require().Len(s.audit.entries, 2)
+	s.Equal("user.status_change", s.audit.entries[0].Action)
+	s.Equal(adminID, s.audit.entries[0].ActorID)
+	s.Equal(existingUser.ID.Hex(), s.audit.entries[0].TargetID)
+	s.Equal([]domain.AuditChange{{Field: "status", Before: "active", After: "suspended"}}, s.audit.entries[0].Changes)
 }
 
 func (s *UserUseCaseTestSuite) TestReadUser() {
@@ -143,6 +281,7 @@ func (s *UserUseCaseTestSuite) TestUpdateUser() {
 
 	// Test case 1: Successful user update
 	s.mockOutputPort.On("GetUser", s.ctx, existingID).Return(existingUser, nil).Once()
+	s.mockOutputPort.On("GetUserByEmail", s.ctx, updateUserInput.Email).Return(nil, errors.New("user not found")).Once()
 	s.mockOutputPort.On("UpdateUser", s.ctx, existingID, mock.AnythingOfType("*domain.User")).Return(nil).Once()
 	user, err := s.userUseCase.UpdateUser(s.ctx, existingID.Hex(), updateUserInput)
 	s.NoError(err)
@@ -179,6 +318,17 @@ func (s *UserUseCaseTestSuite) TestUpdateUser() {
 	s.Nil(user)
 	s.Contains(err.Error(), "invalid id")
 	s.mockOutputPort.AssertExpectations(s.T())
+
+	// Test case 5: Address already used by another user
+	s.SetupTest() // Reset mock for new test case
+	existingUser = &domain.User{ID: existingID, Username: "olduser", Email: "old@example.com"}
+	s.mockOutputPort.On("GetUser", s.ctx, existingID).Return(existingUser, nil).Once()
+	s.mockOutputPort.On("GetUserByEmail", s.ctx, updateUserInput.Email).Return(&domain.User{ID: primitive.NewObjectID(), Email: updateUserInput.Email}, nil).Once()
+	user, err = s.userUseCase.UpdateUser(s.ctx, existingID.Hex(), updateUserInput)
+	s.ErrorIs(err, usecases.ErrEmailTaken)
+	s.Nil(user)
+	s.Equal("old@example.com", existingUser.Email)
+	s.mockOutputPort.AssertExpectations(s.T())
 }
 
 func (s *UserUseCaseTestSuite) TestDeleteUser() {
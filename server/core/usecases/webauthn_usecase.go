@@ -0,0 +1,374 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+	"veritas/core/domain"
+	"veritas/internal/ports/output"
+	"veritas/internal/security"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const webAuthnTimeout = 5 * time.Minute
+
+var (
+	ErrWebAuthnVerification = errors.New("webauthn verification failed")
+	ErrCredentialExists     = errors.New("credential is already registered")
+	ErrCredentialNotFound   = errors.New("credential not found")
+)
+
+type WebAuthnUsecase struct {
+	credentials output.WebAuthnCredentialOutputPort
+	challenges  output.WebAuthnChallengeOutputPort
+	users       output.UserOutputPort
+	audit       output.AuditLogger
+	config      security.WebAuthnConfig
+}
+
+func NewWebAuthnUsecase(credentials output.WebAuthnCredentialOutputPort, challenges output.WebAuthnChallengeOutputPort, users output.UserOutputPort, audit output.AuditLogger, config security.WebAuthnConfig) *WebAuthnUsecase {
+	return &WebAuthnUsecase{credentials: credentials, challenges: challenges, users: users, audit: audit, config: config}
+}
+
+type FinishRegistrationInput struct {
+	Name              string
+	ClientDataJSON    []byte
+	AttestationObject []byte
+	Transports        []string
+}
+
+type FinishLoginInput struct {
+	CredentialID      []byte
+	ClientDataJSON    []byte
+	AuthenticatorData []byte
+	Signature         []byte
+	UserHandle        []byte
+}
+
+// BeginRegistration issues creation options for adding a credential to the
+// user's account.
+func (uc *WebAuthnUsecase) BeginRegistration(ctx context.Context, userID string) (*security.CredentialCreationOptions, error) {
+	objectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id: %w", err)
+	}
+
+	user, err := uc.users.GetUser(ctx, objectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	existing, err := uc.credentials.GetCredentialsByUser(ctx, objectID)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, err := uc.createChallenge(ctx, domain.WebAuthnCeremonyRegistration, objectID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	params := make([]security.CredentialParameter, 0, len(security.WebAuthnAlgorithms))
+	for _, alg := range security.WebAuthnAlgorithms {
+		params = append(params, security.CredentialParameter{Type: "public-key", Alg: alg})
+	}
+
+	return &security.CredentialCreationOptions{
+		RP: security.RelyingPartyEntity{ID: uc.config.RPID, Name: uc.config.RPName},
+		User: security.UserEntity{
+			ID:          objectID[:],
+			Name:        user.Email,
+			DisplayName: user.Username,
+		},
+		Challenge:          challenge,
+		PubKeyCredParams:   params,
+		Timeout:            webAuthnTimeout.Milliseconds(),
+		ExcludeCredentials: descriptors(existing),
+		AuthenticatorSelection: security.AuthenticatorSelection{
+			ResidentKey:      "preferred",
+			UserVerification: "preferred",
+		},
+		Attestation: "none",
+	}, nil
+}
+
+// FinishRegistration verifies the authenticator's response and stores the
+// new credential.
+func (uc *WebAuthnUsecase) FinishRegistration(ctx context.Context, userID string, input FinishRegistrationInput) (*domain.WebAuthnCredential, error) {
+	objectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id: %w", err)
+	}
+
+	challenge, err := uc.consumeChallenge(ctx, input.ClientDataJSON, domain.WebAuthnCeremonyRegistration)
+	if err != nil {
+		return nil, err
+	}
+	if challenge.UserID != objectID {
+		return nil, fmt.Errorf("%w: challenge was issued to another user", ErrWebAuthnVerification)
+	}
+
+	attested, err := uc.config.VerifyRegistration(input.ClientDataJSON, input.AttestationObject, challenge.Challenge, challenge.RequireUV)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWebAuthnVerification, err)
+	}
+
+	if _, err := uc.credentials.GetCredentialByCredentialID(ctx, attested.ID); err == nil {
+		return nil, ErrCredentialExists
+	}
+
+	name := input.Name
+	if name == "" {
+		name = "Passkey"
+	}
+	credential := &domain.WebAuthnCredential{
+		UserID:         objectID,
+		Name:           name,
+		CredentialID:   attested.ID,
+		PublicKey:      attested.PublicKey,
+		AAGUID:         attested.AAGUID,
+		SignCount:      attested.SignCount,
+		Transports:     input.Transports,
+		BackupEligible: attested.BackupEligible,
+		BackupState:    attested.BackupState,
+	}
+
+	id, err := uc.credentials.CreateCredential(ctx, credential)
+	if err != nil {
+		return nil, err
+	}
+	credential.ID = id
+
+	entry := userAuditEntry(domain.AuditCategoryAccount, "webauthn.register", objectID)
+	entry.Reason = "credential " + credential.ID.Hex()
+	recordAudit(ctx, uc.audit, entry)
+
+	return credential, nil
+}
+
+// BeginLogin issues request options. With a user ID the ceremony is a second
+// factor for that user; without one it is a passwordless login with a
+// discoverable credential, which must verify the user.
+func (uc *WebAuthnUsecase) BeginLogin(ctx context.Context, userID string) (*security.CredentialRequestOptions, error) {
+	options := &security.CredentialRequestOptions{
+		Timeout:          webAuthnTimeout.Milliseconds(),
+		RPID:             uc.config.RPID,
+		UserVerification: "required",
+	}
+
+	var objectID primitive.ObjectID
+	if userID != "" {
+		var err error
+		objectID, err = primitive.ObjectIDFromHex(userID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid id: %w", err)
+		}
+
+		existing, err := uc.credentials.GetCredentialsByUser(ctx, objectID)
+		if err != nil {
+			return nil, err
+		}
+		if len(existing) == 0 {
+			return nil, ErrCredentialNotFound
+		}
+		options.AllowCredentials = descriptors(existing)
+		options.UserVerification = "preferred"
+	}
+
+	challenge, err := uc.createChallenge(ctx, domain.WebAuthnCeremonyAuthentication, objectID, userID == "")
+	if err != nil {
+		return nil, err
+	}
+	options.Challenge = challenge
+
+	return options, nil
+}
+
+// FinishLogin verifies an assertion and returns the user it authenticates.
+// When expectedUserID is set, the assertion must come from that user.
+func (uc *WebAuthnUsecase) FinishLogin(ctx context.Context, expectedUserID string, input FinishLoginInput) (*domain.User, error) {
+	entry := &domain.AuditEntry{Category: domain.AuditCategoryAuthentication, Action: "auth.webauthn", TargetType: "user", TargetID: expectedUserID}
+
+	credential, err := uc.credentials.GetCredentialByCredentialID(ctx, input.CredentialID)
+	if err == nil {
+		entry.TargetID = credential.UserID.Hex()
+	}
+
+	if err := uc.verifyAssertion(ctx, expectedUserID, input, credential); err != nil {
+		recordAudit(ctx, uc.audit, failed(entry, err.Error()))
+		return nil, err
+	}
+	recordAudit(ctx, uc.audit, entry)
+
+	return uc.users.GetUser(ctx, credential.UserID)
+}
+
+// verifyAssertion checks an assertion made with credential, which is nil when
+// the credential ID is unknown, and records its new signature counter.
+func (uc *WebAuthnUsecase) verifyAssertion(ctx context.Context, expectedUserID string, input FinishLoginInput, credential *domain.WebAuthnCredential) error {
+	challenge, err := uc.consumeChallenge(ctx, input.ClientDataJSON, domain.WebAuthnCeremonyAuthentication)
+	if err != nil {
+		return err
+	}
+
+	if credential == nil {
+		return fmt.Errorf("%w: unknown credential", ErrWebAuthnVerification)
+	}
+
+	if !challenge.UserID.IsZero() && challenge.UserID != credential.UserID {
+		return fmt.Errorf("%w: credential belongs to another user", ErrWebAuthnVerification)
+	}
+	if expectedUserID != "" && (challenge.UserID.IsZero() || challenge.UserID.Hex() != expectedUserID) {
+		return fmt.Errorf("%w: challenge was issued to another user", ErrWebAuthnVerification)
+	}
+	if challenge.UserID.IsZero() && len(input.UserHandle) == 0 {
+		return fmt.Errorf("%w: user handle is required", ErrWebAuthnVerification)
+	}
+	if len(input.UserHandle) != 0 && !bytes.Equal(input.UserHandle, credential.UserID[:]) {
+		return fmt.Errorf("%w: user handle mismatch", ErrWebAuthnVerification)
+	}
+
+	result, err := uc.config.VerifyAssertion(input.ClientDataJSON, input.AuthenticatorData, input.Signature, credential.PublicKey, challenge.Challenge, credential.SignCount, challenge.RequireUV)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrWebAuthnVerification, err)
+	}
+
+	credential.SignCount = result.SignCount
+	credential.BackupState = result.BackupState
+	credential.LastUsedAt = time.Now()
+	return uc.credentials.UpdateCredential(ctx, credential.ID, credential)
+}
+
+// HasCredentials reports whether the user has registered any credential, in
+// which case it is offered as a second factor at login.
+func (uc *WebAuthnUsecase) HasCredentials(ctx context.Context, userID primitive.ObjectID) (bool, error) {
+	credentials, err := uc.credentials.GetCredentialsByUser(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return len(credentials) > 0, nil
+}
+
+func (uc *WebAuthnUsecase) GetCredentials(ctx context.Context, userID string) ([]*domain.WebAuthnCredential, error) {
+	objectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id: %w", err)
+	}
+	return uc.credentials.GetCredentialsByUser(ctx, objectID)
+}
+
+func (uc *WebAuthnUsecase) RenameCredential(ctx context.Context, userID string, id string, name string) (*domain.WebAuthnCredential, error) {
+	credential, err := uc.ownedCredential(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := userAuditEntry(domain.AuditCategoryAccount, "webauthn.rename", credential.UserID)
+	entry.Reason = "credential " + credential.ID.Hex()
+	entry.Changes = appendChange(nil, "name", credential.Name, name)
+	credential.Name = name
+	if err := uc.credentials.UpdateCredential(ctx, credential.ID, credential); err != nil {
+		return nil, err
+	}
+	recordAudit(ctx, uc.audit, entry)
+
+	return credential, nil
+}
+
+func (uc *WebAuthnUsecase) DeleteCredential(ctx context.Context, userID string, id string) error {
+	credential, err := uc.ownedCredential(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if err := uc.credentials.DeleteCredential(ctx, credential.ID); err != nil {
+		return err
+	}
+
+	entry := userAuditEntry(domain.AuditCategoryAccount, "webauthn.delete", credential.UserID)
+	entry.Reason = "credential " + credential.ID.Hex()
+	recordAudit(ctx, uc.audit, entry)
+	return nil
+}
+
+// DeleteAllCredentials removes every credential of a user, for administrators
+// resetting their second factors.
+func (uc *WebAuthnUsecase) DeleteAllCredentials(ctx context.Context, userID string) error {
+	credentials, err := uc.GetCredentials(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, credential := range credentials {
+		if err := uc.credentials.DeleteCredential(ctx, credential.ID); err != nil {
+			return err
+		}
+	}
+
+	entry := &domain.AuditEntry{Category: domain.AuditCategoryAdmin, Action: "webauthn.delete_all", TargetType: "user", TargetID: userID}
+	entry.Reason = fmt.Sprintf("%d credentials removed", len(credentials))
+	recordAudit(ctx, uc.audit, entry)
+	return nil
+}
+
+func (uc *WebAuthnUsecase) ownedCredential(ctx context.Context, userID string, id string) (*domain.WebAuthnCredential, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id: %w", err)
+	}
+
+	credential, err := uc.credentials.GetCredential(ctx, objectID)
+	if err != nil || credential.UserID.Hex() != userID {
+		return nil, ErrCredentialNotFound
+	}
+
+	return credential, nil
+}
+
+func (uc *WebAuthnUsecase) createChallenge(ctx context.Context, ceremony string, userID primitive.ObjectID, requireUV bool) (security.Base64URL, error) {
+	raw, err := security.RandomBytes(32)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = uc.challenges.CreateChallenge(ctx, &domain.WebAuthnChallenge{
+		Challenge: base64.RawURLEncoding.EncodeToString(raw),
+		Ceremony:  ceremony,
+		UserID:    userID,
+		RequireUV: requireUV,
+		ExpiresAt: time.Now().Add(webAuthnTimeout),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return raw, nil
+}
+
+func (uc *WebAuthnUsecase) consumeChallenge(ctx context.Context, clientDataJSON []byte, ceremony string) (*domain.WebAuthnChallenge, error) {
+	value, err := security.ClientDataChallenge(clientDataJSON)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrWebAuthnVerification, err)
+	}
+
+	challenge, err := uc.challenges.ConsumeChallenge(ctx, value, ceremony)
+	if err != nil || time.Now().After(challenge.ExpiresAt) {
+		return nil, fmt.Errorf("%w: unknown or expired challenge", ErrWebAuthnVerification)
+	}
+
+	return challenge, nil
+}
+
+func descriptors(credentials []*domain.WebAuthnCredential) []security.CredentialDescriptor {
+	result := make([]security.CredentialDescriptor, 0, len(credentials))
+	for _, credential := range credentials {
+		result = append(result, security.CredentialDescriptor{
+			Type:       "public-key",
+			ID:         credential.CredentialID,
+			Transports: credential.Transports,
+		})
+	}
+	return result
+}
@@ -0,0 +1,106 @@
+package breach
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"veritas/internal/ports/output"
+)
+
+// maxLineLength bounds a "HASH:COUNT" line, which is read whole.
+const maxLineLength = 128
+
+type fileStore struct {
+	file *os.File
+	size int64
+}
+
+// NewFileStore opens a breached password corpus: a text file with one
+// uppercase SHA-1 hash per line, optionally followed by ":count", sorted by
+// hash. This is the format of the "ordered by hash" Pwned Passwords
+// download. Lookups binary search the file, so it is not loaded into
+// memory.
+func NewFileStore(path string) (output.BreachedPasswordOutputPort, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open breached password file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to stat breached password file: %w", err)
+	}
+	return &fileStore{file: file, size: info.Size()}, nil
+}
+
+func (s *fileStore) FindHashSuffixes(ctx context.Context, prefix string) ([]string, error) {
+	prefix = strings.ToUpper(prefix)
+
+	// Find the first line whose hash is not below the prefix.
+	lo, hi := int64(0), s.size
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		line, _, err := s.lineAt(mid)
+		if err != nil {
+			return nil, err
+		}
+		if line == "" || hashOf(line) >= prefix {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+
+	_, start, err := s.lineAt(lo)
+	if err != nil {
+		return nil, err
+	}
+	var suffixes []string
+	scanner := bufio.NewScanner(io.NewSectionReader(s.file, start, s.size-start))
+	for scanner.Scan() {
+		hash := hashOf(strings.TrimSpace(scanner.Text()))
+		if !strings.HasPrefix(hash, prefix) {
+			break
+		}
+		suffixes = append(suffixes, hash[len(prefix):])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read breached password file: %w", err)
+	}
+	return suffixes, nil
+}
+
+// lineAt returns the first line starting at or after offset, and where it
+// starts. The line is empty past the end of the file.
+func (s *fileStore) lineAt(offset int64) (string, int64, error) {
+	start := offset
+	if offset > 0 {
+		start = offset - 1
+	}
+	reader := bufio.NewReaderSize(io.NewSectionReader(s.file, start, s.size-start), maxLineLength)
+	if offset > 0 {
+		// Skip the rest of the line the offset falls into. Reading from one
+		// byte earlier keeps a line starting exactly at offset.
+		skipped, err := reader.ReadString('\n')
+		if err == io.EOF {
+			return "", s.size, nil
+		}
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to read breached password file: %w", err)
+		}
+		start += int64(len(skipped))
+	}
+	line, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", 0, fmt.Errorf("failed to read breached password file: %w", err)
+	}
+	return strings.TrimSpace(line), start, nil
+}
+
+func hashOf(line string) string {
+	hash, _, _ := strings.Cut(line, ":")
+	return strings.ToUpper(hash)
+}
@@ -0,0 +1,42 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"veritas/core/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const auditCheckpointCollectionName = "audit_export_checkpoints"
+
+type AuditCheckpointRepository struct {
+	db *mongo.Database
+}
+
+func NewAuditCheckpointRepository(db *mongo.Database) *AuditCheckpointRepository {
+	return &AuditCheckpointRepository{db: db}
+}
+
+func (r *AuditCheckpointRepository) GetCheckpoint(ctx context.Context, exporter string) (*domain.AuditExportCheckpoint, error) {
+	var checkpoint domain.AuditExportCheckpoint
+	err := r.db.Collection(auditCheckpointCollectionName).FindOne(ctx, bson.M{"_id": exporter}).Decode(&checkpoint)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit export checkpoint: %w", err)
+	}
+	return &checkpoint, nil
+}
+
+func (r *AuditCheckpointRepository) SaveCheckpoint(ctx context.Context, checkpoint *domain.AuditExportCheckpoint) error {
+	filter := bson.M{"_id": checkpoint.Exporter}
+	_, err := r.db.Collection(auditCheckpointCollectionName).ReplaceOne(ctx, filter, checkpoint, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to save audit export checkpoint: %w", err)
+	}
+	return nil
+}
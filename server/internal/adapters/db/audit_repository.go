@@ -0,0 +1,160 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"veritas/core/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	auditCollectionName = "audit_log"
+	// maxAuditAppendAttempts bounds the retries when concurrent writers
+	// claim the same sequence number.
+	maxAuditAppendAttempts = 10
+)
+
+// auditCollection is the part of *mongo.Collection that AuditRepository
+// uses.
+type auditCollection interface {
+	collection
+	CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error)
+}
+
+// AuditRepository is an append-only audit store. Entries are never updated
+// or deleted through it. The hash chain runs through the entries of every
+// organization, but FindEntries only returns those of the organization the
+// context is scoped to.
+type AuditRepository struct {
+	db      *mongo.Database
+	entries auditCollection
+}
+
+func NewAuditRepository(db *mongo.Database) *AuditRepository {
+	return &AuditRepository{db: db, entries: db.Collection(auditCollectionName)}
+}
+
+// EnsureIndexes creates the unique sequence index the hash chain relies on,
+// and the indexes used for filtering.
+func (r *AuditRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.db.Collection(auditCollectionName).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "sequence", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: tenantField, Value: 1}, {Key: "sequence", Value: -1}}},
+		{Keys: bson.D{{Key: "actorId", Value: 1}, {Key: "sequence", Value: -1}}},
+		{Keys: bson.D{{Key: "targetId", Value: 1}, {Key: "sequence", Value: -1}}},
+		{Keys: bson.D{{Key: "action", Value: 1}, {Key: "sequence", Value: -1}}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create audit indexes: %w", err)
+	}
+	return nil
+}
+
+// Record links the entry to the last one and inserts it. Writers racing for
+// the same sequence number are kept apart by the unique index; the loser
+// retries on top of the winner's entry.
+func (r *AuditRepository) Record(ctx context.Context, entry *domain.AuditEntry) error {
+	// MongoDB stores milliseconds, and the hash must match what is read back.
+	entry.Time = entry.Time.UTC().Truncate(time.Millisecond)
+
+	for attempt := 0; attempt < maxAuditAppendAttempts; attempt++ {
+		var last domain.AuditEntry
+		opts := options.FindOne().SetSort(bson.D{{Key: "sequence", Value: -1}})
+		err := r.entries.FindOne(ctx, bson.M{}, opts).Decode(&last)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return fmt.Errorf("failed to get last audit entry: %w", err)
+		}
+
+		entry.ID = primitive.NewObjectID()
+		entry.Sequence = last.Sequence + 1
+		entry.PrevHash = last.Hash
+		entry.Hash = entry.ComputeHash()
+
+		_, err = r.entries.InsertOne(ctx, entry)
+		if mongo.IsDuplicateKeyError(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to insert audit entry: %w", err)
+		}
+		return nil
+	}
+	return fmt.Errorf("failed to insert audit entry: too many concurrent writers")
+}
+
+func (r *AuditRepository) FindEntries(ctx context.Context, filter domain.AuditFilter, offset, limit int64) ([]*domain.AuditEntry, int64, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	query := bson.M{tenantField: tenantID}
+	for field, value := range map[string]string{
+		"actorId":    filter.ActorID,
+		"action":     filter.Action,
+		"category":   filter.Category,
+		"outcome":    filter.Outcome,
+		"targetType": filter.TargetType,
+		"targetId":   filter.TargetID,
+	} {
+		if value != "" {
+			query[field] = value
+		}
+	}
+	timeRange := bson.M{}
+	if !filter.From.IsZero() {
+		timeRange["$gte"] = filter.From
+	}
+	if !filter.To.IsZero() {
+		timeRange["$lt"] = filter.To
+	}
+	if len(timeRange) > 0 {
+		query["time"] = timeRange
+	}
+
+	total, err := r.entries.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit entries: %w", err)
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "sequence", Value: -1}}).SetSkip(offset).SetLimit(limit)
+	cursor, err := r.entries.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get audit entries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	entries := []*domain.AuditEntry{}
+	if err = cursor.All(ctx, &entries); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode audit entries: %w", err)
+	}
+
+	return entries, total, nil
+}
+
+func (r *AuditRepository) ScanEntries(ctx context.Context, fromSequence int64, fn func(*domain.AuditEntry) error) error {
+	opts := options.Find().SetSort(bson.D{{Key: "sequence", Value: 1}})
+	cursor, err := r.entries.Find(ctx, bson.M{"sequence": bson.M{"$gte": fromSequence}}, opts)
+	if err != nil {
+		return fmt.Errorf("failed to get audit entries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var entry domain.AuditEntry
+		if err := cursor.Decode(&entry); err != nil {
+			return fmt.Errorf("failed to decode audit entry: %w", err)
+		}
+		if err := fn(&entry); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("failed to read audit entries: %w", err)
+	}
+	return nil
+}
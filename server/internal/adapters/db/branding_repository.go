@@ -0,0 +1,42 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"veritas/core/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const brandingCollectionName = "branding"
+
+type BrandingRepository struct {
+	db *mongo.Database
+}
+
+func NewBrandingRepository(db *mongo.Database) *BrandingRepository {
+	return &BrandingRepository{db: db}
+}
+
+func (r *BrandingRepository) GetBranding(ctx context.Context, id string) (*domain.Branding, error) {
+	var branding domain.Branding
+	err := r.db.Collection(brandingCollectionName).FindOne(ctx, bson.M{"_id": id}).Decode(&branding)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branding: %w", err)
+	}
+	return &branding, nil
+}
+
+func (r *BrandingRepository) SaveBranding(ctx context.Context, branding *domain.Branding) error {
+	filter := bson.M{"_id": branding.ID}
+	_, err := r.db.Collection(brandingCollectionName).ReplaceOne(ctx, filter, branding, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to save branding: %w", err)
+	}
+	return nil
+}
@@ -13,19 +13,21 @@ import (
 
 const claimCollectionName = "claims"
 
+// ClaimRepository stores claims. It only sees the claims of the organization
+// the context is scoped to.
 type ClaimRepository struct {
-	db *mongo.Database
+	claims tenantCollection
 }
 
 func NewClaimRepository(db *mongo.Database) *ClaimRepository {
-	return &ClaimRepository{db: db}
+	return &ClaimRepository{claims: newTenantCollection(db.Collection(claimCollectionName))}
 }
 
 func (r *ClaimRepository) CreateClaim(ctx context.Context, claim *domain.Claim) (primitive.ObjectID, error) {
 	claim.CreatedAt = time.Now()
 	claim.UpdatedAt = time.Now()
 
-	result, err := r.db.Collection(claimCollectionName).InsertOne(ctx, claim)
+	result, err := r.claims.InsertOne(ctx, claim)
 	if err != nil {
 		return primitive.ObjectID{}, fmt.Errorf("failed to insert claim: %w", err)
 	}
@@ -34,6 +36,7 @@ func (r *ClaimRepository) CreateClaim(ctx context.Context, claim *domain.Claim)
 	if !ok {
 		return primitive.ObjectID{}, fmt.Errorf("failed to convert inserted id to ObjectID")
 	}
+	claim.TenantID, _ = domain.TenantFromContext(ctx)
 
 	return objectID, nil
 }
@@ -42,7 +45,7 @@ func (r *ClaimRepository) GetClaim(ctx context.Context, id primitive.ObjectID) (
 	var claim domain.Claim
 	filter := bson.M{"_id": id}
 
-	err := r.db.Collection(claimCollectionName).FindOne(ctx, filter).Decode(&claim)
+	err := r.claims.FindOne(ctx, filter, &claim)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, fmt.Errorf("claim not found")
@@ -57,7 +60,7 @@ func (r *ClaimRepository) GetClaimByName(ctx context.Context, name string) (*dom
 	var claim domain.Claim
 	filter := bson.M{"name": name}
 
-	err := r.db.Collection(claimCollectionName).FindOne(ctx, filter).Decode(&claim)
+	err := r.claims.FindOne(ctx, filter, &claim)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, fmt.Errorf("claim not found")
@@ -72,9 +75,8 @@ func (r *ClaimRepository) UpdateClaim(ctx context.Context, id primitive.ObjectID
 	claim.UpdatedAt = time.Now()
 
 	filter := bson.M{"_id": id}
-	update := bson.M{"$set": claim}
 
-	_, err := r.db.Collection(claimCollectionName).UpdateOne(ctx, filter, update)
+	_, err := r.claims.UpdateOne(ctx, filter, claim)
 	if err != nil {
 		return fmt.Errorf("failed to update claim: %w", err)
 	}
@@ -85,7 +87,7 @@ func (r *ClaimRepository) UpdateClaim(ctx context.Context, id primitive.ObjectID
 func (r *ClaimRepository) DeleteClaim(ctx context.Context, id primitive.ObjectID) error {
 	filter := bson.M{"_id": id}
 
-	_, err := r.db.Collection(claimCollectionName).DeleteOne(ctx, filter)
+	_, err := r.claims.DeleteOne(ctx, filter)
 	if err != nil {
 		return fmt.Errorf("failed to delete claim: %w", err)
 	}
@@ -96,15 +98,9 @@ func (r *ClaimRepository) DeleteClaim(ctx context.Context, id primitive.ObjectID
 func (r *ClaimRepository) GetAllClaims(ctx context.Context) ([]*domain.Claim, error) {
 	var claims []*domain.Claim
 
-	cursor, err := r.db.Collection(claimCollectionName).Find(ctx, bson.M{})
-	if err != nil {
+	if err := r.claims.Find(ctx, bson.M{}, &claims); err != nil {
 		return nil, fmt.Errorf("failed to get all claims: %w", err)
 	}
-	defer cursor.Close(ctx)
-
-	if err = cursor.All(ctx, &claims); err != nil {
-		return nil, fmt.Errorf("failed to decode claims: %w", err)
-	}
 
 	return claims, nil
 }
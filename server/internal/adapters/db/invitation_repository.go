@@ -0,0 +1,107 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"veritas/core/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const invitationCollectionName = "invitations"
+
+// InvitationRepository stores invitations. It only sees the invitations of
+// the organization the context is scoped to.
+type InvitationRepository struct {
+	db          *mongo.Database
+	invitations tenantCollection
+}
+
+func NewInvitationRepository(db *mongo.Database) *InvitationRepository {
+	return &InvitationRepository{db: db, invitations: newTenantCollection(db.Collection(invitationCollectionName))}
+}
+
+// EnsureIndexes creates the index invitations are looked up by, and a TTL
+// index that removes expired ones.
+func (r *InvitationRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.db.Collection(invitationCollectionName).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: tenantField, Value: 1}, {Key: "email", Value: 1}}},
+		{Keys: bson.D{{Key: "expiresAt", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create invitation indexes: %w", err)
+	}
+	return nil
+}
+
+func (r *InvitationRepository) CreateInvitation(ctx context.Context, invitation *domain.Invitation) (primitive.ObjectID, error) {
+	invitation.CreatedAt = time.Now()
+	result, err := r.invitations.InsertOne(ctx, invitation)
+	if err != nil {
+		return primitive.ObjectID{}, fmt.Errorf("failed to insert invitation: %w", err)
+	}
+	objectID, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return primitive.ObjectID{}, fmt.Errorf("failed to convert inserted id to ObjectID")
+	}
+	invitation.TenantID, _ = domain.TenantFromContext(ctx)
+	return objectID, nil
+}
+
+func (r *InvitationRepository) GetInvitation(ctx context.Context, id primitive.ObjectID) (*domain.Invitation, error) {
+	var invitation domain.Invitation
+	filter := bson.M{"_id": id}
+	err := r.invitations.FindOne(ctx, filter, &invitation)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("invitation not found")
+		}
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+	return &invitation, nil
+}
+
+func (r *InvitationRepository) ConsumeInvitation(ctx context.Context, id primitive.ObjectID) (*domain.Invitation, error) {
+	var invitation domain.Invitation
+	filter := bson.M{"_id": id}
+	err := r.invitations.FindOneAndDelete(ctx, filter, &invitation)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("invitation not found")
+		}
+		return nil, fmt.Errorf("failed to consume invitation: %w", err)
+	}
+	return &invitation, nil
+}
+
+func (r *InvitationRepository) DeleteInvitation(ctx context.Context, id primitive.ObjectID) error {
+	filter := bson.M{"_id": id}
+	_, err := r.invitations.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete invitation: %w", err)
+	}
+	return nil
+}
+
+func (r *InvitationRepository) DeleteInvitationsByEmail(ctx context.Context, email string) error {
+	filter := bson.M{"email": email}
+	_, err := r.invitations.DeleteMany(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete invitations: %w", err)
+	}
+	return nil
+}
+
+func (r *InvitationRepository) GetPendingInvitations(ctx context.Context) ([]*domain.Invitation, error) {
+	var invitations []*domain.Invitation
+	filter := bson.M{"expiresAt": bson.M{"$gt": time.Now()}}
+	err := r.invitations.Find(ctx, filter, &invitations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitations: %w", err)
+	}
+	return invitations, nil
+}
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"veritas/core/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const loginAttemptCollectionName = "login_attempts"
+
+type LoginAttemptRepository struct {
+	db *mongo.Database
+}
+
+func NewLoginAttemptRepository(db *mongo.Database) *LoginAttemptRepository {
+	return &LoginAttemptRepository{db: db}
+}
+
+func (r *LoginAttemptRepository) GetAttempt(ctx context.Context, key string) (*domain.LoginAttempt, error) {
+	var attempt domain.LoginAttempt
+	filter := bson.M{"key": key}
+	err := r.db.Collection(loginAttemptCollectionName).FindOne(ctx, filter).Decode(&attempt)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("login attempt not found")
+		}
+		return nil, fmt.Errorf("failed to get login attempt: %w", err)
+	}
+	return &attempt, nil
+}
+
+func (r *LoginAttemptRepository) RecordFailure(ctx context.Context, key string, window time.Duration) (*domain.LoginAttempt, error) {
+	now := time.Now()
+
+	// An update pipeline keeps the reset of stale failures and the increment
+	// in one atomic operation, so parallel guesses are all counted.
+	var attempt domain.LoginAttempt
+	filter := bson.M{"key": key}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"failures": bson.M{"$cond": bson.A{
+				bson.M{"$lt": bson.A{"$lastFailureAt", now.Add(-window)}},
+				1,
+				bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$failures", 0}}, 1}},
+			}},
+			"lastFailureAt": now,
+		}}},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	err := r.db.Collection(loginAttemptCollectionName).FindOneAndUpdate(ctx, filter, update, opts).Decode(&attempt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record login failure: %w", err)
+	}
+	return &attempt, nil
+}
+
+func (r *LoginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	filter := bson.M{"key": key}
+	update := bson.M{"$set": bson.M{"lockedUntil": until, "failures": 0}}
+	_, err := r.db.Collection(loginAttemptCollectionName).UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to lock: %w", err)
+	}
+	return nil
+}
+
+func (r *LoginAttemptRepository) DeleteAttempt(ctx context.Context, key string) error {
+	filter := bson.M{"key": key}
+	_, err := r.db.Collection(loginAttemptCollectionName).DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete login attempt: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"veritas/core/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const oneTimeTokenCollectionName = "one_time_tokens"
+
+type OneTimeTokenRepository struct {
+	db *mongo.Database
+}
+
+func NewOneTimeTokenRepository(db *mongo.Database) *OneTimeTokenRepository {
+	return &OneTimeTokenRepository{db: db}
+}
+
+func (r *OneTimeTokenRepository) CreateToken(ctx context.Context, token *domain.OneTimeToken) (primitive.ObjectID, error) {
+	token.CreatedAt = time.Now()
+
+	result, err := r.db.Collection(oneTimeTokenCollectionName).InsertOne(ctx, token)
+	if err != nil {
+		return primitive.ObjectID{}, fmt.Errorf("failed to insert token: %w", err)
+	}
+
+	objectID, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return primitive.ObjectID{}, fmt.Errorf("failed to convert inserted id to ObjectID")
+	}
+
+	return objectID, nil
+}
+
+func (r *OneTimeTokenRepository) ConsumeToken(ctx context.Context, tokenHash string, purpose string) (*domain.OneTimeToken, error) {
+	var token domain.OneTimeToken
+	filter := bson.M{"tokenHash": tokenHash, "purpose": purpose}
+
+	err := r.db.Collection(oneTimeTokenCollectionName).FindOneAndDelete(ctx, filter).Decode(&token)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("token not found")
+		}
+		return nil, fmt.Errorf("failed to consume token: %w", err)
+	}
+
+	return &token, nil
+}
+
+func (r *OneTimeTokenRepository) GetTokenByHash(ctx context.Context, tokenHash string, purpose string) (*domain.OneTimeToken, error) {
+	var token domain.OneTimeToken
+	filter := bson.M{"tokenHash": tokenHash, "purpose": purpose}
+
+	err := r.db.Collection(oneTimeTokenCollectionName).FindOne(ctx, filter).Decode(&token)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("token not found")
+		}
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+
+	return &token, nil
+}
+
+func (r *OneTimeTokenRepository) GetTokenByUser(ctx context.Context, userID primitive.ObjectID, purpose string) (*domain.OneTimeToken, error) {
+	var token domain.OneTimeToken
+	filter := bson.M{"userId": userID, "purpose": purpose}
+
+	err := r.db.Collection(oneTimeTokenCollectionName).FindOne(ctx, filter).Decode(&token)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("token not found")
+		}
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+
+	return &token, nil
+}
+
+func (r *OneTimeTokenRepository) GetTokenByBinding(ctx context.Context, bindingHash string, purpose string) (*domain.OneTimeToken, error) {
+	var token domain.OneTimeToken
+	filter := bson.M{"bindingHash": bindingHash, "purpose": purpose}
+
+	err := r.db.Collection(oneTimeTokenCollectionName).FindOne(ctx, filter).Decode(&token)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("token not found")
+		}
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+
+	return &token, nil
+}
+
+func (r *OneTimeTokenRepository) IncrementAttempts(ctx context.Context, id primitive.ObjectID) (int, error) {
+	var token domain.OneTimeToken
+	filter := bson.M{"_id": id}
+	update := bson.M{"$inc": bson.M{"attempts": 1}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	err := r.db.Collection(oneTimeTokenCollectionName).FindOneAndUpdate(ctx, filter, update, opts).Decode(&token)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return 0, fmt.Errorf("token not found")
+		}
+		return 0, fmt.Errorf("failed to update token: %w", err)
+	}
+
+	return token.Attempts, nil
+}
+
+func (r *OneTimeTokenRepository) DeleteTokensByUser(ctx context.Context, userID primitive.ObjectID, purpose string) error {
+	filter := bson.M{"userId": userID, "purpose": purpose}
+	_, err := r.db.Collection(oneTimeTokenCollectionName).DeleteMany(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete tokens: %w", err)
+	}
+	return nil
+}
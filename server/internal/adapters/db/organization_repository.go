@@ -0,0 +1,106 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"veritas/core/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const organizationCollectionName = "organizations"
+
+type OrganizationRepository struct {
+	db *mongo.Database
+}
+
+func NewOrganizationRepository(db *mongo.Database) *OrganizationRepository {
+	return &OrganizationRepository{db: db}
+}
+
+// EnsureIndexes creates the unique indexes organizations are resolved by.
+func (r *OrganizationRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.db.Collection(organizationCollectionName).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "slug", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "domains", Value: 1}}, Options: options.Index().SetUnique(true).SetSparse(true)},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create organization indexes: %w", err)
+	}
+	return nil
+}
+
+func (r *OrganizationRepository) CreateOrganization(ctx context.Context, organization *domain.Organization) (primitive.ObjectID, error) {
+	organization.CreatedAt = time.Now()
+	organization.UpdatedAt = time.Now()
+
+	result, err := r.db.Collection(organizationCollectionName).InsertOne(ctx, organization)
+	if err != nil {
+		return primitive.ObjectID{}, fmt.Errorf("failed to insert organization: %w", err)
+	}
+
+	objectID, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return primitive.ObjectID{}, fmt.Errorf("failed to convert inserted id to ObjectID")
+	}
+
+	return objectID, nil
+}
+
+func (r *OrganizationRepository) GetOrganization(ctx context.Context, id primitive.ObjectID) (*domain.Organization, error) {
+	return r.findOne(ctx, bson.M{"_id": id})
+}
+
+func (r *OrganizationRepository) GetOrganizationBySlug(ctx context.Context, slug string) (*domain.Organization, error) {
+	return r.findOne(ctx, bson.M{"slug": slug})
+}
+
+func (r *OrganizationRepository) GetOrganizationByDomain(ctx context.Context, host string) (*domain.Organization, error) {
+	return r.findOne(ctx, bson.M{"domains": host})
+}
+
+func (r *OrganizationRepository) UpdateOrganization(ctx context.Context, id primitive.ObjectID, organization *domain.Organization) error {
+	organization.UpdatedAt = time.Now()
+
+	filter := bson.M{"_id": id}
+	update := bson.M{"$set": organization}
+
+	_, err := r.db.Collection(organizationCollectionName).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to update organization: %w", err)
+	}
+
+	return nil
+}
+
+func (r *OrganizationRepository) GetAllOrganizations(ctx context.Context) ([]*domain.Organization, error) {
+	var organizations []*domain.Organization
+
+	cursor, err := r.db.Collection(organizationCollectionName).Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "slug", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all organizations: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	if err = cursor.All(ctx, &organizations); err != nil {
+		return nil, fmt.Errorf("failed to decode organizations: %w", err)
+	}
+
+	return organizations, nil
+}
+
+func (r *OrganizationRepository) findOne(ctx context.Context, filter bson.M) (*domain.Organization, error) {
+	var organization domain.Organization
+	err := r.db.Collection(organizationCollectionName).FindOne(ctx, filter).Decode(&organization)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	return &organization, nil
+}
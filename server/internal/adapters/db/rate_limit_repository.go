@@ -0,0 +1,62 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"veritas/core/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const rateLimitCollectionName = "rate_limits"
+
+type RateLimitRepository struct {
+	db *mongo.Database
+}
+
+func NewRateLimitRepository(db *mongo.Database) *RateLimitRepository {
+	return &RateLimitRepository{db: db}
+}
+
+// EnsureIndexes creates the indexes counters are looked up by, and a TTL
+// index removing counters once they can no longer affect a limit.
+func (r *RateLimitRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.db.Collection(rateLimitCollectionName).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "key", Value: 1}, {Key: "resetAt", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "expiresAt", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create rate limit indexes: %w", err)
+	}
+	return nil
+}
+
+func (r *RateLimitRepository) Hit(ctx context.Context, key string, window time.Duration) (*domain.RateLimitCounter, error) {
+	resetAt := time.Now().Truncate(window).Add(window)
+
+	var counter domain.RateLimitCounter
+	filter := bson.M{"key": key, "resetAt": resetAt}
+	update := bson.M{
+		"$inc":         bson.M{"count": 1},
+		"$setOnInsert": bson.M{"expiresAt": resetAt.Add(window)},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	err := r.db.Collection(rateLimitCollectionName).FindOneAndUpdate(ctx, filter, update, opts).Decode(&counter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update rate limit: %w", err)
+	}
+
+	var previous domain.RateLimitCounter
+	filter = bson.M{"key": key, "resetAt": resetAt.Add(-window)}
+	err = r.db.Collection(rateLimitCollectionName).FindOne(ctx, filter).Decode(&previous)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("failed to get rate limit: %w", err)
+	}
+	counter.PreviousCount = previous.Count
+
+	return &counter, nil
+}
@@ -13,19 +13,21 @@ import (
 
 const roleCollectionName = "roles"
 
+// RoleRepository stores roles. It only sees the roles of the organization
+// the context is scoped to.
 type RoleRepository struct {
-	db *mongo.Database
+	roles tenantCollection
 }
 
 func NewRoleRepository(db *mongo.Database) *RoleRepository {
-	return &RoleRepository{db: db}
+	return &RoleRepository{roles: newTenantCollection(db.Collection(roleCollectionName))}
 }
 
 func (r *RoleRepository) CreateRole(ctx context.Context, role *domain.Role) (primitive.ObjectID, error) {
 	role.CreatedAt = time.Now()
 	role.UpdatedAt = time.Now()
 
-	result, err := r.db.Collection(roleCollectionName).InsertOne(ctx, role)
+	result, err := r.roles.InsertOne(ctx, role)
 	if err != nil {
 		return primitive.ObjectID{}, fmt.Errorf("failed to insert role: %w", err)
 	}
@@ -34,6 +36,7 @@ func (r *RoleRepository) CreateRole(ctx context.Context, role *domain.Role) (pri
 	if !ok {
 		return primitive.ObjectID{}, fmt.Errorf("failed to convert inserted id to ObjectID")
 	}
+	role.TenantID, _ = domain.TenantFromContext(ctx)
 
 	return objectID, nil
 }
@@ -42,7 +45,7 @@ func (r *RoleRepository) GetRole(ctx context.Context, id primitive.ObjectID) (*d
 	var role domain.Role
 	filter := bson.M{"_id": id}
 
-	err := r.db.Collection(roleCollectionName).FindOne(ctx, filter).Decode(&role)
+	err := r.roles.FindOne(ctx, filter, &role)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, fmt.Errorf("role not found")
@@ -57,7 +60,7 @@ func (r *RoleRepository) GetRoleByName(ctx context.Context, name string) (*domai
 	var role domain.Role
 	filter := bson.M{"name": name}
 
-	err := r.db.Collection(roleCollectionName).FindOne(ctx, filter).Decode(&role)
+	err := r.roles.FindOne(ctx, filter, &role)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, fmt.Errorf("role not found")
@@ -72,9 +75,8 @@ func (r *RoleRepository) UpdateRole(ctx context.Context, id primitive.ObjectID,
 	role.UpdatedAt = time.Now()
 
 	filter := bson.M{"_id": id}
-	update := bson.M{"$set": role}
 
-	_, err := r.db.Collection(roleCollectionName).UpdateOne(ctx, filter, update)
+	_, err := r.roles.UpdateOne(ctx, filter, role)
 	if err != nil {
 		return fmt.Errorf("failed to update role: %w", err)
 	}
@@ -85,7 +87,7 @@ func (r *RoleRepository) UpdateRole(ctx context.Context, id primitive.ObjectID,
 func (r *RoleRepository) DeleteRole(ctx context.Context, id primitive.ObjectID) error {
 	filter := bson.M{"_id": id}
 
-	_, err := r.db.Collection(roleCollectionName).DeleteOne(ctx, filter)
+	_, err := r.roles.DeleteOne(ctx, filter)
 	if err != nil {
 		return fmt.Errorf("failed to delete role: %w", err)
 	}
@@ -96,15 +98,9 @@ func (r *RoleRepository) DeleteRole(ctx context.Context, id primitive.ObjectID)
 func (r *RoleRepository) GetAllRoles(ctx context.Context) ([]*domain.Role, error) {
 	var roles []*domain.Role
 
-	cursor, err := r.db.Collection(roleCollectionName).Find(ctx, bson.M{})
-	if err != nil {
+	if err := r.roles.Find(ctx, bson.M{}, &roles); err != nil {
 		return nil, fmt.Errorf("failed to get all roles: %w", err)
 	}
-	defer cursor.Close(ctx)
-
-	if err = cursor.All(ctx, &roles); err != nil {
-		return nil, fmt.Errorf("failed to decode roles: %w", err)
-	}
 
 	return roles, nil
 }
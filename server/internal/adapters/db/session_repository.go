@@ -0,0 +1,108 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"veritas/core/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const sessionCollectionName = "sessions"
+
+// SessionRepository stores sessions. It only sees the sessions of the
+// organization the context is scoped to.
+type SessionRepository struct {
+	db       *mongo.Database
+	sessions tenantCollection
+}
+
+func NewSessionRepository(db *mongo.Database) *SessionRepository {
+	return &SessionRepository{db: db, sessions: newTenantCollection(db.Collection(sessionCollectionName))}
+}
+
+// EnsureIndexes creates the index sessions are listed by, and a TTL index
+// removing sessions past their absolute timeout.
+func (r *SessionRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.db.Collection(sessionCollectionName).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: tenantField, Value: 1}, {Key: "userId", Value: 1}, {Key: "lastSeenAt", Value: -1}}},
+		{Keys: bson.D{{Key: "expiresAt", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create session indexes: %w", err)
+	}
+	return nil
+}
+
+func (r *SessionRepository) CreateSession(ctx context.Context, session *domain.Session) (primitive.ObjectID, error) {
+	result, err := r.sessions.InsertOne(ctx, session)
+	if err != nil {
+		return primitive.ObjectID{}, fmt.Errorf("failed to insert session: %w", err)
+	}
+
+	objectID, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return primitive.ObjectID{}, fmt.Errorf("failed to convert inserted id to ObjectID")
+	}
+
+	return objectID, nil
+}
+
+func (r *SessionRepository) GetSession(ctx context.Context, id primitive.ObjectID) (*domain.Session, error) {
+	var session domain.Session
+
+	err := r.sessions.FindOne(ctx, bson.M{"_id": id}, &session)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("session not found")
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return &session, nil
+}
+
+func (r *SessionRepository) GetSessionsByUser(ctx context.Context, userID primitive.ObjectID) ([]*domain.Session, error) {
+	var sessions []*domain.Session
+
+	opts := options.Find().SetSort(bson.D{{Key: "lastSeenAt", Value: -1}})
+	if err := r.sessions.Find(ctx, bson.M{"userId": userID}, &sessions, opts); err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+func (r *SessionRepository) TouchSession(ctx context.Context, id primitive.ObjectID, lastSeenAt time.Time, ip string) error {
+	filter := bson.M{"_id": id}
+	update := bson.M{"lastSeenAt": lastSeenAt, "ip": ip}
+
+	_, err := r.sessions.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) DeleteSession(ctx context.Context, id primitive.ObjectID) error {
+	_, err := r.sessions.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) DeleteSessionsByUser(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	result, err := r.sessions.DeleteMany(ctx, bson.M{"userId": userID})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete sessions: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
@@ -0,0 +1,178 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"veritas/core/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// tenantField holds the organization a tenant-owned record belongs to.
+const tenantField = "tenantId"
+
+// tenantCollectionNames are the collections of tenant-owned records.
+var tenantCollectionNames = []string{collectionName, roleCollectionName, claimCollectionName, sessionCollectionName}
+
+// ErrNoTenant is returned by repositories of tenant-owned records when the
+// context is not scoped to an organization.
+var ErrNoTenant = errors.New("no tenant in context")
+
+// collection is the part of *mongo.Collection that tenantCollection uses.
+type collection interface {
+	InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error)
+	FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult
+	Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error)
+	UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
+	DeleteOne(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error)
+	DeleteMany(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error)
+	FindOneAndDelete(ctx context.Context, filter interface{}, opts ...*options.FindOneAndDeleteOptions) *mongo.SingleResult
+}
+
+// tenantCollection scopes every operation on a collection of tenant-owned
+// records to the organization of the context. Filters are restricted to it
+// and written documents are stamped with it, so a query can neither read
+// nor move another organization's records. Contexts without an organization
+// are refused with ErrNoTenant.
+type tenantCollection struct {
+	coll collection
+}
+
+func newTenantCollection(coll collection) tenantCollection {
+	return tenantCollection{coll: coll}
+}
+
+func (c tenantCollection) InsertOne(ctx context.Context, document interface{}) (*mongo.InsertOneResult, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stamped, err := withTenant(document, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return c.coll.InsertOne(ctx, stamped)
+}
+
+// FindOne decodes the first record matching filter into result, returning
+// mongo.ErrNoDocuments when there is none.
+func (c tenantCollection) FindOne(ctx context.Context, filter bson.M, result interface{}) error {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	scoped := scope(filter, tenantID)
+	return c.coll.FindOne(ctx, scoped).Decode(result)
+}
+
+// Find decodes every record matching filter into results.
+func (c tenantCollection) Find(ctx context.Context, filter bson.M, results interface{}, opts ...*options.FindOptions) error {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	scoped := scope(filter, tenantID)
+	cursor, err := c.coll.Find(ctx, scoped, opts...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+	return cursor.All(ctx, results)
+}
+
+// UpdateOne sets the fields of document on the first record matching filter.
+func (c tenantCollection) UpdateOne(ctx context.Context, filter bson.M, document interface{}) (*mongo.UpdateResult, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	scoped := scope(filter, tenantID)
+	stamped, err := withTenant(document, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return c.coll.UpdateOne(ctx, scoped, bson.M{"$set": stamped})
+}
+
+func (c tenantCollection) DeleteOne(ctx context.Context, filter bson.M) (*mongo.DeleteResult, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	scoped := scope(filter, tenantID)
+	return c.coll.DeleteOne(ctx, scoped)
+}
+
+func (c tenantCollection) DeleteMany(ctx context.Context, filter bson.M) (*mongo.DeleteResult, error) {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	scoped := scope(filter, tenantID)
+	return c.coll.DeleteMany(ctx, scoped)
+}
+
+// FindOneAndDelete atomically removes the first record matching filter and
+// decodes it into result, returning mongo.ErrNoDocuments when there is none.
+func (c tenantCollection) FindOneAndDelete(ctx context.Context, filter bson.M, result interface{}) error {
+	tenantID, err := tenantFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	scoped := scope(filter, tenantID)
+	return c.coll.FindOneAndDelete(ctx, scoped).Decode(result)
+}
+
+func tenantFromContext(ctx context.Context) (primitive.ObjectID, error) {
+	tenantID, ok := domain.TenantFromContext(ctx)
+	if !ok {
+		return primitive.NilObjectID, ErrNoTenant
+	}
+	return tenantID, nil
+}
+
+// scope returns a copy of filter restricted to the organization. A tenant
+// condition in filter is replaced, not combined.
+func scope(filter bson.M, tenantID primitive.ObjectID) bson.M {
+	scoped := bson.M{}
+	for key, value := range filter {
+		scoped[key] = value
+	}
+	scoped[tenantField] = tenantID
+	return scoped
+}
+
+// withTenant converts document to a map owned by the organization.
+func withTenant(document interface{}, tenantID primitive.ObjectID) (bson.M, error) {
+	raw, err := bson.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode document: %w", err)
+	}
+	var stamped bson.M
+	if err := bson.Unmarshal(raw, &stamped); err != nil {
+		return nil, fmt.Errorf("failed to encode document: %w", err)
+	}
+	stamped[tenantField] = tenantID
+	return stamped, nil
+}
+
+// AssignTenant gives every tenant-owned record that does not belong to an
+// organization yet, such as those created before organizations existed, to
+// the organization with the given ID.
+func AssignTenant(ctx context.Context, db *mongo.Database, tenantID primitive.ObjectID) error {
+	filter := bson.M{"$or": bson.A{
+		bson.M{tenantField: bson.M{"$exists": false}},
+		bson.M{tenantField: primitive.NilObjectID},
+	}}
+	update := bson.M{"$set": bson.M{tenantField: tenantID}}
+	for _, name := range tenantCollectionNames {
+		if _, err := db.Collection(name).UpdateMany(ctx, filter, update); err != nil {
+			return fmt.Errorf("failed to assign %s to tenant: %w", name, err)
+		}
+	}
+	return nil
+}
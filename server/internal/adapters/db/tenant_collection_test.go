@@ -0,0 +1,381 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"veritas/core/domain"
+
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// fakeCollection keeps documents in memory and matches filters by equality
+// of their top-level fields. It records every filter it is queried with.
+type fakeCollection struct {
+	documents []bson.M
+	filters   []bson.M
+}
+
+func (f *fakeCollection) InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error) {
+	doc := document.(bson.M)
+	if _, ok := doc["_id"]; !ok {
+		doc["_id"] = primitive.NewObjectID()
+	}
+	f.documents = append(f.documents, doc)
+	return &mongo.InsertOneResult{InsertedID: doc["_id"]}, nil
+}
+
+func (f *fakeCollection) FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult {
+	if i := f.match(filter); i >= 0 {
+		return mongo.NewSingleResultFromDocument(f.documents[i], nil, nil)
+	}
+	return mongo.NewSingleResultFromDocument(bson.M{}, mongo.ErrNoDocuments, nil)
+}
+
+func (f *fakeCollection) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error) {
+	var matched []interface{}
+	f.filters = append(f.filters, filter.(bson.M))
+	for _, doc := range f.documents {
+		if matches(doc, filter.(bson.M)) {
+			matched = append(matched, doc)
+		}
+	}
+	return mongo.NewCursorFromDocuments(matched, nil, nil)
+}
+
+func (f *fakeCollection) UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	i := f.match(filter)
+	if i < 0 {
+		return &mongo.UpdateResult{}, nil
+	}
+	for key, value := range update.(bson.M)["$set"].(bson.M) {
+		f.documents[i][key] = value
+	}
+	return &mongo.UpdateResult{MatchedCount: 1, ModifiedCount: 1}, nil
+}
+
+func (f *fakeCollection) DeleteOne(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	i := f.match(filter)
+	if i < 0 {
+		return &mongo.DeleteResult{}, nil
+	}
+	f.documents = append(f.documents[:i], f.documents[i+1:]...)
+	return &mongo.DeleteResult{DeletedCount: 1}, nil
+}
+
+func (f *fakeCollection) DeleteMany(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	var kept []bson.M
+	f.filters = append(f.filters, filter.(bson.M))
+	for _, doc := range f.documents {
+		if !matches(doc, filter.(bson.M)) {
+			kept = append(kept, doc)
+		}
+	}
+	deleted := len(f.documents) - len(kept)
+	f.documents = kept
+	return &mongo.DeleteResult{DeletedCount: int64(deleted)}, nil
+}
+
+func (f *fakeCollection) FindOneAndDelete(ctx context.Context, filter interface{}, opts ...*options.FindOneAndDeleteOptions) *mongo.SingleResult {
+	i := f.match(filter)
+	if i < 0 {
+		return mongo.NewSingleResultFromDocument(bson.M{}, mongo.ErrNoDocuments, nil)
+	}
+	doc := f.documents[i]
+	f.documents = append(f.documents[:i], f.documents[i+1:]...)
+	return mongo.NewSingleResultFromDocument(doc, nil, nil)
+}
+
+func (f *fakeCollection) CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error) {
+	var count int64
+	f.filters = append(f.filters, filter.(bson.M))
+	for _, doc := range f.documents {
+		if matches(doc, filter.(bson.M)) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *fakeCollection) match(filter interface{}) int {
+	f.filters = append(f.filters, filter.(bson.M))
+	for i, doc := range f.documents {
+		if matches(doc, filter.(bson.M)) {
+			return i
+		}
+	}
+	return -1
+}
+
+func matches(doc, filter bson.M) bool {
+	for key, value := range filter {
+		if doc[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+type TenantIsolationTestSuite struct {
+	suite.Suite
+	users, roles, claims *fakeCollection
+	userRepository       *UserRepository
+	roleRepository       *RoleRepository
+	claimRepository      *ClaimRepository
+	tenantA, tenantB     primitive.ObjectID
+	ctxA, ctxB           context.Context
+}
+
+func (s *TenantIsolationTestSuite) SetupTest() {
+	s.users, s.roles, s.claims = &fakeCollection{}, &fakeCollection{}, &fakeCollection{}
+	s.userRepository = &UserRepository{users: newTenantCollection(s.users)}
+	s.roleRepository = &RoleRepository{roles: newTenantCollection(s.roles)}
+	s.claimRepository = &ClaimRepository{claims: newTenantCollection(s.claims)}
+	s.tenantA, s.tenantB = primitive.NewObjectID(), primitive.NewObjectID()
+	s.ctxA = domain.WithTenant(context.Background(), s.tenantA)
+	s.ctxB = domain.WithTenant(context.Background(), s.tenantB)
+}
+
+// createUsers creates a user with the same email address in both tenants.
+func (s *TenantIsolationTestSuite) createUsers() (primitive.ObjectID, primitive.ObjectID) {
+	idA, err := s.userRepository.CreateUser(s.ctxA, &domain.User{Username: "ada", Email: "ada@example.com"})
+	s.Require().NoError(err)
+	idB, err := s.userRepository.CreateUser(s.ctxB, &domain.User{Username: "ada-b", Email: "ada@example.com"})
+	s.Require().NoError(err)
+	return idA, idB
+}
+
+// assertScoped checks that every filter the collection received was
+// restricted to one of the tenants.
+func (s *TenantIsolationTestSuite) assertScoped(collection *fakeCollection) {
+	s.NotEmpty(collection.filters)
+	for _, filter := range collection.filters {
+		s.Contains([]interface{}{s.tenantA, s.tenantB}, filter[tenantField], "filter %v is not scoped", filter)
+	}
+}
+
+func (s *TenantIsolationTestSuite) TestRecordsAreStampedWithTheContextTenant() {
+	// Test case 1: Created records belong to the tenant of the context
+	user := &domain.User{Email: "ada@example.com", TenantID: s.tenantB}
+	id, err := s.userRepository.CreateUser(s.ctxA, user)
+	s.NoError(err)
+	s.Equal(s.tenantA, user.TenantID)
+	s.Equal(s.tenantA, s.users.documents[0][tenantField])
+
+	// Test case 2: Reads return the tenant of the record
+	stored, err := s.userRepository.GetUser(s.ctxA, id)
+	s.NoError(err)
+	s.Equal(s.tenantA, stored.TenantID)
+}
+
+func (s *TenantIsolationTestSuite) TestUserReadsDoNotCrossTenants() {
+	idA, idB := s.createUsers()
+
+	// Test case 1: Another tenant's user cannot be read by ID
+	_, err := s.userRepository.GetUser(s.ctxA, idB)
+	s.EqualError(err, "user not found")
+
+	// Test case 2: The same email address resolves to the context tenant's user
+	user, err := s.userRepository.GetUserByEmail(s.ctxA, "ada@example.com")
+	s.NoError(err)
+	s.Equal(idA, user.ID)
+	user, err = s.userRepository.GetUserByEmail(s.ctxB, "ada@example.com")
+	s.NoError(err)
+	s.Equal(idB, user.ID)
+
+	// Test case 3: Listing only returns the context tenant's users
+	users, err := s.userRepository.GetAllUsers(s.ctxA)
+	s.NoError(err)
+	s.Len(users, 1)
+	s.Equal(idA, users[0].ID)
+	s.assertScoped(s.users)
+}
+
+func (s *TenantIsolationTestSuite) TestUserWritesDoNotCrossTenants() {
+	idA, idB := s.createUsers()
+
+	// Test case 1: Another tenant's user cannot be updated
+	s.NoError(s.userRepository.UpdateUser(s.ctxA, idB, &domain.User{ID: idB, Username: "mallory"}))
+	user, err := s.userRepository.GetUser(s.ctxB, idB)
+	s.NoError(err)
+	s.Equal("ada-b", user.Username)
+
+	// Test case 2: A record cannot be moved to another tenant
+	s.NoError(s.userRepository.UpdateUser(s.ctxA, idA, &domain.User{ID: idA, Username: "ada", TenantID: s.tenantB}))
+	user, err = s.userRepository.GetUser(s.ctxA, idA)
+	s.NoError(err)
+	s.Equal(s.tenantA, user.TenantID)
+
+	// Test case 3: Another tenant's user cannot be deleted
+	s.NoError(s.userRepository.DeleteUser(s.ctxA, idB))
+	_, err = s.userRepository.GetUser(s.ctxB, idB)
+	s.NoError(err)
+	s.assertScoped(s.users)
+}
+
+func (s *TenantIsolationTestSuite) TestRolesAndClaimsDoNotCrossTenants() {
+	roleA, err := s.roleRepository.CreateRole(s.ctxA, &domain.Role{Name: "admin"})
+	s.Require().NoError(err)
+	roleB, err := s.roleRepository.CreateRole(s.ctxB, &domain.Role{Name: "admin"})
+	s.Require().NoError(err)
+	claimA, err := s.claimRepository.CreateClaim(s.ctxA, &domain.Claim{Name: "email"})
+	s.Require().NoError(err)
+	claimB, err := s.claimRepository.CreateClaim(s.ctxB, &domain.Claim{Name: "email"})
+	s.Require().NoError(err)
+
+	// Test case 1: Lookups by name and ID only find the context tenant's records
+	role, err := s.roleRepository.GetRoleByName(s.ctxA, "admin")
+	s.NoError(err)
+	s.Equal(roleA, role.ID)
+	_, err = s.roleRepository.GetRole(s.ctxA, roleB)
+	s.EqualError(err, "role not found")
+	claim, err := s.claimRepository.GetClaimByName(s.ctxA, "email")
+	s.NoError(err)
+	s.Equal(claimA, claim.ID)
+	_, err = s.claimRepository.GetClaim(s.ctxA, claimB)
+	s.EqualError(err, "claim not found")
+
+	// Test case 2: Listing only returns the context tenant's records
+	roles, err := s.roleRepository.GetAllRoles(s.ctxA)
+	s.NoError(err)
+	s.Len(roles, 1)
+	claims, err := s.claimRepository.GetAllClaims(s.ctxA)
+	s.NoError(err)
+	s.Len(claims, 1)
+
+	// Test case 3: Updates and deletes do not reach another tenant's records
+	s.NoError(s.roleRepository.UpdateRole(s.ctxA, roleB, &domain.Role{ID: roleB, Name: "owner"}))
+	s.NoError(s.roleRepository.DeleteRole(s.ctxA, roleB))
+	role, err = s.roleRepository.GetRole(s.ctxB, roleB)
+	s.NoError(err)
+	s.Equal("admin", role.Name)
+	s.NoError(s.claimRepository.UpdateClaim(s.ctxA, claimB, &domain.Claim{ID: claimB, Name: "phone"}))
+	s.NoError(s.claimRepository.DeleteClaim(s.ctxA, claimB))
+	claim, err = s.claimRepository.GetClaim(s.ctxB, claimB)
+	s.NoError(err)
+	s.Equal("email", claim.Name)
+	s.assertScoped(s.roles)
+	s.assertScoped(s.claims)
+}
+
+func (s *TenantIsolationTestSuite) TestInvitationsDoNotCrossTenants() {
+	invitations := &fakeCollection{}
+	repository := &InvitationRepository{invitations: newTenantCollection(invitations)}
+	idA, err := repository.CreateInvitation(s.ctxA, &domain.Invitation{Email: "ada@example.com"})
+	s.Require().NoError(err)
+	idB, err := repository.CreateInvitation(s.ctxB, &domain.Invitation{Email: "ada@example.com"})
+	s.Require().NoError(err)
+
+	// Test case 1: Another tenant's invitation cannot be read or accepted
+	_, err = repository.GetInvitation(s.ctxA, idB)
+	s.EqualError(err, "invitation not found")
+	_, err = repository.ConsumeInvitation(s.ctxA, idB)
+	s.EqualError(err, "invitation not found")
+
+	// Test case 2: Replacing an address's invitations leaves other tenants' alone
+	s.NoError(repository.DeleteInvitationsByEmail(s.ctxA, "ada@example.com"))
+	_, err = repository.GetInvitation(s.ctxA, idA)
+	s.EqualError(err, "invitation not found")
+	invitation, err := repository.ConsumeInvitation(s.ctxB, idB)
+	s.NoError(err)
+	s.Equal(s.tenantB, invitation.TenantID)
+	s.Empty(invitations.documents)
+	s.assertScoped(invitations)
+}
+
+func (s *TenantIsolationTestSuite) TestSessionsDoNotCrossTenants() {
+	sessions := &fakeCollection{}
+	repository := &SessionRepository{sessions: newTenantCollection(sessions)}
+	userB := primitive.NewObjectID()
+	idB, err := repository.CreateSession(s.ctxB, &domain.Session{UserID: userB, IP: "198.51.100.7", UserAgent: "Firefox"})
+	s.Require().NoError(err)
+
+	// Test case 1: Another tenant's sessions cannot be listed or read
+	found, err := repository.GetSessionsByUser(s.ctxA, userB)
+	s.NoError(err)
+	s.Empty(found)
+	_, err = repository.GetSession(s.ctxA, idB)
+	s.EqualError(err, "session not found")
+
+	// Test case 2: Another tenant's sessions cannot be ended
+	s.NoError(repository.DeleteSession(s.ctxA, idB))
+	count, err := repository.DeleteSessionsByUser(s.ctxA, userB)
+	s.NoError(err)
+	s.Zero(count)
+	found, err = repository.GetSessionsByUser(s.ctxB, userB)
+	s.NoError(err)
+	s.Len(found, 1)
+	s.assertScoped(sessions)
+}
+
+func (s *TenantIsolationTestSuite) TestAuditEntriesDoNotCrossTenants() {
+	entries := &fakeCollection{documents: []bson.M{
+		{"_id": primitive.NewObjectID(), "sequence": int64(1), "action": "user.suspend", "tenantId": s.tenantA, "actorId": "ada", "ip": "192.0.2.1"},
+		{"_id": primitive.NewObjectID(), "sequence": int64(2), "action": "user.suspend", "tenantId": s.tenantB, "actorId": "grace", "ip": "198.51.100.7"},
+		{"_id": primitive.NewObjectID(), "sequence": int64(3), "action": "user.suspend", "actorId": "linus"},
+	}}
+	repository := &AuditRepository{entries: entries}
+
+	// Test case 1: A tenant reads only its own entries
+	found, total, err := repository.FindEntries(s.ctxA, domain.AuditFilter{Action: "user.suspend"}, 0, 10)
+	s.NoError(err)
+	s.Equal(int64(1), total)
+	s.Require().Len(found, 1)
+	s.Equal("ada", found[0].ActorID)
+
+	// Test case 2: Filtering on another tenant's actor finds nothing
+	found, total, err = repository.FindEntries(s.ctxA, domain.AuditFilter{ActorID: "grace"}, 0, 10)
+	s.NoError(err)
+	s.Zero(total)
+	s.Empty(found)
+
+	// Test case 3: A context without a tenant is refused
+	_, _, err = repository.FindEntries(context.Background(), domain.AuditFilter{}, 0, 10)
+	s.ErrorIs(err, ErrNoTenant)
+	for _, filter := range entries.filters {
+		s.Equal(s.tenantA, filter[tenantField])
+	}
+}
+
+func (s *TenantIsolationTestSuite) TestContextWithoutTenantIsRefused() {
+	ctx := context.Background()
+	id := primitive.NewObjectID()
+
+	// Test case 1: Every repository method fails before querying
+	_, err := s.userRepository.CreateUser(ctx, &domain.User{})
+	s.ErrorIs(err, ErrNoTenant)
+	_, err = s.userRepository.GetUser(ctx, id)
+	s.ErrorIs(err, ErrNoTenant)
+	_, err = s.userRepository.GetUserByEmail(ctx, "ada@example.com")
+	s.ErrorIs(err, ErrNoTenant)
+	s.ErrorIs(s.userRepository.UpdateUser(ctx, id, &domain.User{}), ErrNoTenant)
+	s.ErrorIs(s.userRepository.DeleteUser(ctx, id), ErrNoTenant)
+	_, err = s.userRepository.GetAllUsers(ctx)
+	s.ErrorIs(err, ErrNoTenant)
+	_, err = s.roleRepository.CreateRole(ctx, &domain.Role{})
+	s.ErrorIs(err, ErrNoTenant)
+	_, err = s.roleRepository.GetAllRoles(ctx)
+	s.ErrorIs(err, ErrNoTenant)
+	_, err = s.claimRepository.GetClaimByName(ctx, "email")
+	s.ErrorIs(err, ErrNoTenant)
+	s.ErrorIs(s.claimRepository.DeleteClaim(ctx, id), ErrNoTenant)
+	s.Empty(s.users.filters)
+	s.Empty(s.users.documents)
+	s.Empty(s.roles.documents)
+	s.Empty(s.claims.filters)
+}
+
+func (s *TenantIsolationTestSuite) TestTenantConditionCannotBeOverridden() {
+	// Test case 1: A tenant condition passed by a caller is replaced
+	collection := newTenantCollection(s.users)
+	var found []bson.M
+	s.NoError(collection.Find(s.ctxA, bson.M{tenantField: s.tenantB}, &found))
+	s.Equal(bson.M{tenantField: s.tenantA}, s.users.filters[0])
+}
+
+func TestTenantIsolationSuite(t *testing.T) {
+	suite.Run(t, new(TenantIsolationTestSuite))
+}
@@ -9,22 +9,38 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 const collectionName = "users"
 
+// UserRepository stores users. It only sees the users of the organization
+// the context is scoped to.
 type UserRepository struct {
-	db *mongo.Database
+	db    *mongo.Database
+	users tenantCollection
 }
 
 func NewUserRepository(db *mongo.Database) *UserRepository {
-	return &UserRepository{db: db}
+	return &UserRepository{db: db, users: newTenantCollection(db.Collection(collectionName))}
+}
+
+// EnsureIndexes creates the unique index keeping email addresses apart
+// within an organization.
+func (r *UserRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.db.Collection(collectionName).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: tenantField, Value: 1}, {Key: "email", Value: 1}}, Options: options.Index().SetUnique(true)},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create user indexes: %w", err)
+	}
+	return nil
 }
 
 func (r *UserRepository) CreateUser(ctx context.Context, user *domain.User) (primitive.ObjectID, error) {
 	user.CreatedAt = time.Now()
 	user.UpdatedAt = time.Now()
-	result, err := r.db.Collection(collectionName).InsertOne(ctx, user)
+	result, err := r.users.InsertOne(ctx, user)
 	if err != nil {
 		return primitive.ObjectID{}, fmt.Errorf("failed to insert user: %w", err)
 	}
@@ -32,13 +48,14 @@ func (r *UserRepository) CreateUser(ctx context.Context, user *domain.User) (pri
 	if !ok {
 		return primitive.ObjectID{}, fmt.Errorf("failed to convert inserted id to ObjectID")
 	}
+	user.TenantID, _ = domain.TenantFromContext(ctx)
 	return objectID, nil
 }
 
 func (r *UserRepository) GetUser(ctx context.Context, id primitive.ObjectID) (*domain.User, error) {
 	var user domain.User
 	filter := bson.M{"_id": id}
-	err := r.db.Collection(collectionName).FindOne(ctx, filter).Decode(&user)
+	err := r.users.FindOne(ctx, filter, &user)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, fmt.Errorf("user not found")
@@ -51,7 +68,7 @@ func (r *UserRepository) GetUser(ctx context.Context, id primitive.ObjectID) (*d
 func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
 	var user domain.User
 	filter := bson.M{"email": email}
-	err := r.db.Collection(collectionName).FindOne(ctx, filter).Decode(&user)
+	err := r.users.FindOne(ctx, filter, &user)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, fmt.Errorf("user not found")
@@ -64,8 +81,7 @@ func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*dom
 func (r *UserRepository) UpdateUser(ctx context.Context, id primitive.ObjectID, user *domain.User) error {
 	user.UpdatedAt = time.Now()
 	filter := bson.M{"_id": id}
-	update := bson.M{"$set": user}
-	_, err := r.db.Collection(collectionName).UpdateOne(ctx, filter, update)
+	_, err := r.users.UpdateOne(ctx, filter, user)
 	if err != nil {
 		return fmt.Errorf("failed to update user: %w", err)
 	}
@@ -74,7 +90,7 @@ func (r *UserRepository) UpdateUser(ctx context.Context, id primitive.ObjectID,
 
 func (r *UserRepository) DeleteUser(ctx context.Context, id primitive.ObjectID) error {
 	filter := bson.M{"_id": id}
-	_, err := r.db.Collection(collectionName).DeleteOne(ctx, filter)
+	_, err := r.users.DeleteOne(ctx, filter)
 	if err != nil {
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
@@ -83,15 +99,9 @@ func (r *UserRepository) DeleteUser(ctx context.Context, id primitive.ObjectID)
 
 func (r *UserRepository) GetAllUsers(ctx context.Context) ([]*domain.User, error) {
 	var users []*domain.User
-	cursor, err := r.db.Collection(collectionName).Find(ctx, bson.M{})
-	if err != nil {
+	if err := r.users.Find(ctx, bson.M{}, &users); err != nil {
 		return nil, fmt.Errorf("failed to get all users: %w", err)
 	}
-	defer cursor.Close(ctx)
-
-	if err = cursor.All(ctx, &users); err != nil {
-		return nil, fmt.Errorf("failed to decode users: %w", err)
-	}
 
 	return users, nil
 }
@@ -0,0 +1,53 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"veritas/core/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const webAuthnChallengeCollectionName = "webauthn_challenges"
+
+type WebAuthnChallengeRepository struct {
+	db *mongo.Database
+}
+
+func NewWebAuthnChallengeRepository(db *mongo.Database) *WebAuthnChallengeRepository {
+	return &WebAuthnChallengeRepository{db: db}
+}
+
+func (r *WebAuthnChallengeRepository) CreateChallenge(ctx context.Context, challenge *domain.WebAuthnChallenge) (primitive.ObjectID, error) {
+	challenge.CreatedAt = time.Now()
+
+	result, err := r.db.Collection(webAuthnChallengeCollectionName).InsertOne(ctx, challenge)
+	if err != nil {
+		return primitive.ObjectID{}, fmt.Errorf("failed to insert challenge: %w", err)
+	}
+
+	objectID, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return primitive.ObjectID{}, fmt.Errorf("failed to convert inserted id to ObjectID")
+	}
+
+	return objectID, nil
+}
+
+func (r *WebAuthnChallengeRepository) ConsumeChallenge(ctx context.Context, challenge string, ceremony string) (*domain.WebAuthnChallenge, error) {
+	var stored domain.WebAuthnChallenge
+	filter := bson.M{"challenge": challenge, "ceremony": ceremony}
+
+	err := r.db.Collection(webAuthnChallengeCollectionName).FindOneAndDelete(ctx, filter).Decode(&stored)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("challenge not found")
+		}
+		return nil, fmt.Errorf("failed to consume challenge: %w", err)
+	}
+
+	return &stored, nil
+}
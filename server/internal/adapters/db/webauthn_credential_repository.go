@@ -0,0 +1,99 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"veritas/core/domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const webAuthnCredentialCollectionName = "webauthn_credentials"
+
+type WebAuthnCredentialRepository struct {
+	db *mongo.Database
+}
+
+func NewWebAuthnCredentialRepository(db *mongo.Database) *WebAuthnCredentialRepository {
+	return &WebAuthnCredentialRepository{db: db}
+}
+
+func (r *WebAuthnCredentialRepository) CreateCredential(ctx context.Context, credential *domain.WebAuthnCredential) (primitive.ObjectID, error) {
+	credential.CreatedAt = time.Now()
+
+	result, err := r.db.Collection(webAuthnCredentialCollectionName).InsertOne(ctx, credential)
+	if err != nil {
+		return primitive.ObjectID{}, fmt.Errorf("failed to insert credential: %w", err)
+	}
+
+	objectID, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return primitive.ObjectID{}, fmt.Errorf("failed to convert inserted id to ObjectID")
+	}
+
+	return objectID, nil
+}
+
+func (r *WebAuthnCredentialRepository) GetCredential(ctx context.Context, id primitive.ObjectID) (*domain.WebAuthnCredential, error) {
+	return r.findOne(ctx, bson.M{"_id": id})
+}
+
+func (r *WebAuthnCredentialRepository) GetCredentialByCredentialID(ctx context.Context, credentialID []byte) (*domain.WebAuthnCredential, error) {
+	return r.findOne(ctx, bson.M{"credentialId": credentialID})
+}
+
+func (r *WebAuthnCredentialRepository) GetCredentialsByUser(ctx context.Context, userID primitive.ObjectID) ([]*domain.WebAuthnCredential, error) {
+	var credentials []*domain.WebAuthnCredential
+
+	cursor, err := r.db.Collection(webAuthnCredentialCollectionName).Find(ctx, bson.M{"userId": userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credentials: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	if err = cursor.All(ctx, &credentials); err != nil {
+		return nil, fmt.Errorf("failed to decode credentials: %w", err)
+	}
+
+	return credentials, nil
+}
+
+func (r *WebAuthnCredentialRepository) UpdateCredential(ctx context.Context, id primitive.ObjectID, credential *domain.WebAuthnCredential) error {
+	filter := bson.M{"_id": id}
+	update := bson.M{"$set": credential}
+
+	_, err := r.db.Collection(webAuthnCredentialCollectionName).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to update credential: %w", err)
+	}
+
+	return nil
+}
+
+func (r *WebAuthnCredentialRepository) DeleteCredential(ctx context.Context, id primitive.ObjectID) error {
+	filter := bson.M{"_id": id}
+
+	_, err := r.db.Collection(webAuthnCredentialCollectionName).DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to delete credential: %w", err)
+	}
+
+	return nil
+}
+
+func (r *WebAuthnCredentialRepository) findOne(ctx context.Context, filter bson.M) (*domain.WebAuthnCredential, error) {
+	var credential domain.WebAuthnCredential
+
+	err := r.db.Collection(webAuthnCredentialCollectionName).FindOne(ctx, filter).Decode(&credential)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("credential not found")
+		}
+		return nil, fmt.Errorf("failed to get credential: %w", err)
+	}
+
+	return &credential, nil
+}
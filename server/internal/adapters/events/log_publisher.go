@@ -0,0 +1,26 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"veritas/core/domain"
+	"veritas/internal/ports/output"
+)
+
+type logPublisher struct{}
+
+// NewLogPublisher returns an EventPublisher that writes each event to the
+// application log as a JSON line.
+func NewLogPublisher() output.EventPublisher {
+	return &logPublisher{}
+}
+
+func (p *logPublisher) Publish(ctx context.Context, event *domain.Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	log.Printf("event: %s", line)
+	return nil
+}
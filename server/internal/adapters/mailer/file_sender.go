@@ -0,0 +1,38 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+	"veritas/core/domain"
+	"veritas/internal/ports/output"
+)
+
+type fileSender struct {
+	dir  string
+	from string
+}
+
+// NewFileSender returns an EmailSender that writes each message to an .eml
+// file in dir, for local development.
+func NewFileSender(dir string, from string) output.EmailSender {
+	return &fileSender{dir: dir, from: from}
+}
+
+func (s *fileSender) SendEmail(ctx context.Context, message *domain.EmailMessage) error {
+	body, err := buildMessage(s.from, message)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create mail directory: %w", err)
+	}
+
+	name := fmt.Sprintf("%s-%d.eml", time.Now().UTC().Format("20060102T150405"), time.Now().UnixNano())
+	if err := os.WriteFile(filepath.Join(s.dir, name), body, 0o600); err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package mailer
+
+import (
+	"context"
+	"sync"
+	"veritas/core/domain"
+)
+
+// MemorySender keeps sent messages in memory. It is meant for tests and
+// local development.
+type MemorySender struct {
+	mu       sync.Mutex
+	messages []domain.EmailMessage
+}
+
+func NewMemorySender() *MemorySender {
+	return &MemorySender{}
+}
+
+func (s *MemorySender) SendEmail(ctx context.Context, message *domain.EmailMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, *message)
+	return nil
+}
+
+// Messages returns a copy of the messages sent so far.
+func (s *MemorySender) Messages() []domain.EmailMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]domain.EmailMessage(nil), s.messages...)
+}
@@ -0,0 +1,68 @@
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"time"
+	"veritas/core/domain"
+	"veritas/internal/security"
+)
+
+// buildMessage renders an RFC 5322 message. When an HTML body is present the
+// message is multipart/alternative with the text part first.
+func buildMessage(from string, message *domain.EmailMessage) ([]byte, error) {
+	fromAddress, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address: %w", err)
+	}
+	toAddress, err := mail.ParseAddress(message.To)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient address: %w", err)
+	}
+	messageID, err := security.RandomBytes(16)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", fromAddress.String())
+	fmt.Fprintf(&buf, "To: %s\r\n", toAddress.String())
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "Message-ID: <%x@%s>\r\n", messageID, domainOf(fromAddress.Address))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+
+	if message.HTMLBody == "" {
+		writePart(&buf, "text/plain", message.TextBody)
+		return buf.Bytes(), nil
+	}
+
+	boundary := fmt.Sprintf("%x", messageID)
+	fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", boundary)
+	fmt.Fprintf(&buf, "--%s\r\n", boundary)
+	writePart(&buf, "text/plain", message.TextBody)
+	fmt.Fprintf(&buf, "\r\n--%s\r\n", boundary)
+	writePart(&buf, "text/html", message.HTMLBody)
+	fmt.Fprintf(&buf, "\r\n--%s--\r\n", boundary)
+	return buf.Bytes(), nil
+}
+
+func writePart(buf *bytes.Buffer, contentType, body string) {
+	fmt.Fprintf(buf, "Content-Type: %s; charset=utf-8\r\n", contentType)
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+	writer := quotedprintable.NewWriter(buf)
+	writer.Write([]byte(body))
+	writer.Close()
+}
+
+func domainOf(address string) string {
+	for i := len(address) - 1; i >= 0; i-- {
+		if address[i] == '@' {
+			return address[i+1:]
+		}
+	}
+	return "localhost"
+}
@@ -0,0 +1,44 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"veritas/config"
+	"veritas/core/domain"
+	"veritas/internal/ports/output"
+)
+
+type smtpSender struct {
+	config config.SMTPConfig
+	from   string
+}
+
+// NewSMTPSender returns an EmailSender delivering through an SMTP relay. The
+// connection is upgraded with STARTTLS when the server offers it, and
+// credentials are only sent over TLS or to localhost.
+func NewSMTPSender(smtpConfig config.SMTPConfig, from string) output.EmailSender {
+	return &smtpSender{config: smtpConfig, from: from}
+}
+
+func (s *smtpSender) SendEmail(ctx context.Context, message *domain.EmailMessage) error {
+	body, err := buildMessage(s.from, message)
+	if err != nil {
+		return err
+	}
+	fromAddress, _ := mail.ParseAddress(s.from)
+	toAddress, _ := mail.ParseAddress(message.To)
+
+	var auth smtp.Auth
+	if s.config.Username != "" {
+		auth = smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+	}
+
+	addr := net.JoinHostPort(s.config.Host, s.config.Port)
+	if err := smtp.SendMail(addr, auth, fromAddress.Address, []string{toAddress.Address}, body); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+	"veritas/core/domain"
+)
+
+// sweepInterval is how many hits pass between removals of stale counters.
+const sweepInterval = 1000
+
+type rateLimitEntry struct {
+	resetAt       time.Time
+	window        time.Duration
+	count         int
+	previousCount int
+}
+
+// RateLimitStore keeps rate limit counters in process memory. Limits are not
+// shared between replicas; use the MongoDB store for that.
+type RateLimitStore struct {
+	mu      sync.Mutex
+	entries map[string]*rateLimitEntry
+	hits    int
+}
+
+func NewRateLimitStore() *RateLimitStore {
+	return &RateLimitStore{entries: map[string]*rateLimitEntry{}}
+}
+
+func (s *RateLimitStore) Hit(ctx context.Context, key string, window time.Duration) (*domain.RateLimitCounter, error) {
+	now := time.Now()
+	resetAt := now.Truncate(window).Add(window)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.hits++
+	if s.hits%sweepInterval == 0 {
+		s.sweep(now)
+	}
+
+	entry, ok := s.entries[key]
+	switch {
+	case !ok:
+		entry = &rateLimitEntry{resetAt: resetAt, window: window}
+		s.entries[key] = entry
+	case entry.resetAt.Equal(resetAt.Add(-window)):
+		entry.previousCount, entry.count = entry.count, 0
+		entry.resetAt = resetAt
+	case !entry.resetAt.Equal(resetAt):
+		entry.previousCount, entry.count = 0, 0
+		entry.resetAt = resetAt
+	}
+	entry.count++
+
+	return &domain.RateLimitCounter{
+		Key:           key,
+		Count:         entry.count,
+		PreviousCount: entry.previousCount,
+		ResetAt:       entry.resetAt,
+	}, nil
+}
+
+// sweep removes counters whose windows can no longer affect a limit.
+func (s *RateLimitStore) sweep(now time.Time) {
+	for key, entry := range s.entries {
+		if now.After(entry.resetAt.Add(entry.window)) {
+			delete(s.entries, key)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package siem
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"veritas/core/domain"
+	"veritas/internal/ports/output"
+)
+
+type fileExporter struct {
+	path     string
+	format   Formatter
+	maxSize  int64
+	maxFiles int
+	file     *os.File
+	size     int64
+}
+
+// NewFileExporter returns an AuditExporter that appends one formatted entry
+// per line to path, such as NDJSON for a log shipper to pick up. Once the
+// file would grow beyond maxSize bytes it is renamed to path.1, older files
+// move up by one, and only maxFiles rotated files are kept. A maxSize of
+// zero disables rotation. Each batch is synced to disk before it counts as
+// exported.
+func NewFileExporter(path string, format Formatter, maxSize int64, maxFiles int) output.AuditExporter {
+	return &fileExporter{path: path, format: format, maxSize: maxSize, maxFiles: maxFiles}
+}
+
+func (e *fileExporter) Export(ctx context.Context, entries []*domain.AuditEntry) error {
+	if e.file == nil {
+		if err := e.open(); err != nil {
+			return err
+		}
+	}
+
+	for _, entry := range entries {
+		line := e.format(entry) + "\n"
+		if e.maxSize > 0 && e.size > 0 && e.size+int64(len(line)) > e.maxSize {
+			if err := e.rotate(); err != nil {
+				return err
+			}
+		}
+		n, err := e.file.WriteString(line)
+		e.size += int64(n)
+		if err != nil {
+			e.Close()
+			return fmt.Errorf("failed to write audit export: %w", err)
+		}
+	}
+
+	if err := e.file.Sync(); err != nil {
+		e.Close()
+		return fmt.Errorf("failed to sync audit export: %w", err)
+	}
+	return nil
+}
+
+func (e *fileExporter) Close() error {
+	if e.file == nil {
+		return nil
+	}
+	err := e.file.Close()
+	e.file = nil
+	return err
+}
+
+func (e *fileExporter) open() error {
+	file, err := os.OpenFile(e.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit export file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to open audit export file: %w", err)
+	}
+	e.file, e.size = file, info.Size()
+	return nil
+}
+
+// rotate shifts path.N to path.N+1, dropping the oldest file, moves the
+// current file to path.1 and starts a new one.
+func (e *fileExporter) rotate() error {
+	if err := e.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync audit export: %w", err)
+	}
+	e.Close()
+
+	if e.maxFiles > 0 {
+		os.Remove(e.rotated(e.maxFiles))
+		for i := e.maxFiles - 1; i >= 1; i-- {
+			if err := os.Rename(e.rotated(i), e.rotated(i+1)); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to rotate audit export: %w", err)
+			}
+		}
+		if err := os.Rename(e.path, e.rotated(1)); err != nil {
+			return fmt.Errorf("failed to rotate audit export: %w", err)
+		}
+	} else if err := os.Remove(e.path); err != nil {
+		return fmt.Errorf("failed to rotate audit export: %w", err)
+	}
+
+	return e.open()
+}
+
+func (e *fileExporter) rotated(n int) string {
+	return fmt.Sprintf("%s.%d", e.path, n)
+}
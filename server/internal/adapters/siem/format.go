@@ -0,0 +1,150 @@
+package siem
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"veritas/core/domain"
+)
+
+// Line formats selectable for an exporter.
+const (
+	FormatJSON = "json"
+	FormatCEF  = "cef"
+	FormatLEEF = "leef"
+)
+
+const (
+	vendor  = "Veritas"
+	product = "Veritas"
+	version = "1.0"
+)
+
+// Formatter renders an audit entry as a single line without the newline.
+type Formatter func(entry *domain.AuditEntry) string
+
+// NewFormatter returns the formatter with the given name.
+func NewFormatter(format string) (Formatter, error) {
+	switch format {
+	case FormatJSON, "":
+		return FormatJSONLine, nil
+	case FormatCEF:
+		return FormatCEFLine, nil
+	case FormatLEEF:
+		return FormatLEEFLine, nil
+	default:
+		return nil, fmt.Errorf("unknown audit export format %q", format)
+	}
+}
+
+// FormatJSONLine renders the entry as one JSON object, for NDJSON files.
+func FormatJSONLine(entry *domain.AuditEntry) string {
+	data, _ := json.Marshal(entry)
+	return string(data)
+}
+
+// FormatCEFLine renders the entry in ArcSight Common Event Format. The
+// action is the signature ID, and the fields without a CEF key are sent as
+// labelled custom strings.
+func FormatCEFLine(entry *domain.AuditEntry) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "CEF:0|%s|%s|%s|%s|%s|%d|",
+		cefHeader(vendor), cefHeader(product), cefHeader(version),
+		cefHeader(entry.Action), cefHeader(entry.Action+" "+entry.Outcome), cefSeverity(entry))
+
+	var extension []string
+	add := func(key, value string) {
+		if value != "" {
+			extension = append(extension, key+"="+cefValue(value))
+		}
+	}
+	custom := func(n int, label, value string) {
+		if value != "" {
+			add(fmt.Sprintf("cs%dLabel", n), label)
+			add(fmt.Sprintf("cs%d", n), value)
+		}
+	}
+	add("rt", strconv.FormatInt(entry.Time.UnixMilli(), 10))
+	add("externalId", strconv.FormatInt(entry.Sequence, 10))
+	add("cat", entry.Category)
+	add("outcome", entry.Outcome)
+	add("reason", entry.Reason)
+	add("suid", entry.ActorID)
+	add("src", entry.IP)
+	add("requestClientApplication", entry.UserAgent)
+	add("msg", changeSummary(entry.Changes))
+	custom(1, "targetType", entry.TargetType)
+	custom(2, "targetId", entry.TargetID)
+	custom(3, "requestId", entry.RequestID)
+	custom(4, "hash", entry.Hash)
+
+	b.WriteString(strings.Join(extension, " "))
+	return b.String()
+}
+
+// FormatLEEFLine renders the entry in IBM QRadar Log Event Extended Format
+// 1.0, with tab-separated attributes.
+func FormatLEEFLine(entry *domain.AuditEntry) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "LEEF:1.0|%s|%s|%s|%s|",
+		leefHeader(vendor), leefHeader(product), leefHeader(version), leefHeader(entry.Action))
+
+	var attributes []string
+	add := func(key, value string) {
+		if value != "" {
+			attributes = append(attributes, key+"="+leefValue(value))
+		}
+	}
+	add("devTime", entry.Time.UTC().Format("2006-01-02T15:04:05.000Z"))
+	add("devTimeFormat", "yyyy-MM-dd'T'HH:mm:ss.SSSX")
+	add("cat", entry.Category)
+	add("sev", strconv.Itoa(max(cefSeverity(entry), 1)))
+	add("outcome", entry.Outcome)
+	add("reason", entry.Reason)
+	add("usrName", entry.ActorID)
+	add("src", entry.IP)
+	add("userAgent", entry.UserAgent)
+	add("targetType", entry.TargetType)
+	add("targetId", entry.TargetID)
+	add("changes", changeSummary(entry.Changes))
+	add("requestId", entry.RequestID)
+	add("sequence", strconv.FormatInt(entry.Sequence, 10))
+	add("hash", entry.Hash)
+
+	b.WriteString(strings.Join(attributes, "\t"))
+	return b.String()
+}
+
+// cefSeverity maps an entry to the 0-10 CEF scale: failures stand out
+// most, then administrative changes.
+func cefSeverity(entry *domain.AuditEntry) int {
+	switch {
+	case entry.Outcome == domain.AuditOutcomeFailure:
+		return 6
+	case entry.Category == domain.AuditCategoryAdmin:
+		return 4
+	default:
+		return 2
+	}
+}
+
+func changeSummary(changes []domain.AuditChange) string {
+	parts := make([]string, 0, len(changes))
+	for _, change := range changes {
+		parts = append(parts, fmt.Sprintf("%s: %q -> %q", change.Field, change.Before, change.After))
+	}
+	return strings.Join(parts, "; ")
+}
+
+var (
+	cefHeaderEscaper  = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\r", " ", "\n", " ")
+	cefValueEscaper   = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\r", `\r`, "\n", `\n`)
+	leefHeaderEscaper = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\r", " ", "\n", " ", "\t", " ")
+	leefValueEscaper  = strings.NewReplacer("\r", " ", "\n", " ", "\t", " ")
+)
+
+func cefHeader(s string) string  { return cefHeaderEscaper.Replace(s) }
+func cefValue(s string) string   { return cefValueEscaper.Replace(s) }
+func leefHeader(s string) string { return leefHeaderEscaper.Replace(s) }
+func leefValue(s string) string  { return leefValueEscaper.Replace(s) }
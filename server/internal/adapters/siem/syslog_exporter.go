@@ -0,0 +1,141 @@
+package siem
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+	"veritas/core/domain"
+	"veritas/internal/ports/output"
+)
+
+// Syslog transports.
+const (
+	NetworkUDP = "udp"
+	NetworkTCP = "tcp"
+	NetworkTLS = "tls"
+)
+
+const (
+	syslogAppName = "veritas"
+	// syslogFacility is "log audit" (13).
+	syslogFacility = 13
+	syslogTimeout  = 10 * time.Second
+	// maxUDPMessage keeps datagrams within the size every receiver accepts
+	// (RFC 5426).
+	maxUDPMessage = 2048
+)
+
+type syslogExporter struct {
+	network   string
+	address   string
+	tlsConfig *tls.Config
+	format    Formatter
+	hostname  string
+	conn      net.Conn
+}
+
+// NewSyslogExporter returns an AuditExporter that sends each entry as an
+// RFC 5424 message whose body is the formatted entry. Over TCP and TLS
+// messages are framed with octet counting (RFC 6587, RFC 5425). UDP offers
+// no delivery guarantee, so entries lost in transit are not sent again.
+func NewSyslogExporter(network, address string, tlsConfig *tls.Config, format Formatter) (output.AuditExporter, error) {
+	if network != NetworkUDP && network != NetworkTCP && network != NetworkTLS {
+		return nil, fmt.Errorf("unknown syslog network %q", network)
+	}
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	return &syslogExporter{network: network, address: address, tlsConfig: tlsConfig, format: format, hostname: hostname}, nil
+}
+
+func (e *syslogExporter) Export(ctx context.Context, entries []*domain.AuditEntry) error {
+	if e.conn == nil {
+		if err := e.dial(ctx); err != nil {
+			return err
+		}
+	}
+
+	for _, entry := range entries {
+		message := e.message(entry)
+		if e.network == NetworkUDP {
+			if len(message) > maxUDPMessage {
+				message = message[:maxUDPMessage]
+			}
+		} else {
+			message = fmt.Sprintf("%d %s", len(message), message)
+		}
+
+		e.conn.SetWriteDeadline(time.Now().Add(syslogTimeout))
+		if _, err := e.conn.Write([]byte(message)); err != nil {
+			e.Close()
+			return fmt.Errorf("failed to write to syslog %s: %w", e.address, err)
+		}
+	}
+	return nil
+}
+
+func (e *syslogExporter) Close() error {
+	if e.conn == nil {
+		return nil
+	}
+	err := e.conn.Close()
+	e.conn = nil
+	return err
+}
+
+func (e *syslogExporter) dial(ctx context.Context) error {
+	dialer := &net.Dialer{Timeout: syslogTimeout}
+	var err error
+	if e.network == NetworkTLS {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: e.tlsConfig}
+		e.conn, err = tlsDialer.DialContext(ctx, "tcp", e.address)
+	} else {
+		e.conn, err = dialer.DialContext(ctx, e.network, e.address)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to syslog %s: %w", e.address, err)
+	}
+	return nil
+}
+
+// message renders entry as an RFC 5424 message. The action is the MSGID, so
+// collectors can route entries without parsing the body.
+func (e *syslogExporter) message(entry *domain.AuditEntry) string {
+	return fmt.Sprintf("<%d>1 %s %s %s - %s - %s",
+		syslogFacility*8+syslogSeverity(entry),
+		entry.Time.UTC().Format("2006-01-02T15:04:05.000Z"),
+		e.hostname, syslogAppName, syslogMsgID(entry.Action), e.format(entry))
+}
+
+// syslogSeverity returns warning for failures, notice for administrative
+// changes and informational otherwise.
+func syslogSeverity(entry *domain.AuditEntry) int {
+	switch {
+	case entry.Outcome == domain.AuditOutcomeFailure:
+		return 4
+	case entry.Category == domain.AuditCategoryAdmin:
+		return 5
+	default:
+		return 6
+	}
+}
+
+// syslogMsgID limits action to the 32 printable ASCII characters a MSGID
+// may have.
+func syslogMsgID(action string) string {
+	id := strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return '_'
+		}
+		return r
+	}, action)
+	if id == "" {
+		return "-"
+	}
+	return id[:min(len(id), 32)]
+}
@@ -0,0 +1,44 @@
+package sms
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+	"veritas/core/domain"
+	"veritas/internal/ports/output"
+)
+
+type logSender struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewLogSender returns an SMSSender for local development. Messages are
+// appended to the file at path, or written to the log when path is empty.
+func NewLogSender(path string) output.SMSSender {
+	return &logSender{path: path}
+}
+
+func (s *logSender) SendSMS(ctx context.Context, message *domain.SMSMessage) error {
+	if s.path == "" {
+		log.Printf("SMS to %s: %s", message.To, message.Body)
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open sms log: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := fmt.Fprintf(file, "%s\t%s\t%q\n", time.Now().UTC().Format(time.RFC3339), message.To, message.Body); err != nil {
+		return fmt.Errorf("failed to write sms log: %w", err)
+	}
+	return nil
+}
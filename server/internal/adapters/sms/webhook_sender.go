@@ -0,0 +1,52 @@
+package sms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+	"veritas/core/domain"
+	"veritas/internal/ports/output"
+)
+
+type webhookSender struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// NewWebhookSender returns an SMSSender that posts each message as JSON,
+// {"to": "+15551234567", "body": "..."}, to an SMS gateway. When token is set
+// it is sent as a bearer token. Any non-2xx response is an error.
+func NewWebhookSender(url string, token string) output.SMSSender {
+	return &webhookSender{url: url, token: token, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (s *webhookSender) SendSMS(ctx context.Context, message *domain.SMSMessage) error {
+	payload, err := json.Marshal(map[string]string{"to": message.To, "body": message.Body})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create sms request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send sms: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("failed to send sms: gateway returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"net/http"
+	"veritas/core/domain"
+	"veritas/core/usecases"
+	"veritas/internal/ports/dtos"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditHandler handles audit log HTTP requests.
+type AuditHandler struct {
+	auditUseCase usecases.AuditUsecase
+}
+
+// NewAuditHandler creates a new AuditHandler with the given AuditUsecase.
+func NewAuditHandler(auditUsecase usecases.AuditUsecase) *AuditHandler {
+	return &AuditHandler{
+		auditUseCase: auditUsecase,
+	}
+}
+
+// ListAuditEntries godoc
+// @Summary List audit log entries
+// @Description List audit log entries, newest first, optionally filtered. Times are RFC 3339; from is inclusive and to exclusive.
+// @Tags audit
+// @Produce  json
+// @Param actorId query string false "Actor user ID"
+// @Param action query string false "Action, e.g. auth.password"
+// @Param category query string false "Category" Enums(authentication, account, admin)
+// @Param outcome query string false "Outcome" Enums(success, failure)
+// @Param targetType query string false "Target type, e.g. user"
+// @Param targetId query string false "Target ID"
+// @Param from query string false "Earliest time"
+// @Param to query string false "Latest time"
+// @Param page query int false "Page, starting at 1"
+// @Param pageSize query int false "Entries per page, at most 500"
+// @Success 200 {object} dtos.AuditPageOutputDTO
+// @Failure 403 {object} object{error=string}
+// @Security ApiKeyAuth
+// @Router /audit [get]
+func (h *AuditHandler) ListAuditEntries(c *gin.Context) {
+	var input dtos.ListAuditEntriesInputDTO
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter := domain.AuditFilter{
+		ActorID:    input.ActorID,
+		Action:     input.Action,
+		Category:   input.Category,
+		Outcome:    input.Outcome,
+		TargetType: input.TargetType,
+		TargetID:   input.TargetID,
+		From:       input.From,
+		To:         input.To,
+	}
+
+	page, err := h.auditUseCase.ListEntries(c.Request.Context(), filter, input.Page, input.PageSize)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	output := dtos.AuditPageOutputDTO{
+		Entries:  []dtos.AuditEntryOutputDTO{},
+		Page:     page.Page,
+		PageSize: page.PageSize,
+		Total:    page.Total,
+	}
+	for _, entry := range page.Entries {
+		output.Entries = append(output.Entries, auditEntryOutput(entry))
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+func auditEntryOutput(entry *domain.AuditEntry) dtos.AuditEntryOutputDTO {
+	output := dtos.AuditEntryOutputDTO{
+		ID:         entry.ID.Hex(),
+		Sequence:   entry.Sequence,
+		Time:       entry.Time,
+		Category:   entry.Category,
+		Action:     entry.Action,
+		Outcome:    entry.Outcome,
+		Reason:     entry.Reason,
+		ActorID:    entry.ActorID,
+		TargetType: entry.TargetType,
+		TargetID:   entry.TargetID,
+		IP:         entry.IP,
+		UserAgent:  entry.UserAgent,
+		RequestID:  entry.RequestID,
+		PrevHash:   entry.PrevHash,
+		Hash:       entry.Hash,
+	}
+	for _, change := range entry.Changes {
+		output.Changes = append(output.Changes, dtos.AuditChangeOutputDTO{
+			Field:  change.Field,
+			Before: change.Before,
+			After:  change.After,
+		})
+	}
+	return output
+}
@@ -1,10 +1,19 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"log"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
+	"veritas/config"
+	"veritas/core/domain"
 	"veritas/core/usecases"
+	"veritas/internal/middleware"
 	"veritas/internal/ports/dtos"
+	"veritas/internal/security"
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/gin-gonic/gin"
@@ -12,24 +21,59 @@ import (
 
 // AuthHandler handles authentication-related HTTP requests.
 type AuthHandler struct {
-	userUseCase usecases.UserUsecase
+	userUseCase     usecases.UserUsecase
+	mfaUseCase      usecases.MFAUsecase
+	webAuthnUseCase usecases.WebAuthnUsecase
+	emailUseCase    usecases.EmailVerificationUsecase
+	resetUseCase    usecases.PasswordResetUsecase
+	loginUseCase    usecases.PasswordlessUsecase
+	smsUseCase      usecases.SMSUsecase
+	lockoutUseCase  usecases.LoginProtectionUsecase
+	sessionUseCase  usecases.SessionUsecase
+	cookies         config.SessionCookieConfig
+	requireDPoP     bool
 }
 
-// NewAuthHandler creates a new AuthHandler with the given UserUseCase.
-func NewAuthHandler(userUsecase usecases.UserUsecase) *AuthHandler {
+// NewAuthHandler creates a new AuthHandler with the given use cases.
+func NewAuthHandler(userUsecase usecases.UserUsecase, mfaUsecase usecases.MFAUsecase, webAuthnUsecase usecases.WebAuthnUsecase, emailUsecase usecases.EmailVerificationUsecase, resetUsecase usecases.PasswordResetUsecase, passwordlessUsecase usecases.PasswordlessUsecase, smsUsecase usecases.SMSUsecase, lockoutUsecase usecases.LoginProtectionUsecase, sessionUsecase usecases.SessionUsecase, cookies config.SessionCookieConfig, requireDPoP bool) *AuthHandler {
 	return &AuthHandler{
-		userUseCase: userUsecase,
+		userUseCase:     userUsecase,
+		mfaUseCase:      mfaUsecase,
+		webAuthnUseCase: webAuthnUsecase,
+		emailUseCase:    emailUsecase,
+		resetUseCase:    resetUsecase,
+		loginUseCase:    passwordlessUsecase,
+		smsUseCase:      smsUsecase,
+		lockoutUseCase:  lockoutUsecase,
+		sessionUseCase:  sessionUsecase,
+		cookies:         cookies,
+		requireDPoP:     requireDPoP,
 	}
 }
 
+// errSecondFactorRequired answers MFA logins that carry no code.
+var errSecondFactorRequired = errors.New("code, smsCode or recoveryCode is required")
+
+const (
+	accessTokenTTL  = time.Hour * 24
+	mfaChallengeTTL = time.Minute * 5
+
+	// loginRequestCookie binds a passwordless login to the browser that
+	// started it.
+	loginRequestCookie = "veritas_login_request"
+)
+
 // Login godoc
 // @Summary Authenticate a user
-// @Description Authenticate a user with the input payload
+// @Description Authenticate a user with the input payload. Users with a second factor receive an MFA challenge to complete at /auth/login/mfa instead of a token. Browser applications can set cookie to receive the token in an HttpOnly session cookie; unsafe requests authenticated by it must then send the returned csrfToken in the X-CSRF-Token header.
 // @Tags auth
 // @Accept  json
 // @Produce  json
 // @Param user body dtos.LoginInputDTO true "Login"
-// @Success 200 {object} object{token=string}
+// @Param DPoP header string false "DPoP proof binding the issued token to the client key"
+// @Success 200 {object} object{token=string,tokenType=string,csrfToken=string} "With cookie set, the token is stored in the session cookie and only tokenType and csrfToken are returned"
+// @Success 202 {object} dtos.MFAChallengeOutputDTO
+// @Failure 429 {object} object{error=string} "Too many failed attempts, see Retry-After"
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var loginInput dtos.LoginInputDTO
@@ -38,24 +82,435 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	_, err := h.userUseCase.VerifyUser(c.Request.Context(), loginInput.Email, loginInput.Password)
+	if !h.checkLoginThrottle(c, loginInput.Email) {
+		return
+	}
+
+	user, err := h.userUseCase.VerifyUser(c.Request.Context(), loginInput.Email, loginInput.Password)
+	if errors.Is(err, usecases.ErrAccountInactive) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
+		h.recordLoginFailure(c, loginInput.Email)
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
 	}
+	if err := h.lockoutUseCase.RecordSuccess(c.Request.Context(), user.Email); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"email": loginInput.Email,
-		"exp":   time.Now().Add(time.Hour * 24).Unix(),
-	})
+	h.completeFirstFactor(c, user, []string{"pwd"}, loginInput.Cookie)
+}
+
+// LoginMFA godoc
+// @Summary Complete a login with a second factor
+// @Description Exchange the MFA challenge from /auth/login and a TOTP, SMS or recovery code for an access token
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param input body dtos.MFALoginInputDTO true "MFA Login"
+// @Param DPoP header string false "DPoP proof binding the issued token to the client key"
+// @Success 200 {object} object{token=string,tokenType=string,csrfToken=string} "With cookie set, the token is stored in the session cookie and only tokenType and csrfToken are returned"
+// @Router /auth/login/mfa [post]
+func (h *AuthHandler) LoginMFA(c *gin.Context) {
+	var mfaInput dtos.MFALoginInputDTO
+	if err := c.ShouldBindJSON(&mfaInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	claims, err := security.ParseToken(mfaInput.MFAToken, security.TokenUseMFAChallenge)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired mfa token"})
+		return
+	}
+	sub, _ := claims["sub"].(string)
+
+	user, err := h.userUseCase.ReadUser(c.Request.Context(), sub)
+	if err != nil || security.IssuedBefore(claims, user.TokensRevokedAt) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired mfa token"})
+		return
+	}
+	if !h.checkLoginThrottle(c, user.Email) {
+		return
+	}
+
+	cookie, _ := claims["cookie"].(bool)
+	methods, err := h.verifySecondFactor(c.Request.Context(), user, mfaInput)
+	if errors.Is(err, errSecondFactorRequired) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, usecases.ErrInvalidMFACode) {
+		h.recordLoginFailure(c, user.Email)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	amr := append(append(firstFactor(claims), "mfa"), methods...)
+	h.issueAccessToken(c, user, amr, cookie)
+}
+
+// verifySecondFactor checks the code submitted to complete an MFA challenge
+// and returns the methods to add to the amr claim.
+func (h *AuthHandler) verifySecondFactor(ctx context.Context, user *domain.User, input dtos.MFALoginInputDTO) ([]string, error) {
+	switch {
+	case input.Code != "":
+		return []string{"otp"}, h.mfaUseCase.VerifyTOTP(ctx, user, input.Code)
+	case input.SMSCode != "":
+		return []string{"sms"}, h.smsUseCase.VerifyLoginCode(ctx, user, input.SMSCode)
+	case input.RecoveryCode != "":
+		return nil, h.mfaUseCase.VerifyRecoveryCode(ctx, user, input.RecoveryCode)
+	}
+	return nil, errSecondFactorRequired
+}
+
+// SendSMSLoginCode godoc
+// @Summary Text a second-factor code
+// @Description Send a code to the verified phone of the user who received the MFA challenge. Submit it as smsCode to /auth/login/mfa.
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param input body dtos.SMSLoginInputDTO true "SMS Login"
+// @Success 202 {object} object{message=string}
+// @Router /auth/login/sms [post]
+func (h *AuthHandler) SendSMSLoginCode(c *gin.Context) {
+	var smsInput dtos.SMSLoginInputDTO
+	if err := c.ShouldBindJSON(&smsInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	claims, err := security.ParseToken(smsInput.MFAToken, security.TokenUseMFAChallenge)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired mfa token"})
+		return
+	}
+	sub, _ := claims["sub"].(string)
+
+	user, err := h.userUseCase.ReadUser(c.Request.Context(), sub)
+	if err != nil || security.IssuedBefore(claims, user.TokensRevokedAt) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired mfa token"})
+		return
+	}
 
-	tokenString, err := token.SignedString([]byte("your_secret_key"))
+	err = h.smsUseCase.SendLoginCode(c.Request.Context(), user)
+	switch {
+	case errors.Is(err, usecases.ErrPhoneNotVerified):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, usecases.ErrSMSRateLimited):
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "Code sent"})
+}
+
+// BeginWebAuthnLogin godoc
+// @Summary Start a passkey login
+// @Description Get the options to pass to navigator.credentials.get. With an MFA token from /auth/login the passkey is used as a second factor, otherwise for a passwordless login with a discoverable credential.
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param input body dtos.BeginWebAuthnLoginInputDTO false "Begin WebAuthn Login"
+// @Success 200 {object} security.CredentialRequestOptions
+// @Router /auth/webauthn/begin [post]
+func (h *AuthHandler) BeginWebAuthnLogin(c *gin.Context) {
+	var beginInput dtos.BeginWebAuthnLoginInputDTO
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&beginInput); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	var userID string
+	if beginInput.MFAToken != "" {
+		claims, err := security.ParseToken(beginInput.MFAToken, security.TokenUseMFAChallenge)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired mfa token"})
+			return
+		}
+		userID, _ = claims["sub"].(string)
+	}
+
+	options, err := h.webAuthnUseCase.BeginLogin(c.Request.Context(), userID)
+	if errors.Is(err, usecases.ErrCredentialNotFound) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not generate token"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"token": tokenString})
+	c.JSON(http.StatusOK, options)
+}
+
+// FinishWebAuthnLogin godoc
+// @Summary Finish a passkey login
+// @Description Verify the authenticator assertion and issue an access token
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param input body dtos.FinishWebAuthnLoginInputDTO true "Finish WebAuthn Login"
+// @Param DPoP header string false "DPoP proof binding the issued token to the client key"
+// @Success 200 {object} object{token=string,tokenType=string,csrfToken=string} "With cookie set, the token is stored in the session cookie and only tokenType and csrfToken are returned"
+// @Router /auth/webauthn/finish [post]
+func (h *AuthHandler) FinishWebAuthnLogin(c *gin.Context) {
+	var finishInput dtos.FinishWebAuthnLoginInputDTO
+	if err := c.ShouldBindJSON(&finishInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// A passkey with user verification satisfies both factors on its own.
+	var userID string
+	var mfaClaims jwt.MapClaims
+	amr := []string{"hwk", "user", "mfa"}
+	cookie := finishInput.Cookie
+	if finishInput.MFAToken != "" {
+		claims, err := security.ParseToken(finishInput.MFAToken, security.TokenUseMFAChallenge)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired mfa token"})
+			return
+		}
+		userID, _ = claims["sub"].(string)
+		mfaClaims = claims
+		amr = append(firstFactor(claims), "hwk", "mfa")
+		cookie, _ = claims["cookie"].(bool)
+	}
+
+	input := usecases.FinishLoginInput{
+		CredentialID:      finishInput.Credential.RawID,
+		ClientDataJSON:    finishInput.Credential.Response.ClientDataJSON,
+		AuthenticatorData: finishInput.Credential.Response.AuthenticatorData,
+		Signature:         finishInput.Credential.Response.Signature,
+		UserHandle:        finishInput.Credential.Response.UserHandle,
+	}
+
+	user, err := h.webAuthnUseCase.FinishLogin(c.Request.Context(), userID, input)
+	if errors.Is(err, usecases.ErrWebAuthnVerification) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if mfaClaims != nil && security.IssuedBefore(mfaClaims, user.TokensRevokedAt) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired mfa token"})
+		return
+	}
+
+	h.issueAccessToken(c, user, amr, cookie)
+}
+
+// completeFirstFactor issues an access token, or an MFA challenge when the
+// user has a second factor. amr and the choice of a cookie session are
+// carried over to the token issued once the challenge is completed.
+func (h *AuthHandler) completeFirstFactor(c *gin.Context, user *domain.User, amr []string, cookie bool) {
+	methods, err := h.secondFactors(c.Request.Context(), user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(methods) > 0 {
+		mfaToken, err := mfaChallenge(user, amr, cookie)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "could not generate token"})
+			return
+		}
+
+		c.JSON(http.StatusAccepted, dtos.MFAChallengeOutputDTO{
+			MFARequired: true,
+			MFAToken:    mfaToken,
+			Methods:     methods,
+		})
+		return
+	}
+
+	h.issueAccessToken(c, user, amr, cookie)
+}
+
+// secondFactors returns the methods the user can complete an MFA challenge
+// with, if any.
+func (h *AuthHandler) secondFactors(ctx context.Context, user *domain.User) ([]string, error) {
+	var methods []string
+	if h.mfaUseCase.RequiresMFA(user) {
+		methods = append(methods, "totp", "recovery_code")
+	}
+	if h.smsUseCase.HasSMS(user) {
+		methods = append(methods, "sms")
+	}
+	hasPasskeys, err := h.webAuthnUseCase.HasCredentials(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if hasPasskeys {
+		methods = append(methods, "webauthn")
+	}
+	return methods, nil
+}
+
+// mfaChallenge signs the token that stands for a completed first factor
+// until the second one is.
+func mfaChallenge(user *domain.User, amr []string, cookie bool) (string, error) {
+	return security.SignToken(security.TokenUseMFAChallenge, jwt.MapClaims{
+		"sub":    user.ID.Hex(),
+		"amr":    amr,
+		"cookie": cookie,
+	}, mfaChallengeTTL)
+}
+
+// checkLoginThrottle answers 429 and reports false while the account or
+// client IP has to wait before trying again.
+func (h *AuthHandler) checkLoginThrottle(c *gin.Context, email string) bool {
+	err := h.lockoutUseCase.Check(c.Request.Context(), email, c.ClientIP())
+	var throttled *usecases.LoginThrottledError
+	if errors.As(err, &throttled) {
+		writeLoginThrottled(c, throttled)
+		return false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	return true
+}
+
+func writeLoginThrottled(c *gin.Context, throttled *usecases.LoginThrottledError) {
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+	c.JSON(http.StatusTooManyRequests, gin.H{"error": throttled.Error()})
+}
+
+func (h *AuthHandler) recordLoginFailure(c *gin.Context, email string) {
+	if err := h.lockoutUseCase.RecordFailure(c.Request.Context(), email, c.ClientIP()); err != nil {
+		log.Printf("failed to record login failure: %v", err)
+	}
+}
+
+// firstFactor returns the methods recorded in an MFA challenge token.
+func firstFactor(claims jwt.MapClaims) []string {
+	values, _ := claims["amr"].([]interface{})
+	amr := make([]string, 0, len(values)+2)
+	for _, value := range values {
+		if method, ok := value.(string); ok {
+			amr = append(amr, method)
+		}
+	}
+	return amr
+}
+
+// issueAccessToken writes an access token for user to the response, recording
+// how they authenticated in the amr claim. With cookie set, the token goes in
+// the session cookie and the response carries its CSRF token instead.
+func (h *AuthHandler) issueAccessToken(c *gin.Context, user *domain.User, amr []string, cookie bool) {
+	if err := h.checkSignInAllowed(user); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	// A DPoP proof on the login request binds the token to the client's key,
+	// and a verified TLS client certificate binds it to that certificate.
+	// Cookies are sent by the browser without a proof, so the two do not mix,
+	// and cookie logins are the only ones allowed without a proof when DPoP
+	// is required.
+	tokenType := "Bearer"
+	cnf := jwt.MapClaims{}
+	if cookie && c.GetHeader(security.DPoPHeader) != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "DPoP cannot be used with a session cookie"})
+		return
+	}
+	if h.requireDPoP && !cookie && c.GetHeader(security.DPoPHeader) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "a DPoP proof is required"})
+		return
+	}
+	if c.GetHeader(security.DPoPHeader) != "" {
+		jkt, err := security.VerifyDPoPProof(c.Request, "")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid DPoP proof"})
+			return
+		}
+		cnf["jkt"] = jkt
+		tokenType = "DPoP"
+	}
+	if cert := security.VerifiedClientCertificate(c.Request); cert != nil {
+		cnf[security.CertificateThumbprintClaim] = security.CertificateThumbprint(cert)
+	}
+
+	token, err := h.startSession(c.Request.Context(), user, amr, cnf)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if cookie {
+		csrfToken := security.CSRFToken(token.sessionID)
+		middleware.SetSessionCookies(c, h.cookies, token.value, csrfToken, token.ttl)
+		c.JSON(http.StatusOK, gin.H{"tokenType": "Cookie", "csrfToken": csrfToken})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"token": token.value, "tokenType": tokenType})
+}
+
+// checkSignInAllowed rejects users whose account status or unverified email
+// address keeps them from signing in.
+func (h *AuthHandler) checkSignInAllowed(user *domain.User) error {
+	if err := h.userUseCase.CheckAccountStatus(user); err != nil {
+		return err
+	}
+	return h.emailUseCase.CheckLoginAllowed(user)
+}
+
+// accessToken is a signed access token and the session it belongs to.
+type accessToken struct {
+	value     string
+	sessionID string
+	ttl       time.Duration
+}
+
+// startSession starts a session for user and signs an access token for it,
+// bound to cnf when it is not empty. Each login starts a session; the token
+// ends with it at the latest. The tenant claim names the user's organization,
+// which requests made with the token are scoped to.
+func (h *AuthHandler) startSession(ctx context.Context, user *domain.User, amr []string, cnf jwt.MapClaims) (*accessToken, error) {
+	claims := jwt.MapClaims{
+		"sub":    user.ID.Hex(),
+		"email":  user.Email,
+		"amr":    amr,
+		"tenant": user.TenantID.Hex(),
+	}
+	if len(cnf) > 0 {
+		claims["cnf"] = cnf
+	}
+
+	session, err := h.sessionUseCase.CreateSession(ctx, user, amr)
+	if err != nil {
+		return nil, err
+	}
+	claims["sid"] = session.ID.Hex()
+
+	ttl := min(accessTokenTTL, time.Until(session.ExpiresAt))
+	value, err := security.SignToken(security.TokenUseAccess, claims, ttl)
+	if err != nil {
+		return nil, errors.New("could not generate token")
+	}
+	h.userUseCase.RecordLogin(ctx, user, amr)
+
+	return &accessToken{value: value, sessionID: session.ID.Hex(), ttl: ttl}, nil
 }
 
 // SignUp godoc
@@ -66,6 +521,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 // @Produce  json
 // @Param user body dtos.CreateUserInputDTO true "Create User"
 // @Success 201 {object} dtos.CreateUserOutputDTO
+// @Failure 409 {object} object{error=string}
 // @Router /auth/signup [post]
 func (h *AuthHandler) SignUp(c *gin.Context) {
 	var userInput dtos.CreateUserInputDTO
@@ -81,6 +537,14 @@ func (h *AuthHandler) SignUp(c *gin.Context) {
 	}
 
 	userID, err := h.userUseCase.CreateUser(c.Request.Context(), input)
+	if errors.Is(err, security.ErrPasswordPolicy) {
+		c.JSON(http.StatusBadRequest, passwordPolicyResponse(err))
+		return
+	}
+	if errors.Is(err, usecases.ErrEmailTaken) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -92,6 +556,12 @@ func (h *AuthHandler) SignUp(c *gin.Context) {
 		return
 	}
 
+	// The account exists at this point; a failed delivery can be retried
+	// through /auth/verify-email/resend.
+	if err := h.emailUseCase.SendVerification(c.Request.Context(), createdUser); err != nil {
+		log.Printf("failed to send verification email to user %s: %v", createdUser.ID.Hex(), err)
+	}
+
 	output := dtos.CreateUserOutputDTO{
 		ID:    createdUser.ID.Hex(),
 		Name:  createdUser.Username,
@@ -99,4 +569,288 @@ func (h *AuthHandler) SignUp(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusCreated, output)
+}
+
+// VerifyEmail godoc
+// @Summary Verify an email address
+// @Description Confirm the email address of a user with the token from the verification email. The token is read from the query string or the JSON body.
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param token query string false "Verification token"
+// @Param input body dtos.VerifyEmailInputDTO false "Verify Email"
+// @Success 200 {object} object{message=string}
+// @Router /auth/verify-email [get]
+// @Router /auth/verify-email [post]
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var verifyInput dtos.VerifyEmailInputDTO
+	var err error
+	if c.Request.Method == http.MethodGet {
+		err = c.ShouldBindQuery(&verifyInput)
+	} else {
+		err = c.ShouldBindJSON(&verifyInput)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	_, err = h.emailUseCase.VerifyEmail(c.Request.Context(), verifyInput.Token)
+	if errors.Is(err, usecases.ErrInvalidVerificationToken) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Email verified successfully"})
+}
+
+// ResendVerification godoc
+// @Summary Resend the verification email
+// @Description Send a new verification email to an unverified account. The response is the same whether or not the address is registered, and repeated requests are throttled.
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param input body dtos.ResendVerificationInputDTO true "Resend Verification"
+// @Success 202 {object} object{message=string}
+// @Router /auth/verify-email/resend [post]
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	var resendInput dtos.ResendVerificationInputDTO
+	if err := c.ShouldBindJSON(&resendInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.emailUseCase.ResendVerification(c.Request.Context(), resendInput.Email); err != nil {
+		log.Printf("failed to resend verification email: %v", err)
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "If the address needs verification, an email has been sent"})
+}
+
+// ForgotPassword godoc
+// @Summary Request a password reset
+// @Description Email a single-use password reset link. The response is the same whether or not the address is registered.
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param input body dtos.ForgotPasswordInputDTO true "Forgot Password"
+// @Success 202 {object} object{message=string}
+// @Router /auth/password/forgot [post]
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var forgotInput dtos.ForgotPasswordInputDTO
+	if err := c.ShouldBindJSON(&forgotInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.resetUseCase.RequestReset(c.Request.Context(), forgotInput.Email); err != nil {
+		log.Printf("failed to send password reset email: %v", err)
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "If the address is registered, a reset link has been sent"})
+}
+
+// ResetPassword godoc
+// @Summary Reset a password
+// @Description Set a new password with the token from the reset email. All existing tokens of the user are revoked.
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param input body dtos.ResetPasswordInputDTO true "Reset Password"
+// @Success 200 {object} object{message=string}
+// @Router /auth/password/reset [post]
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var resetInput dtos.ResetPasswordInputDTO
+	if err := c.ShouldBindJSON(&resetInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.resetUseCase.ResetPassword(c.Request.Context(), resetInput.Token, resetInput.Password)
+	if errors.Is(err, security.ErrPasswordPolicy) || errors.Is(err, usecases.ErrInvalidResetToken) {
+		c.JSON(http.StatusBadRequest, passwordPolicyResponse(err))
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password reset successfully"})
+}
+
+// StartMagicLink godoc
+// @Summary Request a magic link
+// @Description Email a single-use sign-in link. The link only works together with the returned request ID, which is also set as a cookie for the requesting browser. The response is the same whether or not the address is registered.
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param input body dtos.PasswordlessStartInputDTO true "Start Magic Link"
+// @Success 202 {object} dtos.PasswordlessStartOutputDTO
+// @Failure 429 {object} object{error=string} "Too many sign-in emails sent to the address"
+// @Router /auth/magic-link [post]
+func (h *AuthHandler) StartMagicLink(c *gin.Context) {
+	var startInput dtos.PasswordlessStartInputDTO
+	if err := c.ShouldBindJSON(&startInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	request, err := h.loginUseCase.StartMagicLink(c.Request.Context(), startInput.Email)
+	if errors.Is(err, usecases.ErrPasswordlessRateLimited) {
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.writeLoginRequest(c, request)
+}
+
+// VerifyMagicLink godoc
+// @Summary Sign in with a magic link
+// @Description Exchange the token from a magic link for an access token. Users with a second factor receive an MFA challenge instead.
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param token query string false "Link token"
+// @Param requestId query string false "Request ID, when the cookie is not available"
+// @Param input body dtos.MagicLinkVerifyInputDTO false "Verify Magic Link"
+// @Param DPoP header string false "DPoP proof binding the issued token to the client key"
+// @Success 200 {object} object{token=string,tokenType=string,csrfToken=string} "With cookie set, the token is stored in the session cookie and only tokenType and csrfToken are returned"
+// @Success 202 {object} dtos.MFAChallengeOutputDTO
+// @Failure 429 {object} object{error=string} "Too many failed attempts, see Retry-After"
+// @Router /auth/magic-link/verify [get]
+// @Router /auth/magic-link/verify [post]
+func (h *AuthHandler) VerifyMagicLink(c *gin.Context) {
+	var verifyInput dtos.MagicLinkVerifyInputDTO
+	var err error
+	if c.Request.Method == http.MethodGet {
+		err = c.ShouldBindQuery(&verifyInput)
+	} else {
+		err = c.ShouldBindJSON(&verifyInput)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.loginUseCase.VerifyMagicLink(c.Request.Context(), loginRequestID(c, verifyInput.RequestID), verifyInput.Token)
+	if errors.Is(err, usecases.ErrInvalidLoginCode) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	var throttled *usecases.LoginThrottledError
+	if errors.As(err, &throttled) {
+		writeLoginThrottled(c, throttled)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	clearLoginRequest(c)
+	h.completeFirstFactor(c, user, []string{"email"}, verifyInput.Cookie)
+}
+
+// StartEmailOTP godoc
+// @Summary Request an email sign-in code
+// @Description Email a six-digit sign-in code. The code only works together with the returned request ID, which is also set as a cookie for the requesting browser. The response is the same whether or not the address is registered.
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param input body dtos.PasswordlessStartInputDTO true "Start Email OTP"
+// @Success 202 {object} dtos.PasswordlessStartOutputDTO
+// @Failure 429 {object} object{error=string} "Too many sign-in emails sent to the address"
+// @Router /auth/otp [post]
+func (h *AuthHandler) StartEmailOTP(c *gin.Context) {
+	var startInput dtos.PasswordlessStartInputDTO
+	if err := c.ShouldBindJSON(&startInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	request, err := h.loginUseCase.StartEmailOTP(c.Request.Context(), startInput.Email)
+	if errors.Is(err, usecases.ErrPasswordlessRateLimited) {
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.writeLoginRequest(c, request)
+}
+
+// VerifyEmailOTP godoc
+// @Summary Sign in with an email code
+// @Description Exchange a code from a sign-in email for an access token. A code is rejected after five wrong attempts, and wrong codes count towards the lockout of the account. Users with a second factor receive an MFA challenge instead.
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param input body dtos.EmailOTPVerifyInputDTO true "Verify Email OTP"
+// @Param DPoP header string false "DPoP proof binding the issued token to the client key"
+// @Success 200 {object} object{token=string,tokenType=string,csrfToken=string} "With cookie set, the token is stored in the session cookie and only tokenType and csrfToken are returned"
+// @Success 202 {object} dtos.MFAChallengeOutputDTO
+// @Failure 429 {object} object{error=string} "Too many failed attempts, see Retry-After"
+// @Router /auth/otp/verify [post]
+func (h *AuthHandler) VerifyEmailOTP(c *gin.Context) {
+	var verifyInput dtos.EmailOTPVerifyInputDTO
+	if err := c.ShouldBindJSON(&verifyInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.loginUseCase.VerifyEmailOTP(c.Request.Context(), loginRequestID(c, verifyInput.RequestID), verifyInput.Code)
+	if errors.Is(err, usecases.ErrInvalidLoginCode) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+	var throttled *usecases.LoginThrottledError
+	if errors.As(err, &throttled) {
+		writeLoginThrottled(c, throttled)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	clearLoginRequest(c)
+	h.completeFirstFactor(c, user, []string{"email", "otp"}, verifyInput.Cookie)
+}
+
+func (h *AuthHandler) writeLoginRequest(c *gin.Context, request *usecases.PasswordlessRequest) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(loginRequestCookie, request.RequestID, int(request.ExpiresIn.Seconds()), "/auth", "", isSecureRequest(c), true)
+	c.JSON(http.StatusAccepted, dtos.PasswordlessStartOutputDTO{
+		RequestID: request.RequestID,
+		ExpiresIn: int(request.ExpiresIn.Seconds()),
+	})
+}
+
+// loginRequestID prefers the request ID sent by the client over the cookie.
+func loginRequestID(c *gin.Context, requestID string) string {
+	if requestID != "" {
+		return requestID
+	}
+	cookie, _ := c.Cookie(loginRequestCookie)
+	return cookie
+}
+
+func clearLoginRequest(c *gin.Context) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(loginRequestCookie, "", -1, "/auth", "", isSecureRequest(c), true)
+}
+
+func isSecureRequest(c *gin.Context) bool {
+	return c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
 }
\ No newline at end of file
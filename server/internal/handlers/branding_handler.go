@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"veritas/core/domain"
+	"veritas/core/usecases"
+	"veritas/internal/ports/dtos"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BrandingHandler handles the branding of the hosted pages.
+type BrandingHandler struct {
+	brandingUseCase     usecases.BrandingUsecase
+	organizationUseCase usecases.OrganizationUsecase
+}
+
+// NewBrandingHandler creates a new BrandingHandler with the given BrandingUsecase.
+func NewBrandingHandler(brandingUsecase usecases.BrandingUsecase, organizationUsecase usecases.OrganizationUsecase) *BrandingHandler {
+	return &BrandingHandler{
+		brandingUseCase:     brandingUsecase,
+		organizationUseCase: organizationUsecase,
+	}
+}
+
+// GetBranding godoc
+// @Summary Get the branding of the hosted pages
+// @Description Get the product name, logo, colors and copy overrides the hosted pages of the user's organization are rendered with
+// @Tags branding
+// @Produce  json
+// @Success 200 {object} dtos.BrandingOutputDTO
+// @Security ApiKeyAuth
+// @Router /branding [get]
+func (h *BrandingHandler) GetBranding(c *gin.Context) {
+	organization, err := h.organizationUseCase.CurrentOrganization(c.Request.Context())
+	if err != nil {
+		organizationError(c, err)
+		return
+	}
+
+	branding, err := h.brandingUseCase.GetBranding(c.Request.Context(), organization.BrandingID())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, brandingOutput(branding))
+}
+
+// UpdateBranding godoc
+// @Summary Update the branding of the hosted pages
+// @Description Replace the product name, logo, colors and copy overrides of the hosted pages of the user's organization, for its admins. Colors are hex values, the logo an https URL or a path on this server, and copy keys look like login.title. Fields left empty fall back to the default organization's branding.
+// @Tags branding
+// @Accept  json
+// @Produce  json
+// @Param input body dtos.UpdateBrandingInputDTO true "Branding"
+// @Success 200 {object} dtos.BrandingOutputDTO
+// @Failure 400 {object} object{error=string}
+// @Failure 403 {object} object{error=string}
+// @Security ApiKeyAuth
+// @Router /branding [put]
+func (h *BrandingHandler) UpdateBranding(c *gin.Context) {
+	var brandingInput dtos.UpdateBrandingInputDTO
+	if err := c.ShouldBindJSON(&brandingInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	input := usecases.UpdateBrandingInput{
+		ProductName:     brandingInput.ProductName,
+		LogoURL:         brandingInput.LogoURL,
+		PrimaryColor:    brandingInput.PrimaryColor,
+		BackgroundColor: brandingInput.BackgroundColor,
+		TextColor:       brandingInput.TextColor,
+		Copy:            brandingInput.Copy,
+	}
+
+	organization, err := h.organizationUseCase.CurrentOrganization(c.Request.Context())
+	if err != nil {
+		organizationError(c, err)
+		return
+	}
+
+	branding, err := h.brandingUseCase.UpdateBranding(c.Request.Context(), organization.BrandingID(), input)
+	if errors.Is(err, usecases.ErrInvalidBranding) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, brandingOutput(branding))
+}
+
+func brandingOutput(branding *domain.Branding) dtos.BrandingOutputDTO {
+	return dtos.BrandingOutputDTO{
+		ProductName:     branding.ProductName,
+		LogoURL:         branding.LogoURL,
+		PrimaryColor:    branding.PrimaryColor,
+		BackgroundColor: branding.BackgroundColor,
+		TextColor:       branding.TextColor,
+		Copy:            branding.Copy,
+		UpdatedAt:       branding.UpdatedAt,
+	}
+}
@@ -28,6 +28,7 @@ func NewClaimHandler(claimUsecase usecases.ClaimUsecase) *ClaimHandler {
 // @Produce  json
 // @Param claim body dtos.CreateClaimInputDTO true "Create Claim"
 // @Success 201 {object} dtos.CreateClaimOutputDTO
+// @Failure 403 {object} object{error=string}
 // @Security ApiKeyAuth
 // @Router /claims [post]
 func (h *ClaimHandler) CreateClaim(c *gin.Context) {
@@ -85,6 +86,7 @@ func (h *ClaimHandler) GetClaim(c *gin.Context) {
 // @Param id path string true "Claim ID"
 // @Param claim body dtos.UpdateClaimInputDTO true "Update Claim"
 // @Success 200 {object} dtos.UpdateClaimOutputDTO
+// @Failure 403 {object} object{error=string}
 // @Security ApiKeyAuth
 // @Router /claims/{id} [put]
 func (h *ClaimHandler) UpdateClaim(c *gin.Context) {
@@ -120,6 +122,7 @@ func (h *ClaimHandler) UpdateClaim(c *gin.Context) {
 // @Tags claims
 // @Param id path string true "Claim ID"
 // @Success 200 {object} object{message=string}
+// @Failure 403 {object} object{error=string}
 // @Security ApiKeyAuth
 // @Router /claims/{id} [delete]
 func (h *ClaimHandler) DeleteClaim(c *gin.Context) {
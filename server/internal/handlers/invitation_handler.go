@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"veritas/core/usecases"
+	"veritas/internal/middleware"
+	"veritas/internal/ports/dtos"
+	"veritas/internal/security"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InvitationHandler handles invitation-related HTTP requests.
+type InvitationHandler struct {
+	invitationUseCase usecases.InvitationUsecase
+}
+
+// NewInvitationHandler creates a new InvitationHandler with the given InvitationUsecase.
+func NewInvitationHandler(invitationUsecase usecases.InvitationUsecase) *InvitationHandler {
+	return &InvitationHandler{
+		invitationUseCase: invitationUsecase,
+	}
+}
+
+// CreateInvitation godoc
+// @Summary Invite a user to an organization
+// @Description Email a single-use invitation link to join the organization with the given roles of it, and as one of its admins when admin is set. Inviting an address again replaces its pending invitation.
+// @Tags organizations
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Organization ID"
+// @Param invitation body dtos.CreateInvitationInputDTO true "Create Invitation"
+// @Success 201 {object} domain.Invitation
+// @Failure 400 {object} object{error=string}
+// @Failure 403 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Security ApiKeyAuth
+// @Router /orgs/{id}/invitations [post]
+func (h *InvitationHandler) CreateInvitation(c *gin.Context) {
+	var invitationInput dtos.CreateInvitationInputDTO
+	if err := c.ShouldBindJSON(&invitationInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	input := usecases.CreateInvitationInput{
+		Email:   invitationInput.Email,
+		RoleIDs: invitationInput.RoleIDs,
+		Admin:   invitationInput.Admin,
+	}
+
+	invitation, err := h.invitationUseCase.Invite(c.Request.Context(), c.GetString(middleware.UserIDKey), c.Param("id"), input)
+	if err != nil {
+		invitationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, invitation)
+}
+
+// GetInvitations godoc
+// @Summary Get pending invitations
+// @Description Get the invitations of the organization that have not been accepted, revoked or expired
+// @Tags organizations
+// @Produce  json
+// @Param id path string true "Organization ID"
+// @Success 200 {array} domain.Invitation
+// @Failure 403 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Security ApiKeyAuth
+// @Router /orgs/{id}/invitations [get]
+func (h *InvitationHandler) GetInvitations(c *gin.Context) {
+	invitations, err := h.invitationUseCase.ListInvitations(c.Request.Context(), c.GetString(middleware.UserIDKey), c.Param("id"))
+	if err != nil {
+		invitationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+// RevokeInvitation godoc
+// @Summary Revoke an invitation
+// @Description Delete a pending invitation, so its link stops working
+// @Tags organizations
+// @Param id path string true "Organization ID"
+// @Param invitationId path string true "Invitation ID"
+// @Success 204
+// @Failure 403 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Security ApiKeyAuth
+// @Router /orgs/{id}/invitations/{invitationId} [delete]
+func (h *InvitationHandler) RevokeInvitation(c *gin.Context) {
+	err := h.invitationUseCase.RevokeInvitation(c.Request.Context(), c.GetString(middleware.UserIDKey), c.Param("id"), c.Param("invitationId"))
+	if err != nil {
+		invitationError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ReadInvitation godoc
+// @Summary Get an invitation
+// @Description Get the organization and address of the invitation from an invitation link, and whether accepting it links an existing account
+// @Tags auth
+// @Produce  json
+// @Param token query string true "Invitation token"
+// @Success 200 {object} dtos.InvitationOutputDTO
+// @Failure 400 {object} object{error=string}
+// @Router /auth/invitations/accept [get]
+func (h *InvitationHandler) ReadInvitation(c *gin.Context) {
+	var readInput dtos.ReadInvitationInputDTO
+	if err := c.ShouldBindQuery(&readInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	details, err := h.invitationUseCase.ReadInvitation(c.Request.Context(), readInput.Token)
+	if err != nil {
+		invitationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.InvitationOutputDTO{
+		OrganizationID:   details.Organization.ID.Hex(),
+		OrganizationName: details.Organization.Name,
+		Email:            details.Invitation.Email,
+		HasAccount:       details.HasAccount,
+		ExpiresAt:        details.Invitation.ExpiresAt,
+	})
+}
+
+// AcceptInvitation godoc
+// @Summary Accept an invitation
+// @Description Join the organization with the token from an invitation link. An existing account with the invited, verified address gets the roles of the invitation; otherwise an account is created with the name and password. An existing account whose address was never verified is taken over instead: the password replaces its own, and its sessions, second factors and passkeys are removed. The address then counts as verified.
+// @Tags auth
+// @Accept  json
+// @Produce  json
+// @Param input body dtos.AcceptInvitationInputDTO true "Accept Invitation"
+// @Success 200 {object} dtos.AcceptInvitationOutputDTO
+// @Success 201 {object} dtos.AcceptInvitationOutputDTO
+// @Failure 400 {object} object{error=string}
+// @Router /auth/invitations/accept [post]
+func (h *InvitationHandler) AcceptInvitation(c *gin.Context) {
+	var acceptInput dtos.AcceptInvitationInputDTO
+	if err := c.ShouldBindJSON(&acceptInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	input := usecases.AcceptInvitationInput{
+		Token:    acceptInput.Token,
+		Name:     acceptInput.Name,
+		Password: acceptInput.Password,
+	}
+
+	user, created, err := h.invitationUseCase.AcceptInvitation(c.Request.Context(), input)
+	if errors.Is(err, security.ErrPasswordPolicy) {
+		c.JSON(http.StatusBadRequest, passwordPolicyResponse(err))
+		return
+	}
+	if err != nil {
+		invitationError(c, err)
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	c.JSON(status, dtos.AcceptInvitationOutputDTO{
+		ID:      user.ID.Hex(),
+		Name:    user.Username,
+		Email:   user.Email,
+		Created: created,
+	})
+}
+
+// invitationError responds with the status matching an error of the
+// InvitationUsecase, or of the OrganizationUsecase it checks access with.
+func invitationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrInvalidInvitation), errors.Is(err, usecases.ErrInvalidInvitationToken), errors.Is(err, usecases.ErrInvitationNameRequired):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, usecases.ErrInvitationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		organizationError(c, err)
+	}
+}
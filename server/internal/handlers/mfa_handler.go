@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"veritas/core/usecases"
+	"veritas/internal/middleware"
+	"veritas/internal/ports/dtos"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skip2/go-qrcode"
+)
+
+// MFAHandler handles multi-factor enrollment and administration requests.
+type MFAHandler struct {
+	mfaUseCase      usecases.MFAUsecase
+	webAuthnUseCase usecases.WebAuthnUsecase
+	smsUseCase      usecases.SMSUsecase
+}
+
+// NewMFAHandler creates a new MFAHandler with the given MFAUseCase, WebAuthnUseCase and SMSUseCase.
+func NewMFAHandler(mfaUsecase usecases.MFAUsecase, webAuthnUsecase usecases.WebAuthnUsecase, smsUsecase usecases.SMSUsecase) *MFAHandler {
+	return &MFAHandler{
+		mfaUseCase:      mfaUsecase,
+		webAuthnUseCase: webAuthnUsecase,
+		smsUseCase:      smsUsecase,
+	}
+}
+
+// EnrollTOTP godoc
+// @Summary Start TOTP enrollment
+// @Description Generate a TOTP secret for the current user. It becomes active once confirmed with a first code.
+// @Tags mfa
+// @Produce  json
+// @Success 200 {object} dtos.TOTPEnrollmentOutputDTO
+// @Security ApiKeyAuth
+// @Router /me/mfa/totp [post]
+func (h *MFAHandler) EnrollTOTP(c *gin.Context) {
+	enrollment, err := h.mfaUseCase.EnrollTOTP(c.Request.Context(), c.GetString(middleware.UserIDKey))
+	if errors.Is(err, usecases.ErrTOTPAlreadyEnabled) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	png, err := qrcode.Encode(enrollment.URI, qrcode.Medium, 256)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not generate qr code"})
+		return
+	}
+
+	output := dtos.TOTPEnrollmentOutputDTO{
+		Secret: enrollment.Secret,
+		URI:    enrollment.URI,
+		QRCode: "data:image/png;base64," + base64.StdEncoding.EncodeToString(png),
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+// ConfirmTOTP godoc
+// @Summary Confirm TOTP enrollment
+// @Description Enable TOTP with a first code from the authenticator and receive single-use recovery codes
+// @Tags mfa
+// @Accept  json
+// @Produce  json
+// @Param input body dtos.ConfirmTOTPInputDTO true "Confirm TOTP"
+// @Success 200 {object} dtos.RecoveryCodesOutputDTO
+// @Security ApiKeyAuth
+// @Router /me/mfa/totp/confirm [post]
+func (h *MFAHandler) ConfirmTOTP(c *gin.Context) {
+	var confirmInput dtos.ConfirmTOTPInputDTO
+	if err := c.ShouldBindJSON(&confirmInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	codes, err := h.mfaUseCase.ConfirmTOTP(c.Request.Context(), c.GetString(middleware.UserIDKey), confirmInput.Code)
+	switch {
+	case errors.Is(err, usecases.ErrInvalidMFACode):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, usecases.ErrTOTPAlreadyEnabled), errors.Is(err, usecases.ErrTOTPNotEnrolled):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.RecoveryCodesOutputDTO{RecoveryCodes: codes})
+}
+
+// ResetMFA godoc
+// @Summary Reset a user's MFA
+// @Description Remove every second factor, passkey and recovery code from a user. A verified phone number must be verified again before it can receive codes.
+// @Tags mfa
+// @Param id path string true "User ID"
+// @Success 200 {object} object{message=string}
+// @Failure 403 {object} object{error=string}
+// @Security ApiKeyAuth
+// @Router /users/{id}/mfa [delete]
+func (h *MFAHandler) ResetMFA(c *gin.Context) {
+	id := c.Param("id")
+
+	err := h.mfaUseCase.ResetMFA(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.webAuthnUseCase.DeleteAllCredentials(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "MFA reset successfully"})
+}
+
+// SetPhone godoc
+// @Summary Set the phone number
+// @Description Store a phone number in E.164 format for the current user and text it a verification code. The number is unverified until confirmed.
+// @Tags mfa
+// @Accept  json
+// @Produce  json
+// @Param input body dtos.SetPhoneInputDTO true "Set Phone"
+// @Success 202 {object} object{message=string}
+// @Security ApiKeyAuth
+// @Router /me/phone [put]
+func (h *MFAHandler) SetPhone(c *gin.Context) {
+	var phoneInput dtos.SetPhoneInputDTO
+	if err := c.ShouldBindJSON(&phoneInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.smsUseCase.SetPhoneNumber(c.Request.Context(), c.GetString(middleware.UserIDKey), phoneInput.PhoneNumber)
+	switch {
+	case errors.Is(err, usecases.ErrInvalidPhoneNumber):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, usecases.ErrSMSRateLimited):
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "Verification code sent"})
+}
+
+// VerifyPhone godoc
+// @Summary Verify the phone number
+// @Description Confirm the current user's phone number with the code texted to it. A verified number can receive second-factor codes at login.
+// @Tags mfa
+// @Accept  json
+// @Produce  json
+// @Param input body dtos.VerifyPhoneInputDTO true "Verify Phone"
+// @Success 200 {object} object{message=string}
+// @Security ApiKeyAuth
+// @Router /me/phone/verify [post]
+func (h *MFAHandler) VerifyPhone(c *gin.Context) {
+	var verifyInput dtos.VerifyPhoneInputDTO
+	if err := c.ShouldBindJSON(&verifyInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.smsUseCase.VerifyPhone(c.Request.Context(), c.GetString(middleware.UserIDKey), verifyInput.Code)
+	if errors.Is(err, usecases.ErrInvalidMFACode) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Phone number verified successfully"})
+}
+
+// RemovePhone godoc
+// @Summary Remove the phone number
+// @Description Remove the current user's phone number, and with it SMS as a second factor
+// @Tags mfa
+// @Success 200 {object} object{message=string}
+// @Security ApiKeyAuth
+// @Router /me/phone [delete]
+func (h *MFAHandler) RemovePhone(c *gin.Context) {
+	err := h.smsUseCase.RemovePhoneNumber(c.Request.Context(), c.GetString(middleware.UserIDKey))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Phone number removed successfully"})
+}
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"veritas/core/usecases"
+	"veritas/internal/middleware"
+	"veritas/internal/ports/dtos"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OrganizationHandler handles organization-related HTTP requests.
+type OrganizationHandler struct {
+	organizationUseCase usecases.OrganizationUsecase
+}
+
+// NewOrganizationHandler creates a new OrganizationHandler with the given OrganizationUsecase.
+func NewOrganizationHandler(organizationUsecase usecases.OrganizationUsecase) *OrganizationHandler {
+	return &OrganizationHandler{
+		organizationUseCase: organizationUsecase,
+	}
+}
+
+// CreateOrganization godoc
+// @Summary Create an organization
+// @Description Create an organization, for admins of the default organization. Domains are the hosts its hosted pages are served on.
+// @Tags organizations
+// @Accept  json
+// @Produce  json
+// @Param organization body dtos.CreateOrganizationInputDTO true "Create Organization"
+// @Success 201 {object} domain.Organization
+// @Failure 400 {object} object{error=string}
+// @Failure 403 {object} object{error=string}
+// @Failure 409 {object} object{error=string}
+// @Security ApiKeyAuth
+// @Router /orgs [post]
+func (h *OrganizationHandler) CreateOrganization(c *gin.Context) {
+	var organizationInput dtos.CreateOrganizationInputDTO
+	if err := c.ShouldBindJSON(&organizationInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	input := usecases.CreateOrganizationInput{
+		Name:    organizationInput.Name,
+		Slug:    organizationInput.Slug,
+		Domains: organizationInput.Domains,
+	}
+
+	organization, err := h.organizationUseCase.CreateOrganization(c.Request.Context(), c.GetString(middleware.UserIDKey), input)
+	if err != nil {
+		organizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, organization)
+}
+
+// GetAllOrganizations godoc
+// @Summary Get all organizations
+// @Description Get every organization, for admins of the default organization
+// @Tags organizations
+// @Produce  json
+// @Success 200 {array} domain.Organization
+// @Failure 403 {object} object{error=string}
+// @Security ApiKeyAuth
+// @Router /orgs [get]
+func (h *OrganizationHandler) GetAllOrganizations(c *gin.Context) {
+	organizations, err := h.organizationUseCase.ListOrganizations(c.Request.Context(), c.GetString(middleware.UserIDKey))
+	if err != nil {
+		organizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, organizations)
+}
+
+// GetCurrentOrganization godoc
+// @Summary Get my organization
+// @Description Get the organization the authenticated user belongs to
+// @Tags organizations
+// @Produce  json
+// @Success 200 {object} domain.Organization
+// @Security ApiKeyAuth
+// @Router /orgs/current [get]
+func (h *OrganizationHandler) GetCurrentOrganization(c *gin.Context) {
+	organization, err := h.organizationUseCase.CurrentOrganization(c.Request.Context())
+	if err != nil {
+		organizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, organization)
+}
+
+// GetOrganization godoc
+// @Summary Get an organization by ID
+// @Description Get an organization, for its admins and admins of the default organization
+// @Tags organizations
+// @Produce  json
+// @Param id path string true "Organization ID"
+// @Success 200 {object} domain.Organization
+// @Failure 403 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Security ApiKeyAuth
+// @Router /orgs/{id} [get]
+func (h *OrganizationHandler) GetOrganization(c *gin.Context) {
+	organization, err := h.organizationUseCase.ReadOrganization(c.Request.Context(), c.GetString(middleware.UserIDKey), c.Param("id"))
+	if err != nil {
+		organizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, organization)
+}
+
+// UpdateOrganization godoc
+// @Summary Update an organization
+// @Description Rename an organization and replace its domains. The slug cannot be changed. Only admins of the default organization can change the domains; other admins must send them unchanged.
+// @Tags organizations
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Organization ID"
+// @Param organization body dtos.UpdateOrganizationInputDTO true "Update Organization"
+// @Success 200 {object} domain.Organization
+// @Failure 400 {object} object{error=string}
+// @Failure 403 {object} object{error=string}
+// @Failure 409 {object} object{error=string}
+// @Security ApiKeyAuth
+// @Router /orgs/{id} [put]
+func (h *OrganizationHandler) UpdateOrganization(c *gin.Context) {
+	var organizationInput dtos.UpdateOrganizationInputDTO
+	if err := c.ShouldBindJSON(&organizationInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	input := usecases.UpdateOrganizationInput{
+		Name:    organizationInput.Name,
+		Domains: organizationInput.Domains,
+	}
+
+	organization, err := h.organizationUseCase.UpdateOrganization(c.Request.Context(), c.GetString(middleware.UserIDKey), c.Param("id"), input)
+	if err != nil {
+		organizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, organization)
+}
+
+// AddOrganizationAdmin godoc
+// @Summary Add an organization admin
+// @Description Make a member of the organization one of its admins
+// @Tags organizations
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Organization ID"
+// @Param admin body dtos.AddOrganizationAdminInputDTO true "Admin"
+// @Success 200 {object} domain.Organization
+// @Failure 400 {object} object{error=string}
+// @Failure 403 {object} object{error=string}
+// @Security ApiKeyAuth
+// @Router /orgs/{id}/admins [post]
+func (h *OrganizationHandler) AddOrganizationAdmin(c *gin.Context) {
+	var adminInput dtos.AddOrganizationAdminInputDTO
+	if err := c.ShouldBindJSON(&adminInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	organization, err := h.organizationUseCase.AddAdmin(c.Request.Context(), c.GetString(middleware.UserIDKey), c.Param("id"), adminInput.UserID)
+	if err != nil {
+		organizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, organization)
+}
+
+// RemoveOrganizationAdmin godoc
+// @Summary Remove an organization admin
+// @Description Take a user's admin rights for the organization away. The last admin cannot be removed.
+// @Tags organizations
+// @Produce  json
+// @Param id path string true "Organization ID"
+// @Param userId path string true "User ID"
+// @Success 200 {object} domain.Organization
+// @Failure 403 {object} object{error=string}
+// @Failure 409 {object} object{error=string}
+// @Security ApiKeyAuth
+// @Router /orgs/{id}/admins/{userId} [delete]
+func (h *OrganizationHandler) RemoveOrganizationAdmin(c *gin.Context) {
+	organization, err := h.organizationUseCase.RemoveAdmin(c.Request.Context(), c.GetString(middleware.UserIDKey), c.Param("id"), c.Param("userId"))
+	if err != nil {
+		organizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, organization)
+}
+
+// organizationError responds with the status matching an error of the
+// OrganizationUsecase.
+func organizationError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, usecases.ErrInvalidOrganization), errors.Is(err, usecases.ErrNotOrganizationMember):
+		status = http.StatusBadRequest
+	case errors.Is(err, usecases.ErrNotOrganizationAdmin):
+		status = http.StatusForbidden
+	case errors.Is(err, usecases.ErrOrganizationNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, usecases.ErrOrganizationExists), errors.Is(err, usecases.ErrLastOrganizationAdmin):
+		status = http.StatusConflict
+	}
+	c.JSON(status, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,725 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"encoding/hex"
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"veritas/core/domain"
+	"veritas/core/usecases"
+	"veritas/internal/middleware"
+	"veritas/internal/ports/dtos"
+	"veritas/internal/security"
+	"veritas/internal/web"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// mfaChallengeCookie holds the MFA challenge of a hosted login between
+	// the password and the second factor.
+	mfaChallengeCookie = "veritas_mfa"
+	mfaChallengePath   = "/ui/auth/login"
+
+	// formCSRFCookie is the double-submit CSRF token of the hosted forms
+	// used before a session exists.
+	formCSRFCookie = "veritas_form"
+
+	loginPagePath   = "/ui/auth/login"
+	accountPagePath = "/ui/account"
+)
+
+// PageHandler serves the hosted login, signup and account pages. Users are
+// signed in with the session cookie, through the same checks as the JSON
+// API.
+type PageHandler struct {
+	auth                *AuthHandler
+	brandingUseCase     usecases.BrandingUsecase
+	organizationUseCase usecases.OrganizationUsecase
+	invitationUseCase   usecases.InvitationUsecase
+	renderer            *web.Renderer
+}
+
+// NewPageHandler creates a new PageHandler that signs users in through
+// authHandler.
+func NewPageHandler(authHandler *AuthHandler, brandingUsecase usecases.BrandingUsecase, organizationUsecase usecases.OrganizationUsecase, invitationUsecase usecases.InvitationUsecase, renderer *web.Renderer) *PageHandler {
+	return &PageHandler{
+		auth:                authHandler,
+		brandingUseCase:     brandingUsecase,
+		organizationUseCase: organizationUsecase,
+		invitationUseCase:   invitationUsecase,
+		renderer:            renderer,
+	}
+}
+
+// LoginPage shows the login form.
+func (h *PageHandler) LoginPage(c *gin.Context) {
+	page, ok := h.newPage(c)
+	if !ok {
+		return
+	}
+	page.ReturnTo = returnTo(c.Query("return_to"))
+	h.render(c, http.StatusOK, "login", page)
+}
+
+// Login checks the email and password, then continues with the second
+// factor or signs the user in.
+func (h *PageHandler) Login(c *gin.Context) {
+	page, ok := h.formPage(c)
+	if !ok {
+		return
+	}
+	page.ReturnTo = returnTo(c.PostForm("return_to"))
+	page.Email = c.PostForm("email")
+
+	if !h.checkLoginThrottle(c, page, "login", page.Email) {
+		return
+	}
+
+	user, err := h.auth.userUseCase.VerifyUser(c.Request.Context(), page.Email, c.PostForm("password"))
+	if errors.Is(err, usecases.ErrAccountInactive) {
+		page.Error = page.T("error.inactive")
+		h.render(c, http.StatusForbidden, "login", page)
+		return
+	}
+	if err != nil {
+		h.auth.recordLoginFailure(c, page.Email)
+		page.Error = page.T("error.credentials")
+		h.render(c, http.StatusUnauthorized, "login", page)
+		return
+	}
+	if err := h.auth.lockoutUseCase.RecordSuccess(c.Request.Context(), user.Email); err != nil {
+		h.fail(c, page, err)
+		return
+	}
+
+	methods, err := h.auth.secondFactors(c.Request.Context(), user)
+	if err != nil {
+		h.fail(c, page, err)
+		return
+	}
+	if len(methods) == 0 {
+		h.signIn(c, page, "login", user, []string{"pwd"})
+		return
+	}
+	if len(pageMethods(methods)) == 0 {
+		page.Error = page.T("error.passkey")
+		h.render(c, http.StatusForbidden, "login", page)
+		return
+	}
+
+	mfaToken, err := mfaChallenge(user, []string{"pwd"}, true)
+	if err != nil {
+		h.fail(c, page, err)
+		return
+	}
+	h.setCookie(c, mfaChallengeCookie, mfaToken, int(mfaChallengeTTL.Seconds()), mfaChallengePath)
+	c.Redirect(http.StatusSeeOther, page.URL(mfaChallengePath+"/mfa?return_to="+url.QueryEscape(page.ReturnTo)))
+}
+
+// MFAPage shows the second-factor form of a login in progress.
+func (h *PageHandler) MFAPage(c *gin.Context) {
+	page, ok := h.newPage(c)
+	if !ok {
+		return
+	}
+	page.ReturnTo = returnTo(c.Query("return_to"))
+
+	user, _, ok := h.mfaUser(c, page)
+	if !ok {
+		return
+	}
+	if !h.loadMethods(c, page, user) {
+		return
+	}
+	h.render(c, http.StatusOK, "mfa", page)
+}
+
+// MFA checks the second factor and signs the user in.
+func (h *PageHandler) MFA(c *gin.Context) {
+	page, ok := h.formPage(c)
+	if !ok {
+		return
+	}
+	page.ReturnTo = returnTo(c.PostForm("return_to"))
+	page.Method = c.PostForm("method")
+
+	user, claims, ok := h.mfaUser(c, page)
+	if !ok {
+		return
+	}
+	if !h.loadMethods(c, page, user) {
+		return
+	}
+	if !h.checkLoginThrottle(c, page, "mfa", user.Email) {
+		return
+	}
+
+	var input dtos.MFALoginInputDTO
+	switch code := c.PostForm("code"); page.Method {
+	case "totp":
+		input.Code = code
+	case "sms":
+		input.SMSCode = code
+	case "recovery_code":
+		input.RecoveryCode = code
+	}
+	factors, err := h.auth.verifySecondFactor(c.Request.Context(), user, input)
+	if errors.Is(err, usecases.ErrInvalidMFACode) || errors.Is(err, errSecondFactorRequired) {
+		h.auth.recordLoginFailure(c, user.Email)
+		page.Error = page.T("error.code")
+		h.render(c, http.StatusUnauthorized, "mfa", page)
+		return
+	}
+	if err != nil {
+		h.fail(c, page, err)
+		return
+	}
+
+	h.setCookie(c, mfaChallengeCookie, "", -1, mfaChallengePath)
+	amr := append(append(firstFactor(claims), "mfa"), factors...)
+	h.signIn(c, page, "mfa", user, amr)
+}
+
+// SendSMSCode texts a second-factor code for a login in progress.
+func (h *PageHandler) SendSMSCode(c *gin.Context) {
+	page, ok := h.formPage(c)
+	if !ok {
+		return
+	}
+	page.ReturnTo = returnTo(c.PostForm("return_to"))
+	page.Method = "sms"
+
+	user, _, ok := h.mfaUser(c, page)
+	if !ok {
+		return
+	}
+	if !h.loadMethods(c, page, user) {
+		return
+	}
+
+	err := h.auth.smsUseCase.SendLoginCode(c.Request.Context(), user)
+	switch {
+	case errors.Is(err, usecases.ErrSMSRateLimited):
+		page.Error = page.T("error.throttled")
+		h.render(c, http.StatusTooManyRequests, "mfa", page)
+		return
+	case err != nil:
+		h.fail(c, page, err)
+		return
+	}
+
+	page.Notice = page.T("mfa.sms_sent")
+	h.render(c, http.StatusOK, "mfa", page)
+}
+
+// SignUpPage shows the signup form.
+func (h *PageHandler) SignUpPage(c *gin.Context) {
+	page, ok := h.newPage(c)
+	if !ok {
+		return
+	}
+	h.render(c, http.StatusOK, "signup", page)
+}
+
+// SignUp creates the account and sends the verification email.
+func (h *PageHandler) SignUp(c *gin.Context) {
+	page, ok := h.formPage(c)
+	if !ok {
+		return
+	}
+	page.Name = c.PostForm("name")
+	page.Email = c.PostForm("email")
+
+	userID, err := h.auth.userUseCase.CreateUser(c.Request.Context(), usecases.CreateUserInput{
+		Name:     page.Name,
+		Email:    page.Email,
+		Password: c.PostForm("password"),
+	})
+	if errors.Is(err, security.ErrPasswordPolicy) {
+		page.Violations = passwordViolations(err)
+		h.render(c, http.StatusBadRequest, "signup", page)
+		return
+	}
+	if errors.Is(err, usecases.ErrEmailTaken) {
+		page.Error = page.T("error.email_taken")
+		h.render(c, http.StatusConflict, "signup", page)
+		return
+	}
+	if err != nil {
+		h.fail(c, page, err)
+		return
+	}
+
+	createdUser, err := h.auth.userUseCase.ReadUser(c.Request.Context(), userID.Hex())
+	if err != nil {
+		h.fail(c, page, err)
+		return
+	}
+	if err := h.auth.emailUseCase.SendVerification(c.Request.Context(), createdUser); err != nil {
+		log.Printf("failed to send verification email to user %s: %v", createdUser.ID.Hex(), err)
+	}
+
+	h.message(c, http.StatusCreated, page, "signup.title", "signup.done")
+}
+
+// ForgotPasswordPage shows the password reset request form.
+func (h *PageHandler) ForgotPasswordPage(c *gin.Context) {
+	page, ok := h.newPage(c)
+	if !ok {
+		return
+	}
+	h.render(c, http.StatusOK, "forgot_password", page)
+}
+
+// ForgotPassword emails a reset link. The answer is the same whether or not
+// the address is registered.
+func (h *PageHandler) ForgotPassword(c *gin.Context) {
+	page, ok := h.formPage(c)
+	if !ok {
+		return
+	}
+
+	if err := h.auth.resetUseCase.RequestReset(c.Request.Context(), c.PostForm("email")); err != nil {
+		log.Printf("failed to send password reset email: %v", err)
+	}
+
+	h.message(c, http.StatusOK, page, "forgot.title", "forgot.done")
+}
+
+// ResetPasswordPage shows the new password form of a reset link.
+func (h *PageHandler) ResetPasswordPage(c *gin.Context) {
+	page, ok := h.newPage(c)
+	if !ok {
+		return
+	}
+	page.Token = c.Query("token")
+	if page.Token == "" {
+		h.message(c, http.StatusBadRequest, page, "reset.title", "reset.invalid")
+		return
+	}
+	h.render(c, http.StatusOK, "reset_password", page)
+}
+
+// ResetPassword sets the new password of a reset link.
+func (h *PageHandler) ResetPassword(c *gin.Context) {
+	page, ok := h.formPage(c)
+	if !ok {
+		return
+	}
+	page.Token = c.PostForm("token")
+
+	password := c.PostForm("password")
+	if password != c.PostForm("password_confirm") {
+		page.Error = page.T("reset.mismatch")
+		h.render(c, http.StatusBadRequest, "reset_password", page)
+		return
+	}
+
+	err := h.auth.resetUseCase.ResetPassword(c.Request.Context(), page.Token, password)
+	if errors.Is(err, usecases.ErrInvalidResetToken) {
+		h.message(c, http.StatusBadRequest, page, "reset.title", "reset.invalid")
+		return
+	}
+	if errors.Is(err, security.ErrPasswordPolicy) {
+		page.Violations = passwordViolations(err)
+		h.render(c, http.StatusBadRequest, "reset_password", page)
+		return
+	}
+	if err != nil {
+		h.fail(c, page, err)
+		return
+	}
+
+	h.message(c, http.StatusOK, page, "reset.title", "reset.done")
+}
+
+// VerifyEmail confirms the email address of a verification link.
+func (h *PageHandler) VerifyEmail(c *gin.Context) {
+	page, ok := h.newPage(c)
+	if !ok {
+		return
+	}
+
+	_, err := h.auth.emailUseCase.VerifyEmail(c.Request.Context(), c.Query("token"))
+	if errors.Is(err, usecases.ErrInvalidVerificationToken) {
+		h.message(c, http.StatusBadRequest, page, "verify.title", "verify.invalid")
+		return
+	}
+	if err != nil {
+		h.fail(c, page, err)
+		return
+	}
+
+	h.message(c, http.StatusOK, page, "verify.title", "verify.done")
+}
+
+// InvitationPage shows the invitation of an invitation link, asking
+// invitees without an account for their name and password.
+func (h *PageHandler) InvitationPage(c *gin.Context) {
+	page, ok := h.newPage(c)
+	if !ok {
+		return
+	}
+	page.Token = c.Query("token")
+	if !h.loadInvitation(c, page) {
+		return
+	}
+	h.render(c, http.StatusOK, "invitation", page)
+}
+
+// AcceptInvitation accepts the invitation of an invitation link.
+func (h *PageHandler) AcceptInvitation(c *gin.Context) {
+	page, ok := h.formPage(c)
+	if !ok {
+		return
+	}
+	page.Token = c.PostForm("token")
+	page.Name = c.PostForm("name")
+	if !h.loadInvitation(c, page) {
+		return
+	}
+
+	password := c.PostForm("password")
+	if !page.HasAccount && password != c.PostForm("password_confirm") {
+		page.Error = page.T("reset.mismatch")
+		h.render(c, http.StatusBadRequest, "invitation", page)
+		return
+	}
+
+	_, _, err := h.invitationUseCase.AcceptInvitation(c.Request.Context(), usecases.AcceptInvitationInput{
+		Token:    page.Token,
+		Name:     page.Name,
+		Password: password,
+	})
+	switch {
+	case errors.Is(err, usecases.ErrInvalidInvitationToken):
+		h.message(c, http.StatusBadRequest, page, "invitation.title", "invitation.invalid")
+		return
+	case errors.Is(err, security.ErrPasswordPolicy), errors.Is(err, usecases.ErrInvitationNameRequired):
+		page.Violations = passwordViolations(err)
+		h.render(c, http.StatusBadRequest, "invitation", page)
+		return
+	case err != nil:
+		h.fail(c, page, err)
+		return
+	}
+
+	h.message(c, http.StatusOK, page, "invitation.title", "invitation.done")
+}
+
+// loadInvitation sets the invitation of the page's token, or answers that
+// the link is invalid.
+func (h *PageHandler) loadInvitation(c *gin.Context, page *web.Page) bool {
+	details, err := h.invitationUseCase.ReadInvitation(c.Request.Context(), page.Token)
+	if errors.Is(err, usecases.ErrInvalidInvitationToken) {
+		h.message(c, http.StatusBadRequest, page, "invitation.title", "invitation.invalid")
+		return false
+	}
+	if err != nil {
+		h.fail(c, page, err)
+		return false
+	}
+	page.Organization = details.Organization.Name
+	page.Email = details.Invitation.Email
+	page.HasAccount = details.HasAccount
+	return true
+}
+
+// RequireLogin sends visitors without a session cookie to the login page,
+// returning them to the requested page afterwards.
+func (h *PageHandler) RequireLogin(c *gin.Context) {
+	if value, err := c.Cookie(h.auth.cookies.Name); err != nil || value == "" {
+		c.Redirect(http.StatusFound, web.TenantURL(loginPagePath+"?return_to="+url.QueryEscape(c.Request.URL.RequestURI()), tenantRef(c)))
+		c.Abort()
+		return
+	}
+	c.Next()
+}
+
+// AccountPage lists the devices the user is signed in on.
+func (h *PageHandler) AccountPage(c *gin.Context) {
+	page, ok := h.newPage(c)
+	if !ok {
+		return
+	}
+
+	userID := c.GetString(middleware.UserIDKey)
+	user, err := h.auth.userUseCase.ReadUser(c.Request.Context(), userID)
+	if err != nil {
+		h.fail(c, page, err)
+		return
+	}
+	sessions, err := h.auth.sessionUseCase.ListSessions(c.Request.Context(), userID)
+	if err != nil {
+		h.fail(c, page, err)
+		return
+	}
+
+	// Forms of the account page are authenticated by the session cookie,
+	// so they carry the session's CSRF token.
+	page.User = user
+	page.Sessions = sessions
+	page.SessionID = c.GetString(middleware.SessionIDKey)
+	page.CSRFToken = security.CSRFToken(page.SessionID)
+	h.render(c, http.StatusOK, "account", page)
+}
+
+// RevokeSession signs out another device of the user.
+func (h *PageHandler) RevokeSession(c *gin.Context) {
+	err := h.auth.sessionUseCase.RevokeSession(c.Request.Context(), c.GetString(middleware.UserIDKey), c.Param("id"))
+	if err != nil && !errors.Is(err, usecases.ErrSessionNotFound) {
+		if page, ok := h.newPage(c); ok {
+			h.fail(c, page, err)
+		}
+		return
+	}
+	c.Redirect(http.StatusSeeOther, accountPagePath)
+}
+
+// Logout ends the current session and clears the session cookies.
+func (h *PageHandler) Logout(c *gin.Context) {
+	err := h.auth.sessionUseCase.RevokeSession(c.Request.Context(), c.GetString(middleware.UserIDKey), c.GetString(middleware.SessionIDKey))
+	if err != nil && !errors.Is(err, usecases.ErrSessionNotFound) {
+		if page, ok := h.newPage(c); ok {
+			h.fail(c, page, err)
+		}
+		return
+	}
+	middleware.ClearSessionCookies(c, h.auth.cookies)
+	c.Redirect(http.StatusSeeOther, web.TenantURL(loginPagePath, tenantRef(c)))
+}
+
+// signIn starts a cookie session for user and returns them to where they
+// were going.
+func (h *PageHandler) signIn(c *gin.Context, page *web.Page, name string, user *domain.User, amr []string) {
+	if err := h.auth.checkSignInAllowed(user); err != nil {
+		page.Error = page.T("error.inactive")
+		if errors.Is(err, usecases.ErrEmailNotVerified) {
+			page.Error = page.T("error.unverified")
+		}
+		h.render(c, http.StatusForbidden, name, page)
+		return
+	}
+
+	cnf := jwt.MapClaims{}
+	if cert := security.VerifiedClientCertificate(c.Request); cert != nil {
+		cnf[security.CertificateThumbprintClaim] = security.CertificateThumbprint(cert)
+	}
+	token, err := h.auth.startSession(c.Request.Context(), user, amr, cnf)
+	if err != nil {
+		h.fail(c, page, err)
+		return
+	}
+
+	middleware.SetSessionCookies(c, h.auth.cookies, token.value, security.CSRFToken(token.sessionID), token.ttl)
+	c.Redirect(http.StatusSeeOther, page.ReturnTo)
+}
+
+// mfaUser returns the user and claims of the MFA challenge cookie, or sends
+// the user back to the login form when it is missing or expired.
+func (h *PageHandler) mfaUser(c *gin.Context, page *web.Page) (*domain.User, jwt.MapClaims, bool) {
+	mfaToken, _ := c.Cookie(mfaChallengeCookie)
+	claims, err := security.ParseToken(mfaToken, security.TokenUseMFAChallenge)
+	if err == nil {
+		sub, _ := claims["sub"].(string)
+		user, err := h.auth.userUseCase.ReadUser(c.Request.Context(), sub)
+		if err == nil && !security.IssuedBefore(claims, user.TokensRevokedAt) {
+			return user, claims, true
+		}
+	}
+
+	page.Error = page.T("error.challenge")
+	h.render(c, http.StatusUnauthorized, "login", page)
+	return nil, nil, false
+}
+
+// loadMethods sets the second factors the MFA page offers to user.
+func (h *PageHandler) loadMethods(c *gin.Context, page *web.Page, user *domain.User) bool {
+	methods, err := h.auth.secondFactors(c.Request.Context(), user)
+	if err != nil {
+		h.fail(c, page, err)
+		return false
+	}
+	page.Methods = pageMethods(methods)
+	return true
+}
+
+// checkLoginThrottle renders the named page with 429 while the account or
+// client IP has to wait before trying again.
+func (h *PageHandler) checkLoginThrottle(c *gin.Context, page *web.Page, name, email string) bool {
+	err := h.auth.lockoutUseCase.Check(c.Request.Context(), email, c.ClientIP())
+	var throttled *usecases.LoginThrottledError
+	if errors.As(err, &throttled) {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+		page.Error = page.T("error.throttled")
+		h.render(c, http.StatusTooManyRequests, name, page)
+		return false
+	}
+	if err != nil {
+		h.fail(c, page, err)
+		return false
+	}
+	return true
+}
+
+// newPage returns a page with the branding of the request's organization
+// and the form CSRF token, or answers 500 when the branding cannot be
+// loaded.
+func (h *PageHandler) newPage(c *gin.Context) (*web.Page, bool) {
+	organization, err := h.organizationUseCase.CurrentOrganization(c.Request.Context())
+	if err != nil {
+		log.Printf("failed to load organization: %v", err)
+		c.String(http.StatusInternalServerError, "could not load page")
+		return nil, false
+	}
+	branding, err := h.brandingUseCase.GetBranding(c.Request.Context(), organization.BrandingID())
+	if err != nil {
+		log.Printf("failed to load branding: %v", err)
+		c.String(http.StatusInternalServerError, "could not load page")
+		return nil, false
+	}
+
+	page := web.NewPage(branding)
+	page.Tenant = tenantRef(c)
+	page.Nonce = c.GetString(middleware.CSPNonceKey)
+	page.CSRFToken, err = h.formCSRFToken(c)
+	if err != nil {
+		h.fail(c, page, err)
+		return nil, false
+	}
+	return page, true
+}
+
+// formPage is newPage for form submissions, which must echo the CSRF token
+// of their cookie.
+func (h *PageHandler) formPage(c *gin.Context) (*web.Page, bool) {
+	page, ok := h.newPage(c)
+	if !ok {
+		return nil, false
+	}
+	if !hmac.Equal([]byte(page.CSRFToken), []byte(c.PostForm(middleware.CSRFFormField))) {
+		h.message(c, http.StatusForbidden, page, "error.title", "error.csrf")
+		return nil, false
+	}
+	return page, true
+}
+
+// formCSRFToken returns the browser's form CSRF token, setting a new one
+// when it has none.
+func (h *PageHandler) formCSRFToken(c *gin.Context) (string, error) {
+	name := h.formCSRFCookieName()
+	if token, err := c.Cookie(name); err == nil && token != "" {
+		return token, nil
+	}
+	b, err := security.RandomBytes(32)
+	if err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(b)
+	h.setCookie(c, name, token, 0, "/")
+	return token, nil
+}
+
+// formCSRFCookieName uses the __Host- prefix when cookies are secure, so a
+// sibling subdomain cannot plant the cookie.
+func (h *PageHandler) formCSRFCookieName() string {
+	if h.auth.cookies.Secure {
+		return "__Host-" + formCSRFCookie
+	}
+	return formCSRFCookie
+}
+
+func (h *PageHandler) setCookie(c *gin.Context, name, value string, maxAge int, path string) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		MaxAge:   maxAge,
+		Secure:   h.auth.cookies.Secure,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
+func (h *PageHandler) message(c *gin.Context, status int, page *web.Page, title, message string) {
+	page.Title = page.T(title)
+	page.Message = page.T(message)
+	h.render(c, status, "message", page)
+}
+
+func (h *PageHandler) fail(c *gin.Context, page *web.Page, err error) {
+	log.Printf("hosted page %s failed: %v", c.Request.URL.Path, err)
+	h.message(c, http.StatusInternalServerError, page, "error.title", "error.generic")
+}
+
+// render writes the named page, buffered so that a template error does not
+// leave a partial page behind.
+func (h *PageHandler) render(c *gin.Context, status int, name string, page *web.Page) {
+	var buf bytes.Buffer
+	if err := h.renderer.Render(&buf, name, page); err != nil {
+		log.Printf("failed to render page %s: %v", name, err)
+		c.String(http.StatusInternalServerError, "could not render page")
+		return
+	}
+	c.Data(status, "text/html; charset=utf-8", buf.Bytes())
+}
+
+// pageMethods returns the second factors the hosted pages support. Passkeys
+// need the WebAuthn browser API, which the pages do not use.
+func pageMethods(methods []string) []string {
+	var supported []string
+	for _, method := range methods {
+		if method != "webauthn" {
+			supported = append(supported, method)
+		}
+	}
+	return supported
+}
+
+func passwordViolations(err error) []string {
+	var policyErr *security.PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		return []string{err.Error()}
+	}
+	messages := make([]string, len(policyErr.Violations))
+	for i, violation := range policyErr.Violations {
+		messages[i] = violation.Message
+	}
+	return messages
+}
+
+// tenantRef returns the organization the links and redirects of a page keep
+// naming: the one the request named, or for signed-in users their own.
+// Pages served on an organization's domain need neither.
+func tenantRef(c *gin.Context) string {
+	if ref := c.Query(middleware.TenantQueryParam); ref != "" {
+		return ref
+	}
+	if c.GetString(middleware.UserIDKey) != "" {
+		if tenantID, ok := domain.TenantFromContext(c.Request.Context()); ok {
+			return tenantID.Hex()
+		}
+	}
+	return ""
+}
+
+// returnTo accepts only paths on this server as the page to return to after
+// login, so the login form cannot be used to redirect elsewhere.
+func returnTo(value string) string {
+	if !strings.HasPrefix(value, "/") || strings.HasPrefix(value, "//") || strings.ContainsAny(value, "\\\t\r\n") {
+		return accountPagePath
+	}
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Scheme != "" || parsed.Host != "" {
+		return accountPagePath
+	}
+	return value
+}
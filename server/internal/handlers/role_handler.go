@@ -28,6 +28,7 @@ func NewRoleHandler(roleUsecase usecases.RoleUsecase) *RoleHandler {
 // @Produce  json
 // @Param role body dtos.CreateRoleInputDTO true "Create Role"
 // @Success 201 {object} dtos.CreateRoleOutputDTO
+// @Failure 403 {object} object{error=string}
 // @Security ApiKeyAuth
 // @Router /roles [post]
 func (h *RoleHandler) CreateRole(c *gin.Context) {
@@ -85,6 +86,7 @@ func (h *RoleHandler) GetRole(c *gin.Context) {
 // @Param id path string true "Role ID"
 // @Param role body dtos.UpdateRoleInputDTO true "Update Role"
 // @Success 200 {object} dtos.UpdateRoleOutputDTO
+// @Failure 403 {object} object{error=string}
 // @Security ApiKeyAuth
 // @Router /roles/{id} [put]
 func (h *RoleHandler) UpdateRole(c *gin.Context) {
@@ -120,6 +122,7 @@ func (h *RoleHandler) UpdateRole(c *gin.Context) {
 // @Tags roles
 // @Param id path string true "Role ID"
 // @Success 200 {object} object{message=string}
+// @Failure 403 {object} object{error=string}
 // @Security ApiKeyAuth
 // @Router /roles/{id} [delete]
 func (h *RoleHandler) DeleteRole(c *gin.Context) {
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"veritas/config"
+	"veritas/core/domain"
+	"veritas/core/usecases"
+	"veritas/internal/middleware"
+	"veritas/internal/ports/dtos"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SessionHandler handles session-related HTTP requests.
+type SessionHandler struct {
+	sessionUseCase usecases.SessionUsecase
+	cookies        config.SessionCookieConfig
+}
+
+// NewSessionHandler creates a new SessionHandler with the given SessionUsecase.
+func NewSessionHandler(sessionUsecase usecases.SessionUsecase, cookies config.SessionCookieConfig) *SessionHandler {
+	return &SessionHandler{
+		sessionUseCase: sessionUsecase,
+		cookies:        cookies,
+	}
+}
+
+// Logout godoc
+// @Summary Sign out
+// @Description End the session of the presented token and clear the session cookies. Cookie sessions must send the X-CSRF-Token header.
+// @Tags auth
+// @Produce  json
+// @Param X-CSRF-Token header string false "CSRF token of a cookie session"
+// @Success 200 {object} object{message=string}
+// @Security ApiKeyAuth
+// @Router /auth/logout [post]
+func (h *SessionHandler) Logout(c *gin.Context) {
+	err := h.sessionUseCase.RevokeSession(c.Request.Context(), c.GetString(middleware.UserIDKey), c.GetString(middleware.SessionIDKey))
+	if err != nil && !errors.Is(err, usecases.ErrSessionNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	middleware.ClearSessionCookies(c, h.cookies)
+	c.JSON(http.StatusOK, gin.H{"message": "Signed out successfully"})
+}
+
+// GetMySessions godoc
+// @Summary List the current user's sessions
+// @Description List the devices the authenticated user is signed in on, most recently used first
+// @Tags sessions
+// @Produce  json
+// @Success 200 {array} dtos.SessionOutputDTO
+// @Security ApiKeyAuth
+// @Router /me/sessions [get]
+func (h *SessionHandler) GetMySessions(c *gin.Context) {
+	h.listSessions(c, c.GetString(middleware.UserIDKey))
+}
+
+// DeleteMySession godoc
+// @Summary Sign out one of the current user's sessions
+// @Description End a session of the authenticated user. Tokens of that session are rejected from then on.
+// @Tags sessions
+// @Param id path string true "Session ID"
+// @Success 200 {object} object{message=string}
+// @Failure 404 {object} object{error=string}
+// @Security ApiKeyAuth
+// @Router /me/sessions/{id} [delete]
+func (h *SessionHandler) DeleteMySession(c *gin.Context) {
+	h.revokeSession(c, c.GetString(middleware.UserIDKey), c.Param("id"))
+}
+
+// GetUserSessions godoc
+// @Summary List a user's sessions
+// @Description List the devices a user is signed in on, most recently used first
+// @Tags sessions
+// @Produce  json
+// @Param id path string true "User ID"
+// @Success 200 {array} dtos.SessionOutputDTO
+// @Failure 403 {object} object{error=string}
+// @Security ApiKeyAuth
+// @Router /users/{id}/sessions [get]
+func (h *SessionHandler) GetUserSessions(c *gin.Context) {
+	h.listSessions(c, c.Param("id"))
+}
+
+// DeleteUserSession godoc
+// @Summary Sign out one of a user's sessions
+// @Description End a session of a user. Tokens of that session are rejected from then on.
+// @Tags sessions
+// @Param id path string true "User ID"
+// @Param sessionId path string true "Session ID"
+// @Success 200 {object} object{message=string}
+// @Failure 403 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Security ApiKeyAuth
+// @Router /users/{id}/sessions/{sessionId} [delete]
+func (h *SessionHandler) DeleteUserSession(c *gin.Context) {
+	h.revokeSession(c, c.Param("id"), c.Param("sessionId"))
+}
+
+// DeleteUserSessions godoc
+// @Summary Sign out all of a user's sessions
+// @Description End every session of a user, signing them out on all devices
+// @Tags sessions
+// @Param id path string true "User ID"
+// @Success 200 {object} object{message=string,count=int}
+// @Failure 403 {object} object{error=string}
+// @Security ApiKeyAuth
+// @Router /users/{id}/sessions [delete]
+func (h *SessionHandler) DeleteUserSessions(c *gin.Context) {
+	count, err := h.sessionUseCase.RevokeAllSessions(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Sessions ended successfully", "count": count})
+}
+
+func (h *SessionHandler) listSessions(c *gin.Context, userID string) {
+	sessions, err := h.sessionUseCase.ListSessions(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	output := []dtos.SessionOutputDTO{}
+	for _, session := range sessions {
+		output = append(output, sessionOutput(session, c.GetString(middleware.SessionIDKey)))
+	}
+
+	c.JSON(http.StatusOK, output)
+}
+
+func (h *SessionHandler) revokeSession(c *gin.Context, userID string, sessionID string) {
+	err := h.sessionUseCase.RevokeSession(c.Request.Context(), userID, sessionID)
+	if errors.Is(err, usecases.ErrSessionNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Session ended successfully"})
+}
+
+func sessionOutput(session *domain.Session, currentSessionID string) dtos.SessionOutputDTO {
+	return dtos.SessionOutputDTO{
+		ID:          session.ID.Hex(),
+		Device:      session.Device,
+		UserAgent:   session.UserAgent,
+		IP:          session.IP,
+		AuthMethods: session.AuthMethods,
+		CreatedAt:   session.CreatedAt,
+		LastSeenAt:  session.LastSeenAt,
+		ExpiresAt:   session.ExpiresAt,
+		Current:     session.ID.Hex() == currentSessionID,
+	}
+}
@@ -1,22 +1,28 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"veritas/core/domain"
 	"veritas/core/usecases"
+	"veritas/internal/middleware"
 	"veritas/internal/ports/dtos"
+	"veritas/internal/security"
 
 	"github.com/gin-gonic/gin"
 )
 
 // UserHandler handles user-related HTTP requests.
 type UserHandler struct {
-	userUseCase usecases.UserUsecase
+	userUseCase    usecases.UserUsecase
+	lockoutUseCase usecases.LoginProtectionUsecase
 }
 
-// NewUserHandler creates a new UserHandler with the given UserUseCase.
-func NewUserHandler(userUsecase usecases.UserUsecase) *UserHandler {
+// NewUserHandler creates a new UserHandler with the given UserUseCase and LoginProtectionUseCase.
+func NewUserHandler(userUsecase usecases.UserUsecase, lockoutUsecase usecases.LoginProtectionUsecase) *UserHandler {
 	return &UserHandler{
-		userUseCase: userUsecase,
+		userUseCase:    userUsecase,
+		lockoutUseCase: lockoutUsecase,
 	}
 }
 
@@ -56,6 +62,8 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 // @Param id path string true "User ID"
 // @Param user body dtos.UpdateUserInputDTO true "Update User"
 // @Success 200 {object} dtos.UpdateUserOutputDTO
+// @Failure 403 {object} object{error=string}
+// @Failure 409 {object} object{error=string}
 // @Security ApiKeyAuth
 // @Router /users/{id} [put]
 func (h *UserHandler) UpdateUser(c *gin.Context) {
@@ -74,6 +82,14 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 	}
 
 	user, err := h.userUseCase.UpdateUser(c.Request.Context(), id, input)
+	if errors.Is(err, security.ErrPasswordPolicy) {
+		c.JSON(http.StatusBadRequest, passwordPolicyResponse(err))
+		return
+	}
+	if errors.Is(err, usecases.ErrEmailTaken) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -94,6 +110,7 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 // @Tags users
 // @Param id path string true "User ID"
 // @Success 200 {object} object{message=string}
+// @Failure 403 {object} object{error=string}
 // @Security ApiKeyAuth
 // @Router /users/{id} [delete]
 func (h *UserHandler) DeleteUser(c *gin.Context) {
@@ -134,3 +151,191 @@ func (h *UserHandler) GetAllUsers(c *gin.Context) {
 
 	c.JSON(http.StatusOK, outputUsers)
 }
+
+// UnlockUser godoc
+// @Summary Unlock a user
+// @Description Lift a lockout caused by failed login attempts
+// @Tags users
+// @Param id path string true "User ID"
+// @Success 200 {object} object{message=string}
+// @Failure 403 {object} object{error=string}
+// @Security ApiKeyAuth
+// @Router /users/{id}/unlock [post]
+func (h *UserHandler) UnlockUser(c *gin.Context) {
+	id := c.Param("id")
+
+	err := h.lockoutUseCase.Unlock(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User unlocked successfully"})
+}
+
+// GetUserStatus godoc
+// @Summary Get a user's account status
+// @Description Get the account status of a user and the history of its changes
+// @Tags users
+// @Produce  json
+// @Param id path string true "User ID"
+// @Success 200 {object} dtos.UserStatusOutputDTO
+// @Failure 403 {object} object{error=string}
+// @Security ApiKeyAuth
+// @Router /users/{id}/status [get]
+func (h *UserHandler) GetUserStatus(c *gin.Context) {
+	user, err := h.userUseCase.ReadUser(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, userStatusOutput(user))
+}
+
+// SetUserStatus godoc
+// @Summary Change a user's account status
+// @Description Move a user to another account status: pending, active, suspended, disabled or locked. Only the transitions of the account state machine are allowed.
+// @Tags users
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID"
+// @Param input body dtos.SetUserStatusInputDTO true "Status"
+// @Success 200 {object} dtos.UserStatusOutputDTO
+// @Failure 403 {object} object{error=string}
+// @Failure 409 {object} object{error=string} "Transition not allowed"
+// @Security ApiKeyAuth
+// @Router /users/{id}/status [put]
+func (h *UserHandler) SetUserStatus(c *gin.Context) {
+	var statusInput dtos.SetUserStatusInputDTO
+	if err := c.ShouldBindJSON(&statusInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.changeStatus(c, domain.UserStatus(statusInput.Status), statusInput.Reason)
+}
+
+// SuspendUser godoc
+// @Summary Suspend a user
+// @Description Bar a user from signing in until they are reactivated. Their tokens stop working immediately.
+// @Tags users
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID"
+// @Param input body dtos.UserStatusReasonInputDTO true "Reason"
+// @Success 200 {object} dtos.UserStatusOutputDTO
+// @Failure 403 {object} object{error=string}
+// @Failure 409 {object} object{error=string} "Transition not allowed"
+// @Security ApiKeyAuth
+// @Router /users/{id}/suspend [post]
+func (h *UserHandler) SuspendUser(c *gin.Context) {
+	var reasonInput dtos.UserStatusReasonInputDTO
+	if err := c.ShouldBindJSON(&reasonInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.changeStatus(c, domain.UserStatusSuspended, reasonInput.Reason)
+}
+
+// ReactivateUser godoc
+// @Summary Reactivate a user
+// @Description Return a suspended, locked or disabled user to active
+// @Tags users
+// @Accept  json
+// @Produce  json
+// @Param id path string true "User ID"
+// @Param input body dtos.UserStatusReasonInputDTO true "Reason"
+// @Success 200 {object} dtos.UserStatusOutputDTO
+// @Failure 403 {object} object{error=string}
+// @Failure 409 {object} object{error=string} "Transition not allowed"
+// @Security ApiKeyAuth
+// @Router /users/{id}/reactivate [post]
+func (h *UserHandler) ReactivateUser(c *gin.Context) {
+	var reasonInput dtos.UserStatusReasonInputDTO
+	if err := c.ShouldBindJSON(&reasonInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.changeStatus(c, domain.UserStatusActive, reasonInput.Reason)
+}
+
+func (h *UserHandler) changeStatus(c *gin.Context, status domain.UserStatus, reason string) {
+	user, err := h.userUseCase.ChangeStatus(c.Request.Context(), c.Param("id"), status, reason, c.GetString(middleware.UserIDKey))
+	if errors.Is(err, usecases.ErrInvalidStatusTransition) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, userStatusOutput(user))
+}
+
+func userStatusOutput(user *domain.User) dtos.UserStatusOutputDTO {
+	output := dtos.UserStatusOutputDTO{
+		ID:      user.ID.Hex(),
+		Status:  string(user.CurrentStatus()),
+		History: []dtos.UserStatusChangeOutputDTO{},
+	}
+	for _, change := range user.StatusHistory {
+		output.History = append(output.History, dtos.UserStatusChangeOutputDTO{
+			From:      string(change.From),
+			To:        string(change.To),
+			Reason:    change.Reason,
+			ActorID:   change.ActorID,
+			ChangedAt: change.ChangedAt,
+		})
+	}
+	return output
+}
+
+// ChangePassword godoc
+// @Summary Change the current user's password
+// @Description Replace the password of the authenticated user. The current password is required. A rejected new password is answered with the policy rules it breaks.
+// @Tags users
+// @Accept  json
+// @Produce  json
+// @Param input body dtos.ChangePasswordInputDTO true "Change Password"
+// @Success 200 {object} object{message=string}
+// @Failure 400 {object} object{error=string,violations=[]security.PasswordViolation} "Password rejected by the policy"
+// @Security ApiKeyAuth
+// @Router /me/password [put]
+func (h *UserHandler) ChangePassword(c *gin.Context) {
+	var passwordInput dtos.ChangePasswordInputDTO
+	if err := c.ShouldBindJSON(&passwordInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.userUseCase.ChangePassword(c.Request.Context(), c.GetString(middleware.UserIDKey), passwordInput.CurrentPassword, passwordInput.NewPassword)
+	if errors.Is(err, usecases.ErrIncorrectPassword) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, security.ErrPasswordPolicy) {
+		c.JSON(http.StatusBadRequest, passwordPolicyResponse(err))
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password changed successfully"})
+}
+
+// passwordPolicyResponse describes a rejected password, listing the rules it
+// breaks.
+func passwordPolicyResponse(err error) gin.H {
+	response := gin.H{"error": err.Error()}
+	var policyErr *security.PasswordPolicyError
+	if errors.As(err, &policyErr) {
+		response["violations"] = policyErr.Violations
+	}
+	return response
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"veritas/core/usecases"
+	"veritas/internal/middleware"
+	"veritas/internal/ports/dtos"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebAuthnHandler handles passkey registration and management requests.
+type WebAuthnHandler struct {
+	webAuthnUseCase usecases.WebAuthnUsecase
+}
+
+// NewWebAuthnHandler creates a new WebAuthnHandler with the given WebAuthnUseCase.
+func NewWebAuthnHandler(webAuthnUsecase usecases.WebAuthnUsecase) *WebAuthnHandler {
+	return &WebAuthnHandler{
+		webAuthnUseCase: webAuthnUsecase,
+	}
+}
+
+// BeginRegistration godoc
+// @Summary Start passkey registration
+// @Description Get the options to pass to navigator.credentials.create for the current user
+// @Tags credentials
+// @Produce  json
+// @Success 200 {object} security.CredentialCreationOptions
+// @Security ApiKeyAuth
+// @Router /me/credentials/webauthn/begin [post]
+func (h *WebAuthnHandler) BeginRegistration(c *gin.Context) {
+	options, err := h.webAuthnUseCase.BeginRegistration(c.Request.Context(), c.GetString(middleware.UserIDKey))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, options)
+}
+
+// FinishRegistration godoc
+// @Summary Finish passkey registration
+// @Description Verify the authenticator response and store the new credential
+// @Tags credentials
+// @Accept  json
+// @Produce  json
+// @Param credential body dtos.FinishCredentialRegistrationInputDTO true "Registration Response"
+// @Success 201 {object} domain.WebAuthnCredential
+// @Security ApiKeyAuth
+// @Router /me/credentials/webauthn/finish [post]
+func (h *WebAuthnHandler) FinishRegistration(c *gin.Context) {
+	var registrationInput dtos.FinishCredentialRegistrationInputDTO
+	if err := c.ShouldBindJSON(&registrationInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	input := usecases.FinishRegistrationInput{
+		Name:              registrationInput.Name,
+		ClientDataJSON:    registrationInput.Credential.Response.ClientDataJSON,
+		AttestationObject: registrationInput.Credential.Response.AttestationObject,
+		Transports:        registrationInput.Credential.Response.Transports,
+	}
+
+	credential, err := h.webAuthnUseCase.FinishRegistration(c.Request.Context(), c.GetString(middleware.UserIDKey), input)
+	switch {
+	case errors.Is(err, usecases.ErrWebAuthnVerification):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, usecases.ErrCredentialExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, credential)
+}
+
+// GetCredentials godoc
+// @Summary List passkeys
+// @Description Get the passkeys and security keys registered by the current user
+// @Tags credentials
+// @Produce  json
+// @Success 200 {array} domain.WebAuthnCredential
+// @Security ApiKeyAuth
+// @Router /me/credentials [get]
+func (h *WebAuthnHandler) GetCredentials(c *gin.Context) {
+	credentials, err := h.webAuthnUseCase.GetCredentials(c.Request.Context(), c.GetString(middleware.UserIDKey))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, credentials)
+}
+
+// UpdateCredential godoc
+// @Summary Rename a passkey
+// @Description Rename one of the current user's credentials
+// @Tags credentials
+// @Accept  json
+// @Produce  json
+// @Param id path string true "Credential ID"
+// @Param credential body dtos.UpdateCredentialInputDTO true "Update Credential"
+// @Success 200 {object} domain.WebAuthnCredential
+// @Security ApiKeyAuth
+// @Router /me/credentials/{id} [patch]
+func (h *WebAuthnHandler) UpdateCredential(c *gin.Context) {
+	id := c.Param("id")
+
+	var credentialInput dtos.UpdateCredentialInputDTO
+	if err := c.ShouldBindJSON(&credentialInput); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	credential, err := h.webAuthnUseCase.RenameCredential(c.Request.Context(), c.GetString(middleware.UserIDKey), id, credentialInput.Name)
+	if errors.Is(err, usecases.ErrCredentialNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, credential)
+}
+
+// DeleteCredential godoc
+// @Summary Delete a passkey
+// @Description Delete one of the current user's credentials
+// @Tags credentials
+// @Param id path string true "Credential ID"
+// @Success 200 {object} object{message=string}
+// @Security ApiKeyAuth
+// @Router /me/credentials/{id} [delete]
+func (h *WebAuthnHandler) DeleteCredential(c *gin.Context) {
+	id := c.Param("id")
+
+	err := h.webAuthnUseCase.DeleteCredential(c.Request.Context(), c.GetString(middleware.UserIDKey), id)
+	if errors.Is(err, usecases.ErrCredentialNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Credential deleted successfully"})
+}
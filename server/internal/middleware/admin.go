@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"veritas/core/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireOrganizationAdmin rejects requests from users who are neither
+// admins of the organization the request is scoped to nor platform admins.
+// It runs after AuthMiddleware, which sets the user and the organization of
+// the token.
+func RequireOrganizationAdmin(organizations *usecases.OrganizationUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organization, err := organizations.CurrentOrganization(ctx)
+		if err == nil {
+			err = organizations.CheckAdmin(ctx, c.GetString(UserIDKey), organization)
+		}
+		if errors.Is(err, usecases.ErrNotOrganizationAdmin) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			c.Abort()
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
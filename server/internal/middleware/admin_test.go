@@ -0,0 +1,76 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"veritas/core/domain"
+	"veritas/core/usecases"
+	"veritas/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type RequireOrganizationAdminTestSuite struct {
+	suite.Suite
+	router     *gin.Engine
+	defaultOrg *domain.Organization
+	acme       *domain.Organization
+	acmeAdmin  primitive.ObjectID
+}
+
+func (s *RequireOrganizationAdminTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.acmeAdmin = primitive.NewObjectID()
+	s.defaultOrg = &domain.Organization{ID: primitive.NewObjectID(), Slug: domain.DefaultOrganizationSlug, AdminIDs: []primitive.ObjectID{primitive.NewObjectID()}}
+	s.acme = &domain.Organization{ID: primitive.NewObjectID(), Slug: "acme", AdminIDs: []primitive.ObjectID{s.acmeAdmin}}
+	organizations := usecases.NewOrganizationUsecase(&fakeOrganizations{organizations: []*domain.Organization{s.defaultOrg, s.acme}}, nil, discardAudit{})
+
+	// The user and organization stand in for those AuthMiddleware takes
+	// from the token.
+	s.router = gin.New()
+	s.router.Use(func(c *gin.Context) {
+		tenantID, _ := primitive.ObjectIDFromHex(c.GetHeader("X-Test-Tenant"))
+		c.Request = c.Request.WithContext(domain.WithTenant(c.Request.Context(), tenantID))
+		c.Set(middleware.UserIDKey, c.GetHeader("X-Test-User"))
+	})
+	s.router.Use(middleware.RequireOrganizationAdmin(organizations))
+	s.router.POST("/users/:id/suspend", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+}
+
+func (s *RequireOrganizationAdminTestSuite) post(tenant *domain.Organization, userID primitive.ObjectID) int {
+	req := httptest.NewRequest(http.MethodPost, "/users/"+primitive.NewObjectID().Hex()+"/suspend", nil)
+	req.Header.Set("X-Test-Tenant", tenant.ID.Hex())
+	req.Header.Set("X-Test-User", userID.Hex())
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w.Code
+}
+
+// Test case 1: Admins of the organization pass
+func (s *RequireOrganizationAdminTestSuite) TestOrganizationAdmin() {
+	s.Equal(http.StatusNoContent, s.post(s.acme, s.acmeAdmin))
+}
+
+// Test case 2: Other members are forbidden
+func (s *RequireOrganizationAdminTestSuite) TestMember() {
+	s.Equal(http.StatusForbidden, s.post(s.acme, primitive.NewObjectID()))
+}
+
+// Test case 3: Being an admin of one organization grants nothing in another
+func (s *RequireOrganizationAdminTestSuite) TestAdminOfAnotherOrganization() {
+	s.Equal(http.StatusForbidden, s.post(s.defaultOrg, s.acmeAdmin))
+}
+
+// Test case 4: Platform admins pass in the default organization
+func (s *RequireOrganizationAdminTestSuite) TestPlatformAdmin() {
+	s.Equal(http.StatusNoContent, s.post(s.defaultOrg, s.defaultOrg.AdminIDs[0]))
+}
+
+func TestRequireOrganizationAdminSuite(t *testing.T) {
+	suite.Run(t, new(RequireOrganizationAdminTestSuite))
+}
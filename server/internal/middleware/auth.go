@@ -1,36 +1,155 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
+	"veritas/config"
+	"veritas/core/domain"
+	"veritas/core/usecases"
+	"veritas/internal/ports/output"
+	"veritas/internal/security"
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-var jwtKey = []byte("your_secret_key")
+// Context keys set by AuthMiddleware for downstream handlers.
+const (
+	UserIDKey    = "userID"
+	ClaimsKey    = "claims"
+	SessionIDKey = "sessionID"
+)
 
-func AuthMiddleware() gin.HandlerFunc {
+// AuthMiddleware authenticates requests with an access token, taken from the
+// Authorization header or, failing that, from the session cookie. Tokens of
+// users that no longer exist, that can no longer sign in, or that were issued
+// before the user's tokens were revoked, are rejected, as are tokens whose
+// session was ended or timed out. Unsafe requests authenticated by the cookie
+// must also carry the session's CSRF token. The request is scoped to the
+// organization of the token's tenant claim, whichever one it named. With
+// requireDPoP set, tokens sent in the Authorization header must be bound with
+// DPoP.
+func AuthMiddleware(users output.UserOutputPort, sessions *usecases.SessionUsecase, cookie config.SessionCookieConfig, requireDPoP bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
+		var scheme, tokenString string
+		if authHeader := c.GetHeader("Authorization"); authHeader != "" {
+			scheme, tokenString = "Bearer", strings.TrimPrefix(authHeader, "Bearer ")
+			if strings.HasPrefix(authHeader, "DPoP ") {
+				scheme, tokenString = "DPoP", strings.TrimPrefix(authHeader, "DPoP ")
+			}
+		} else if value, err := c.Cookie(cookie.Name); err == nil && value != "" {
+			scheme, tokenString = "Cookie", value
+		} else {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "missing authorization header"})
 			c.Abort()
 			return
 		}
 
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+		claims, err := security.ParseToken(tokenString, security.TokenUseAccess)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			c.Abort()
+			return
+		}
+
+		// Sender-constrained tokens must be presented with the DPoP scheme and
+		// a proof signed by the key they were bound to. Proofs sent alongside
+		// plain bearer tokens are still verified.
+		jkt := confirmationClaim(claims, "jkt")
+		if (jkt != "") != (scheme == "DPoP") || (requireDPoP && scheme == "Bearer") {
+			c.Header("WWW-Authenticate", `DPoP error="invalid_token"`)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			c.Abort()
+			return
+		}
+		if jkt != "" || c.GetHeader(security.DPoPHeader) != "" {
+			proofJKT, err := security.VerifyDPoPProof(c.Request, tokenString)
+			if err != nil || (jkt != "" && proofJKT != jkt) {
+				c.Header("WWW-Authenticate", `DPoP error="invalid_dpop_proof"`)
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid DPoP proof"})
+				c.Abort()
+				return
+			}
+		}
+
+		// Certificate-bound tokens are only accepted over the same mTLS identity.
+		if x5t := confirmationClaim(claims, security.CertificateThumbprintClaim); x5t != "" {
+			if err := security.VerifyCertificateBinding(c.Request, x5t); err != nil {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+				c.Abort()
+				return
+			}
+		}
 
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			return jwtKey, nil
-		})
+		sub, _ := claims["sub"].(string)
+		userID, err := primitive.ObjectIDFromHex(sub)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			c.Abort()
+			return
+		}
+		tenant, _ := claims["tenant"].(string)
+		tenantID, err := primitive.ObjectIDFromHex(tenant)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			c.Abort()
+			return
+		}
+		c.Request = c.Request.WithContext(domain.WithTenant(c.Request.Context(), tenantID))
 
-		if err != nil || !token.Valid {
+		user, err := users.GetUser(c.Request.Context(), userID)
+		if err != nil || security.IssuedBefore(claims, user.TokensRevokedAt) {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
 			c.Abort()
 			return
 		}
+		if !user.CanSignIn() {
+			c.JSON(http.StatusForbidden, gin.H{"error": "account is not active"})
+			c.Abort()
+			return
+		}
+
+		sid, _ := claims["sid"].(string)
+		session, err := sessions.ValidateSession(c.Request.Context(), sid, sub)
+		if errors.Is(err, usecases.ErrSessionNotFound) || errors.Is(err, usecases.ErrSessionExpired) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "session has ended"})
+			c.Abort()
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			c.Abort()
+			return
+		}
+
+		// Browsers attach the cookie to cross-site requests too, so unsafe
+		// ones must prove they came from a page that knows the CSRF token,
+		// either in the header or, for HTML forms, in a form field.
+		if scheme == "Cookie" && !isSafeMethod(c.Request.Method) && !security.VerifyCSRFToken(session.ID.Hex(), csrfToken(c)) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "invalid CSRF token"})
+			c.Abort()
+			return
+		}
+
+		c.Set(UserIDKey, sub)
+		c.Set(ClaimsKey, claims)
+		c.Set(SessionIDKey, session.ID.Hex())
+		if info := domain.RequestInfoFromContext(c.Request.Context()); info != nil {
+			info.ActorID = sub
+		}
 
 		c.Next()
 	}
 }
+
+// confirmationClaim returns a member of the token's cnf claim (RFC 7800).
+func confirmationClaim(claims jwt.MapClaims, member string) string {
+	cnf, ok := claims["cnf"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	value, _ := cnf[member].(string)
+	return value
+}
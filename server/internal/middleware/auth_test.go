@@ -0,0 +1,209 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+	"veritas/config"
+	"veritas/core/domain"
+	"veritas/core/usecases"
+	"veritas/internal/middleware"
+	"veritas/internal/ports/output"
+	"veritas/internal/security"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type fakeUsers struct {
+	output.UserOutputPort
+	user *domain.User
+}
+
+// GetUser only finds the user in its own tenant, like the repository.
+func (f *fakeUsers) GetUser(ctx context.Context, id primitive.ObjectID) (*domain.User, error) {
+	if tenantID, _ := domain.TenantFromContext(ctx); id != f.user.ID || tenantID != f.user.TenantID {
+		return nil, errors.New("user not found")
+	}
+	return f.user, nil
+}
+
+type fakeSessions struct {
+	output.SessionOutputPort
+	sessions map[primitive.ObjectID]*domain.Session
+}
+
+func (f *fakeSessions) CreateSession(ctx context.Context, session *domain.Session) (primitive.ObjectID, error) {
+	copied := *session
+	copied.ID = primitive.NewObjectID()
+	f.sessions[copied.ID] = &copied
+	return copied.ID, nil
+}
+
+func (f *fakeSessions) GetSession(ctx context.Context, id primitive.ObjectID) (*domain.Session, error) {
+	session, ok := f.sessions[id]
+	if !ok {
+		return nil, errors.New("session not found")
+	}
+	copied := *session
+	return &copied, nil
+}
+
+func (f *fakeSessions) GetSessionsByUser(ctx context.Context, userID primitive.ObjectID) ([]*domain.Session, error) {
+	return nil, nil
+}
+
+type discardAudit struct{}
+
+func (discardAudit) Record(ctx context.Context, entry *domain.AuditEntry) error { return nil }
+
+type AuthMiddlewareTestSuite struct {
+	suite.Suite
+	router   *gin.Engine
+	cookies  config.SessionCookieConfig
+	user     *domain.User
+	sessions *usecases.SessionUsecase
+	session  *domain.Session
+}
+
+func (s *AuthMiddlewareTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.cookies = config.SessionCookieConfig{Name: "veritas_session", CSRFName: "veritas_csrf", Path: "/", Secure: true, SameSite: http.SameSiteLaxMode}
+	s.user = &domain.User{ID: primitive.NewObjectID(), TenantID: primitive.NewObjectID(), Email: "ada@example.com"}
+
+	sessions := usecases.NewSessionUsecase(&fakeSessions{sessions: map[primitive.ObjectID]*domain.Session{}}, discardAudit{}, usecases.SessionPolicy{AbsoluteTimeout: time.Hour})
+	session, err := sessions.CreateSession(context.Background(), s.user, []string{"pwd"})
+	s.Require().NoError(err)
+	s.sessions = sessions
+	s.session = session
+	s.router = s.newRouter(false)
+}
+
+func (s *AuthMiddlewareTestSuite) newRouter(requireDPoP bool) *gin.Engine {
+	router := gin.New()
+	router.Use(middleware.AuthMiddleware(&fakeUsers{user: s.user}, s.sessions, s.cookies, requireDPoP))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/me", ok)
+	router.POST("/me", ok)
+	router.GET("/tenant", func(c *gin.Context) {
+		tenantID, _ := domain.TenantFromContext(c.Request.Context())
+		c.String(http.StatusOK, tenantID.Hex())
+	})
+	return router
+}
+
+func (s *AuthMiddlewareTestSuite) token(claims jwt.MapClaims) string {
+	claims["sub"] = s.user.ID.Hex()
+	claims["sid"] = s.session.ID.Hex()
+	if _, ok := claims["tenant"]; !ok {
+		claims["tenant"] = s.user.TenantID.Hex()
+	}
+	token, err := security.SignToken(security.TokenUseAccess, claims, time.Hour)
+	s.Require().NoError(err)
+	return token
+}
+
+func (s *AuthMiddlewareTestSuite) do(method string, setup func(req *http.Request)) int {
+	req := httptest.NewRequest(method, "/me", nil)
+	setup(req)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w.Code
+}
+
+func (s *AuthMiddlewareTestSuite) withCookie(token, csrfToken string) func(req *http.Request) {
+	return func(req *http.Request) {
+		req.AddCookie(&http.Cookie{Name: s.cookies.Name, Value: token})
+		if csrfToken != "" {
+			req.Header.Set(middleware.CSRFHeader, csrfToken)
+		}
+	}
+}
+
+// Test case 1: Safe requests are authenticated by the session cookie alone
+func (s *AuthMiddlewareTestSuite) TestCookieAuthentication() {
+	s.Equal(http.StatusOK, s.do(http.MethodGet, s.withCookie(s.token(jwt.MapClaims{}), "")))
+	s.Equal(http.StatusUnauthorized, s.do(http.MethodGet, s.withCookie("not-a-token", "")))
+	s.Equal(http.StatusUnauthorized, s.do(http.MethodGet, func(req *http.Request) {}))
+}
+
+// Test case 2: Unsafe cookie requests need the CSRF token of their session
+func (s *AuthMiddlewareTestSuite) TestCookieCSRF() {
+	token := s.token(jwt.MapClaims{})
+
+	s.Equal(http.StatusForbidden, s.do(http.MethodPost, s.withCookie(token, "")))
+	s.Equal(http.StatusForbidden, s.do(http.MethodPost, s.withCookie(token, security.CSRFToken(primitive.NewObjectID().Hex()))))
+	s.Equal(http.StatusOK, s.do(http.MethodPost, s.withCookie(token, security.CSRFToken(s.session.ID.Hex()))))
+}
+
+// Test case 3: Bearer tokens need no CSRF token and take precedence over the cookie
+func (s *AuthMiddlewareTestSuite) TestBearerSkipsCSRF() {
+	token := s.token(jwt.MapClaims{})
+
+	s.Equal(http.StatusOK, s.do(http.MethodPost, func(req *http.Request) {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}))
+	s.Equal(http.StatusUnauthorized, s.do(http.MethodGet, func(req *http.Request) {
+		req.Header.Set("Authorization", "Bearer not-a-token")
+		req.AddCookie(&http.Cookie{Name: s.cookies.Name, Value: token})
+	}))
+}
+
+// Test case 4: Sender-constrained tokens are not accepted from the cookie
+func (s *AuthMiddlewareTestSuite) TestCookieRejectsDPoPBoundToken() {
+	token := s.token(jwt.MapClaims{"cnf": jwt.MapClaims{"jkt": "thumbprint"}})
+
+	s.Equal(http.StatusUnauthorized, s.do(http.MethodGet, s.withCookie(token, "")))
+}
+
+// Test case 5: HTML forms may send the CSRF token as a form field
+func (s *AuthMiddlewareTestSuite) TestCookieCSRFFormField() {
+	token := s.token(jwt.MapClaims{})
+
+	s.Equal(http.StatusOK, s.do(http.MethodPost, func(req *http.Request) {
+		s.withCookie(token, "")(req)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Body = io.NopCloser(strings.NewReader(url.Values{middleware.CSRFFormField: {security.CSRFToken(s.session.ID.Hex())}}.Encode()))
+	}))
+}
+
+// Test case 6: Requests are scoped to the tenant of the token, which must be the user's
+func (s *AuthMiddlewareTestSuite) TestTenantClaim() {
+	req := httptest.NewRequest(http.MethodGet, "/tenant", nil)
+	req = req.WithContext(domain.WithTenant(req.Context(), primitive.NewObjectID()))
+	req.Header.Set("Authorization", "Bearer "+s.token(jwt.MapClaims{}))
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal(s.user.TenantID.Hex(), w.Body.String())
+
+	for _, tenant := range []interface{}{primitive.NewObjectID().Hex(), "", nil} {
+		token := s.token(jwt.MapClaims{"tenant": tenant})
+		s.Equal(http.StatusUnauthorized, s.do(http.MethodGet, func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}))
+	}
+}
+
+// Test case 7: Requiring DPoP refuses unbound tokens in the Authorization header only
+func (s *AuthMiddlewareTestSuite) TestRequireDPoP() {
+	s.router = s.newRouter(true)
+	token := s.token(jwt.MapClaims{})
+
+	s.Equal(http.StatusUnauthorized, s.do(http.MethodGet, func(req *http.Request) {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}))
+	s.Equal(http.StatusOK, s.do(http.MethodGet, s.withCookie(token, "")))
+}
+
+func TestAuthMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(AuthMiddlewareTestSuite))
+}
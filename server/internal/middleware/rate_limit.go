@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"veritas/config"
+	"veritas/internal/ports/output"
+	"veritas/internal/security"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitMiddleware applies the first policy matching each request's route
+// and answers 429 with Retry-After once its limit is exceeded. Responses carry
+// RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset and RateLimit-Policy
+// headers. When the store fails, requests are let through.
+func RateLimitMiddleware(store output.RateLimitOutputPort, policies []config.RateLimitPolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		policy := matchRateLimitPolicy(policies, c.Request.Method, c.FullPath())
+		if policy == nil {
+			c.Next()
+			return
+		}
+
+		window := time.Duration(policy.Window)
+		counter, err := store.Hit(c.Request.Context(), "ratelimit:"+policy.Name+":"+rateLimitKey(c, policy.Key), window)
+		if err != nil {
+			log.Printf("rate limit store failed: %v", err)
+			c.Next()
+			return
+		}
+
+		// Sliding window approximation: the previous window's count is
+		// weighted by how much of it still overlaps the sliding window.
+		now := time.Now()
+		elapsed := window - counter.ResetAt.Sub(now)
+		weight := 1 - float64(elapsed)/float64(window)
+		estimate := float64(counter.PreviousCount)*weight + float64(counter.Count)
+
+		remaining := policy.Limit - int(math.Ceil(estimate))
+		if remaining < 0 {
+			remaining = 0
+		}
+		reset := strconv.Itoa(int(math.Ceil(counter.ResetAt.Sub(now).Seconds())))
+		c.Header("RateLimit-Limit", strconv.Itoa(policy.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(remaining))
+		c.Header("RateLimit-Reset", reset)
+		c.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%d", policy.Limit, int(window.Seconds())))
+
+		if estimate > float64(policy.Limit) {
+			c.Header("Retry-After", reset)
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func matchRateLimitPolicy(policies []config.RateLimitPolicy, method, path string) *config.RateLimitPolicy {
+	if path == "" {
+		return nil
+	}
+	for i := range policies {
+		for _, route := range policies[i].Routes {
+			routeMethod, routePath, ok := strings.Cut(route, " ")
+			if !ok {
+				routeMethod, routePath = "", route
+			}
+			if routeMethod != "" && routeMethod != method {
+				continue
+			}
+			if prefix, wildcard := strings.CutSuffix(routePath, "*"); wildcard && strings.HasPrefix(path, prefix) || routePath == path {
+				return &policies[i]
+			}
+		}
+	}
+	return nil
+}
+
+// rateLimitKey identifies who a request is counted against. The principal is
+// read from a validly signed access token; the full checks of
+// AuthMiddleware still run afterwards. The client IP only follows
+// X-Forwarded-For from the router's trusted proxies (TRUSTED_PROXIES).
+func rateLimitKey(c *gin.Context, key string) string {
+	switch key {
+	case config.RateLimitKeyUser:
+		authHeader := c.GetHeader("Authorization")
+		tokenString := strings.TrimPrefix(strings.TrimPrefix(authHeader, "Bearer "), "DPoP ")
+		if claims, err := security.ParseToken(tokenString, security.TokenUseAccess); err == nil {
+			if sub, _ := claims["sub"].(string); sub != "" {
+				return "user:" + sub
+			}
+		}
+	case config.RateLimitKeyClient:
+		if cert := security.VerifiedClientCertificate(c.Request); cert != nil {
+			return "client:" + security.CertificateThumbprint(cert)
+		}
+	}
+	return "ip:" + c.ClientIP()
+}
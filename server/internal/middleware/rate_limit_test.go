@@ -0,0 +1,120 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"veritas/config"
+	"veritas/internal/adapters/memory"
+	"veritas/internal/middleware"
+	"veritas/internal/security"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type RateLimitTestSuite struct {
+	suite.Suite
+	router *gin.Engine
+}
+
+func (s *RateLimitTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	policies := []config.RateLimitPolicy{
+		{Name: "login", Routes: []string{"POST /auth/login"}, Limit: 2, Window: config.Duration(time.Hour), Key: config.RateLimitKeyIP},
+		{Name: "api", Routes: []string{"/users*"}, Limit: 1, Window: config.Duration(time.Hour), Key: config.RateLimitKeyUser},
+	}
+	s.router = gin.New()
+	s.Require().NoError(s.router.SetTrustedProxies(config.GetTrustedProxies()))
+	s.router.Use(middleware.RateLimitMiddleware(memory.NewRateLimitStore(), policies))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	s.router.POST("/auth/login", ok)
+	s.router.GET("/auth/login", ok)
+	s.router.GET("/users/:id", ok)
+}
+
+func (s *RateLimitTestSuite) do(method, path, ip, token string) *httptest.ResponseRecorder {
+	return s.doForwarded(method, path, ip, token, "")
+}
+
+// doForwarded makes a request whose X-Forwarded-For header claims it was
+// made for forwardedFor.
+func (s *RateLimitTestSuite) doForwarded(method, path, ip, token, forwardedFor string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.RemoteAddr = ip + ":1234"
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if forwardedFor != "" {
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// Test case 1: Requests over the limit are rejected with Retry-After
+func (s *RateLimitTestSuite) TestRateLimitExceeded() {
+	w := s.do(http.MethodPost, "/auth/login", "192.0.2.1", "")
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal("2", w.Header().Get("RateLimit-Limit"))
+	s.Equal("1", w.Header().Get("RateLimit-Remaining"))
+	s.Equal("2;w=3600", w.Header().Get("RateLimit-Policy"))
+
+	s.Equal(http.StatusOK, s.do(http.MethodPost, "/auth/login", "192.0.2.1", "").Code)
+
+	w = s.do(http.MethodPost, "/auth/login", "192.0.2.1", "")
+	s.Equal(http.StatusTooManyRequests, w.Code)
+	s.Equal("0", w.Header().Get("RateLimit-Remaining"))
+	s.NotEmpty(w.Header().Get("Retry-After"))
+
+	// Other clients have their own counters.
+	s.Equal(http.StatusOK, s.do(http.MethodPost, "/auth/login", "192.0.2.2", "").Code)
+}
+
+// Test case 2: Policies only apply to the methods they name
+func (s *RateLimitTestSuite) TestRateLimitMethod() {
+	for i := 0; i < 3; i++ {
+		w := s.do(http.MethodGet, "/auth/login", "192.0.2.1", "")
+		s.Equal(http.StatusOK, w.Code)
+		s.Empty(w.Header().Get("RateLimit-Limit"))
+	}
+}
+
+// Test case 3: User policies count requests per access token subject
+func (s *RateLimitTestSuite) TestRateLimitByUser() {
+	alice, err := security.SignToken(security.TokenUseAccess, jwt.MapClaims{"sub": "alice"}, time.Hour)
+	s.Require().NoError(err)
+	bob, err := security.SignToken(security.TokenUseAccess, jwt.MapClaims{"sub": "bob"}, time.Hour)
+	s.Require().NoError(err)
+
+	s.Equal(http.StatusOK, s.do(http.MethodGet, "/users/1", "192.0.2.1", alice).Code)
+	s.Equal(http.StatusTooManyRequests, s.do(http.MethodGet, "/users/1", "192.0.2.1", alice).Code)
+	s.Equal(http.StatusOK, s.do(http.MethodGet, "/users/1", "192.0.2.1", bob).Code)
+}
+
+// Test case 4: Clients cannot pick their IP with X-Forwarded-For
+func (s *RateLimitTestSuite) TestForwardedForIgnoredByDefault() {
+	s.Equal(http.StatusOK, s.doForwarded(http.MethodPost, "/auth/login", "192.0.2.1", "", "198.51.100.1").Code)
+	s.Equal(http.StatusOK, s.doForwarded(http.MethodPost, "/auth/login", "192.0.2.1", "", "198.51.100.2").Code)
+	s.Equal(http.StatusTooManyRequests, s.doForwarded(http.MethodPost, "/auth/login", "192.0.2.1", "", "198.51.100.3").Code)
+}
+
+// Test case 5: Behind a trusted proxy, the forwarded clients are counted
+func (s *RateLimitTestSuite) TestForwardedForFromTrustedProxy() {
+	s.T().Setenv("TRUSTED_PROXIES", "192.0.2.1")
+	s.SetupTest()
+
+	s.Equal(http.StatusOK, s.doForwarded(http.MethodPost, "/auth/login", "192.0.2.1", "", "198.51.100.1").Code)
+	s.Equal(http.StatusOK, s.doForwarded(http.MethodPost, "/auth/login", "192.0.2.1", "", "198.51.100.1").Code)
+	s.Equal(http.StatusTooManyRequests, s.doForwarded(http.MethodPost, "/auth/login", "192.0.2.1", "", "198.51.100.1").Code)
+	s.Equal(http.StatusOK, s.doForwarded(http.MethodPost, "/auth/login", "192.0.2.1", "", "198.51.100.2").Code)
+	// A client that is not a trusted proxy is still counted by its own address.
+	s.Equal(http.StatusOK, s.doForwarded(http.MethodPost, "/auth/login", "203.0.113.9", "", "198.51.100.1").Code)
+}
+
+func TestRateLimitSuite(t *testing.T) {
+	suite.Run(t, new(RateLimitTestSuite))
+}
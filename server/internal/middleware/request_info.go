@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"encoding/hex"
+	"veritas/core/domain"
+	"veritas/internal/security"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader carries the ID that correlates a request with its audit
+// entries and logs.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestInfoMiddleware attaches a domain.RequestInfo to the request context
+// so usecases can record who made a request and from where. A request ID sent
+// by the client or a proxy is kept, otherwise one is generated, and it is
+// echoed in the response.
+func RequestInfoMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > 128 {
+			if b, err := security.RandomBytes(16); err == nil {
+				requestID = hex.EncodeToString(b)
+			}
+		}
+		c.Header(RequestIDHeader, requestID)
+
+		info := &domain.RequestInfo{
+			IP:        c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+			RequestID: requestID,
+		}
+		c.Request = c.Request.WithContext(domain.WithRequestInfo(c.Request.Context(), info))
+
+		c.Next()
+	}
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+	"veritas/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CSRFHeader carries the CSRF token on unsafe requests authenticated by a
+// session cookie. HTML forms, which cannot set headers, send it in the
+// CSRFFormField field instead.
+const (
+	CSRFHeader    = "X-CSRF-Token"
+	CSRFFormField = "csrf_token"
+)
+
+// SetSessionCookies stores an access token in the HttpOnly session cookie and
+// its CSRF token in a cookie the page's scripts can read. The cookies last as
+// long as the token, or less when cookie.MaxAge is shorter.
+func SetSessionCookies(c *gin.Context, cookie config.SessionCookieConfig, token, csrfToken string, ttl time.Duration) {
+	if cookie.MaxAge > 0 && cookie.MaxAge < ttl {
+		ttl = cookie.MaxAge
+	}
+	maxAge := int(ttl.Seconds())
+	http.SetCookie(c.Writer, sessionCookie(cookie, cookie.Name, token, maxAge, true))
+	http.SetCookie(c.Writer, sessionCookie(cookie, cookie.CSRFName, csrfToken, maxAge, false))
+}
+
+// ClearSessionCookies expires both session cookies.
+func ClearSessionCookies(c *gin.Context, cookie config.SessionCookieConfig) {
+	http.SetCookie(c.Writer, sessionCookie(cookie, cookie.Name, "", -1, true))
+	http.SetCookie(c.Writer, sessionCookie(cookie, cookie.CSRFName, "", -1, false))
+}
+
+func sessionCookie(cookie config.SessionCookieConfig, name, value string, maxAge int, httpOnly bool) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     cookie.Path,
+		Domain:   cookie.Domain,
+		MaxAge:   maxAge,
+		Secure:   cookie.Secure,
+		HttpOnly: httpOnly,
+		SameSite: cookie.SameSite,
+	}
+}
+
+// isSafeMethod reports whether a request method is one that must not change
+// state, and so needs no CSRF protection.
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+func csrfToken(c *gin.Context) string {
+	if token := c.GetHeader(CSRFHeader); token != "" {
+		return token
+	}
+	return c.PostForm(CSRFFormField)
+}
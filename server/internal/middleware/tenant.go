@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"veritas/core/domain"
+	"veritas/core/usecases"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TenantHeader names the organization an unauthenticated request is made
+// in, by ID or slug.
+const TenantHeader = "X-Tenant"
+
+// TenantQueryParam names the organization in links, such as those sent by
+// email and the hosted pages, where the header cannot be set.
+const TenantQueryParam = "tenant"
+
+// TenantMiddleware scopes the request context to the organization named by
+// the X-Tenant header or the tenant query parameter, or else to the one
+// serving the request's host, or else to the default organization.
+// AuthMiddleware later rescopes authenticated requests to the organization
+// of their token.
+func TenantMiddleware(organizations *usecases.OrganizationUsecase) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ref := c.GetHeader(TenantHeader)
+		if ref == "" {
+			ref = c.Query(TenantQueryParam)
+		}
+		host := c.Request.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+
+		organization, err := organizations.ResolveTenant(c.Request.Context(), ref, host)
+		if errors.Is(err, usecases.ErrOrganizationNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			c.Abort()
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(domain.WithTenant(c.Request.Context(), organization.ID))
+		c.Next()
+	}
+}
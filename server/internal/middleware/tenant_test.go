@@ -0,0 +1,112 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"veritas/core/domain"
+	"veritas/core/usecases"
+	"veritas/internal/middleware"
+	"veritas/internal/ports/output"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type fakeOrganizations struct {
+	output.OrganizationOutputPort
+	organizations []*domain.Organization
+}
+
+func (f *fakeOrganizations) GetOrganization(ctx context.Context, id primitive.ObjectID) (*domain.Organization, error) {
+	for _, o := range f.organizations {
+		if o.ID == id {
+			return o, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeOrganizations) GetOrganizationBySlug(ctx context.Context, slug string) (*domain.Organization, error) {
+	for _, o := range f.organizations {
+		if o.Slug == slug {
+			return o, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeOrganizations) GetOrganizationByDomain(ctx context.Context, host string) (*domain.Organization, error) {
+	for _, o := range f.organizations {
+		for _, d := range o.Domains {
+			if d == host {
+				return o, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+type TenantMiddlewareTestSuite struct {
+	suite.Suite
+	router     *gin.Engine
+	defaultOrg *domain.Organization
+	acme       *domain.Organization
+}
+
+func (s *TenantMiddlewareTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	s.defaultOrg = &domain.Organization{ID: primitive.NewObjectID(), Slug: domain.DefaultOrganizationSlug}
+	s.acme = &domain.Organization{ID: primitive.NewObjectID(), Slug: "acme", Domains: []string{"login.acme.example"}}
+	organizations := usecases.NewOrganizationUsecase(&fakeOrganizations{organizations: []*domain.Organization{s.defaultOrg, s.acme}}, nil, discardAudit{})
+
+	s.router = gin.New()
+	s.router.Use(middleware.TenantMiddleware(organizations))
+	s.router.GET("/tenant", func(c *gin.Context) {
+		tenantID, _ := domain.TenantFromContext(c.Request.Context())
+		c.String(http.StatusOK, tenantID.Hex())
+	})
+}
+
+func (s *TenantMiddlewareTestSuite) get(target string, setup func(req *http.Request)) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	setup(req)
+	w := httptest.NewRecorder()
+	s.router.ServeHTTP(w, req)
+	return w
+}
+
+// Test case 1: The header takes precedence over the query parameter and the host
+func (s *TenantMiddlewareTestSuite) TestHeader() {
+	w := s.get("/tenant?tenant=default", func(req *http.Request) {
+		req.Header.Set(middleware.TenantHeader, "acme")
+		req.Host = "id.example.com"
+	})
+	s.Equal(s.acme.ID.Hex(), w.Body.String())
+}
+
+// Test case 2: Links name the organization with the query parameter
+func (s *TenantMiddlewareTestSuite) TestQueryParameter() {
+	w := s.get("/tenant?tenant="+s.acme.ID.Hex(), func(req *http.Request) {})
+	s.Equal(s.acme.ID.Hex(), w.Body.String())
+}
+
+// Test case 3: Otherwise the host, and failing that the default organization, is used
+func (s *TenantMiddlewareTestSuite) TestHost() {
+	w := s.get("/tenant", func(req *http.Request) { req.Host = "login.acme.example:8443" })
+	s.Equal(s.acme.ID.Hex(), w.Body.String())
+	w = s.get("/tenant", func(req *http.Request) { req.Host = "id.example.com" })
+	s.Equal(s.defaultOrg.ID.Hex(), w.Body.String())
+}
+
+// Test case 4: Unknown organizations are rejected
+func (s *TenantMiddlewareTestSuite) TestUnknownOrganization() {
+	w := s.get("/tenant", func(req *http.Request) { req.Header.Set(middleware.TenantHeader, "globex") })
+	s.Equal(http.StatusNotFound, w.Code)
+}
+
+func TestTenantMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(TenantMiddlewareTestSuite))
+}
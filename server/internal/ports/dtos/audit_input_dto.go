@@ -0,0 +1,18 @@
+package dtos
+
+import "time"
+
+// ListAuditEntriesInputDTO filters the audit log. Times are RFC 3339; From is
+// inclusive and To exclusive.
+type ListAuditEntriesInputDTO struct {
+	ActorID    string    `form:"actorId"`
+	Action     string    `form:"action"`
+	Category   string    `form:"category" binding:"omitempty,oneof=authentication account admin"`
+	Outcome    string    `form:"outcome" binding:"omitempty,oneof=success failure"`
+	TargetType string    `form:"targetType"`
+	TargetID   string    `form:"targetId"`
+	From       time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To         time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Page       int       `form:"page" binding:"omitempty,min=1"`
+	PageSize   int       `form:"pageSize" binding:"omitempty,min=1,max=500"`
+}
@@ -0,0 +1,35 @@
+package dtos
+
+import "time"
+
+type AuditChangeOutputDTO struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+type AuditEntryOutputDTO struct {
+	ID         string                 `json:"id"`
+	Sequence   int64                  `json:"sequence"`
+	Time       time.Time              `json:"time"`
+	Category   string                 `json:"category"`
+	Action     string                 `json:"action"`
+	Outcome    string                 `json:"outcome"`
+	Reason     string                 `json:"reason,omitempty"`
+	ActorID    string                 `json:"actorId,omitempty"` // empty for anonymous requests and the system
+	TargetType string                 `json:"targetType,omitempty"`
+	TargetID   string                 `json:"targetId,omitempty"`
+	Changes    []AuditChangeOutputDTO `json:"changes,omitempty"`
+	IP         string                 `json:"ip,omitempty"`
+	UserAgent  string                 `json:"userAgent,omitempty"`
+	RequestID  string                 `json:"requestId,omitempty"`
+	PrevHash   string                 `json:"prevHash"`
+	Hash       string                 `json:"hash"`
+}
+
+type AuditPageOutputDTO struct {
+	Entries  []AuditEntryOutputDTO `json:"entries"`
+	Page     int                   `json:"page"`
+	PageSize int                   `json:"pageSize"`
+	Total    int64                 `json:"total"`
+}
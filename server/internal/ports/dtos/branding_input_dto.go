@@ -0,0 +1,13 @@
+package dtos
+
+// UpdateBrandingInputDTO replaces the branding of the hosted pages. Empty
+// fields use the default theme, and Copy overrides page text by key, such as
+// "login.title".
+type UpdateBrandingInputDTO struct {
+	ProductName     string            `json:"productName"`
+	LogoURL         string            `json:"logoUrl"`
+	PrimaryColor    string            `json:"primaryColor"`
+	BackgroundColor string            `json:"backgroundColor"`
+	TextColor       string            `json:"textColor"`
+	Copy            map[string]string `json:"copy"`
+}
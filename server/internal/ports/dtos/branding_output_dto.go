@@ -0,0 +1,13 @@
+package dtos
+
+import "time"
+
+type BrandingOutputDTO struct {
+	ProductName     string            `json:"productName"`
+	LogoURL         string            `json:"logoUrl,omitempty"`
+	PrimaryColor    string            `json:"primaryColor"`
+	BackgroundColor string            `json:"backgroundColor"`
+	TextColor       string            `json:"textColor"`
+	Copy            map[string]string `json:"copy"`
+	UpdatedAt       time.Time         `json:"updatedAt,omitempty"`
+}
@@ -0,0 +1,9 @@
+package dtos
+
+type VerifyEmailInputDTO struct {
+	Token string `json:"token" form:"token" binding:"required"`
+}
+
+type ResendVerificationInputDTO struct {
+	Email string `json:"email" binding:"required,email"`
+}
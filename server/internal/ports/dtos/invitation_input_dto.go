@@ -0,0 +1,20 @@
+package dtos
+
+type CreateInvitationInputDTO struct {
+	Email   string   `json:"email" binding:"required,email"`
+	RoleIDs []string `json:"roleIds"`
+	Admin   bool     `json:"admin"`
+}
+
+type ReadInvitationInputDTO struct {
+	Token string `form:"token" binding:"required"`
+}
+
+// AcceptInvitationInputDTO carries the name and password of the account to
+// create. Invitees who already have an account with a verified address only
+// send the token.
+type AcceptInvitationInputDTO struct {
+	Token    string `json:"token" binding:"required"`
+	Name     string `json:"name"`
+	Password string `json:"password" binding:"omitempty,max=72"`
+}
@@ -0,0 +1,18 @@
+package dtos
+
+import "time"
+
+type InvitationOutputDTO struct {
+	OrganizationID   string    `json:"organizationId"`
+	OrganizationName string    `json:"organizationName"`
+	Email            string    `json:"email"`
+	HasAccount       bool      `json:"hasAccount"` // accepting links the existing account
+	ExpiresAt        time.Time `json:"expiresAt"`
+}
+
+type AcceptInvitationOutputDTO struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Created bool   `json:"created"`
+}
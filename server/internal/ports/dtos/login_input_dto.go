@@ -1,7 +1,10 @@
 package dtos
 
-// LoginInputDTO represents the data transfer object for user login.
+// LoginInputDTO represents the data transfer object for user login. With
+// Cookie set, the token is stored in the session cookie instead of being
+// returned, for browser applications.
 type LoginInputDTO struct {
 	Email    string `json:"email"`
-	Password string `json:"password"`
+	Password string `json:"password" binding:"max=72"`
+	Cookie   bool   `json:"cookie"`
 }
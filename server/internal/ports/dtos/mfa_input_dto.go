@@ -0,0 +1,14 @@
+package dtos
+
+// MFALoginInputDTO completes a login that returned an MFA challenge. Exactly
+// one of Code, SMSCode or RecoveryCode is expected.
+type MFALoginInputDTO struct {
+	MFAToken     string `json:"mfaToken" binding:"required"`
+	Code         string `json:"code"`
+	SMSCode      string `json:"smsCode"`
+	RecoveryCode string `json:"recoveryCode"`
+}
+
+type ConfirmTOTPInputDTO struct {
+	Code string `json:"code" binding:"required"`
+}
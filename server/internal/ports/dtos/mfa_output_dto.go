@@ -0,0 +1,17 @@
+package dtos
+
+type MFAChallengeOutputDTO struct {
+	MFARequired bool     `json:"mfaRequired"`
+	MFAToken    string   `json:"mfaToken"`
+	Methods     []string `json:"methods"`
+}
+
+type TOTPEnrollmentOutputDTO struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+	QRCode string `json:"qrCode"` // PNG data URI of URI
+}
+
+type RecoveryCodesOutputDTO struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
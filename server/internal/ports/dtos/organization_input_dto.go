@@ -0,0 +1,16 @@
+package dtos
+
+type CreateOrganizationInputDTO struct {
+	Name    string   `json:"name" binding:"required"`
+	Slug    string   `json:"slug" binding:"required"`
+	Domains []string `json:"domains"`
+}
+
+type UpdateOrganizationInputDTO struct {
+	Name    string   `json:"name" binding:"required"`
+	Domains []string `json:"domains"`
+}
+
+type AddOrganizationAdminInputDTO struct {
+	UserID string `json:"userId" binding:"required"`
+}
@@ -0,0 +1,10 @@
+package dtos
+
+type ForgotPasswordInputDTO struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordInputDTO struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,max=72"`
+}
@@ -0,0 +1,21 @@
+package dtos
+
+type PasswordlessStartInputDTO struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// MagicLinkVerifyInputDTO carries the token from a magic link. RequestID may
+// be omitted when the browser that started the login sends its cookie.
+type MagicLinkVerifyInputDTO struct {
+	Token     string `json:"token" form:"token" binding:"required"`
+	RequestID string `json:"requestId" form:"requestId"`
+	Cookie    bool   `json:"cookie" form:"cookie"`
+}
+
+// EmailOTPVerifyInputDTO carries a code from a login email. RequestID may be
+// omitted when the browser that started the login sends its cookie.
+type EmailOTPVerifyInputDTO struct {
+	Code      string `json:"code" binding:"required"`
+	RequestID string `json:"requestId"`
+	Cookie    bool   `json:"cookie"`
+}
@@ -0,0 +1,6 @@
+package dtos
+
+type PasswordlessStartOutputDTO struct {
+	RequestID string `json:"requestId"`
+	ExpiresIn int    `json:"expiresIn"` // seconds
+}
@@ -0,0 +1,13 @@
+package dtos
+
+type SetPhoneInputDTO struct {
+	PhoneNumber string `json:"phoneNumber" binding:"required"` // E.164, e.g. +15551234567
+}
+
+type VerifyPhoneInputDTO struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type SMSLoginInputDTO struct {
+	MFAToken string `json:"mfaToken" binding:"required"`
+}
@@ -0,0 +1,15 @@
+package dtos
+
+import "time"
+
+type SessionOutputDTO struct {
+	ID          string    `json:"id"`
+	Device      string    `json:"device"`
+	UserAgent   string    `json:"userAgent"`
+	IP          string    `json:"ip"`
+	AuthMethods []string  `json:"authMethods"`
+	CreatedAt   time.Time `json:"createdAt"`
+	LastSeenAt  time.Time `json:"lastSeenAt"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+	Current     bool      `json:"current"` // the session of the request
+}
@@ -3,11 +3,25 @@ package dtos
 type CreateUserInputDTO struct {
 	Name     string `json:"name" binding:"required"`
 	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required,min=8"`
+	Password string `json:"password" binding:"required,max=72"`
 }
 
 type UpdateUserInputDTO struct {
 	Name     string `json:"name,omitempty"`
 	Email    string `json:"email,omitempty,email"`
-	Password string `json:"password,omitempty,min=8"`
+	Password string `json:"password,omitempty" binding:"omitempty,max=72"`
+}
+
+type ChangePasswordInputDTO struct {
+	CurrentPassword string `json:"currentPassword" binding:"required,max=72"`
+	NewPassword     string `json:"newPassword" binding:"required,max=72"`
+}
+
+type UserStatusReasonInputDTO struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+type SetUserStatusInputDTO struct {
+	Status string `json:"status" binding:"required,oneof=pending active suspended disabled locked"`
+	Reason string `json:"reason" binding:"required"`
 }
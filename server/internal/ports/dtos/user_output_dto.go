@@ -1,5 +1,7 @@
 package dtos
 
+import "time"
+
 type CreateUserOutputDTO struct {
 	ID    string `json:"id"`
 	Name  string `json:"name"`
@@ -11,3 +13,17 @@ type UpdateUserOutputDTO struct {
 	Name  string `json:"name"`
 	Email string `json:"email"`
 }
+
+type UserStatusChangeOutputDTO struct {
+	From      string    `json:"from,omitempty"`
+	To        string    `json:"to"`
+	Reason    string    `json:"reason"`
+	ActorID   string    `json:"actorId,omitempty"` // empty for changes made by the system
+	ChangedAt time.Time `json:"changedAt"`
+}
+
+type UserStatusOutputDTO struct {
+	ID      string                      `json:"id"`
+	Status  string                      `json:"status"`
+	History []UserStatusChangeOutputDTO `json:"history"`
+}
@@ -0,0 +1,54 @@
+package dtos
+
+import "veritas/internal/security"
+
+// RegistrationResponseDTO is the JSON serialization of the PublicKeyCredential
+// returned by navigator.credentials.create.
+type RegistrationResponseDTO struct {
+	ID       string             `json:"id" binding:"required"`
+	RawID    security.Base64URL `json:"rawId" binding:"required"`
+	Type     string             `json:"type" binding:"required,eq=public-key"`
+	Response struct {
+		ClientDataJSON    security.Base64URL `json:"clientDataJSON" binding:"required"`
+		AttestationObject security.Base64URL `json:"attestationObject" binding:"required"`
+		Transports        []string           `json:"transports"`
+	} `json:"response" binding:"required"`
+}
+
+// AssertionResponseDTO is the JSON serialization of the PublicKeyCredential
+// returned by navigator.credentials.get.
+type AssertionResponseDTO struct {
+	ID       string             `json:"id" binding:"required"`
+	RawID    security.Base64URL `json:"rawId" binding:"required"`
+	Type     string             `json:"type" binding:"required,eq=public-key"`
+	Response struct {
+		ClientDataJSON    security.Base64URL `json:"clientDataJSON" binding:"required"`
+		AuthenticatorData security.Base64URL `json:"authenticatorData" binding:"required"`
+		Signature         security.Base64URL `json:"signature" binding:"required"`
+		UserHandle        security.Base64URL `json:"userHandle"`
+	} `json:"response" binding:"required"`
+}
+
+type FinishCredentialRegistrationInputDTO struct {
+	Name       string                  `json:"name"`
+	Credential RegistrationResponseDTO `json:"credential" binding:"required"`
+}
+
+type UpdateCredentialInputDTO struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// BeginWebAuthnLoginInputDTO starts a passkey ceremony. With an MFA token it
+// is the second factor of a password login, otherwise a passwordless login.
+type BeginWebAuthnLoginInputDTO struct {
+	MFAToken string `json:"mfaToken"`
+}
+
+// FinishWebAuthnLoginInputDTO completes a passkey ceremony. Cookie only
+// applies to passwordless logins; a second factor follows the choice made
+// when the MFA challenge was issued.
+type FinishWebAuthnLoginInputDTO struct {
+	MFAToken   string               `json:"mfaToken"`
+	Credential AssertionResponseDTO `json:"credential" binding:"required"`
+	Cookie     bool                 `json:"cookie"`
+}
@@ -0,0 +1,22 @@
+package output
+
+import (
+	"context"
+	"veritas/core/domain"
+)
+
+// AuditExporter delivers audit entries to an external system such as a
+// SIEM.
+type AuditExporter interface {
+	// Export delivers entries in order. A nil error means the destination
+	// accepted all of them; otherwise they are all sent again later.
+	Export(ctx context.Context, entries []*domain.AuditEntry) error
+	Close() error
+}
+
+type AuditCheckpointOutputPort interface {
+	// GetCheckpoint returns the checkpoint of the named exporter, or nil
+	// when it has not exported anything yet.
+	GetCheckpoint(ctx context.Context, exporter string) (*domain.AuditExportCheckpoint, error)
+	SaveCheckpoint(ctx context.Context, checkpoint *domain.AuditExportCheckpoint) error
+}
@@ -0,0 +1,23 @@
+package output
+
+import (
+	"context"
+	"veritas/core/domain"
+)
+
+// AuditLogger appends entries to the audit log.
+type AuditLogger interface {
+	// Record assigns the entry its sequence number and chain hashes and
+	// stores it.
+	Record(ctx context.Context, entry *domain.AuditEntry) error
+}
+
+type AuditOutputPort interface {
+	AuditLogger
+	// FindEntries returns a page of the entries matching filter, newest
+	// first, and the number of matching entries.
+	FindEntries(ctx context.Context, filter domain.AuditFilter, offset, limit int64) ([]*domain.AuditEntry, int64, error)
+	// ScanEntries calls fn with every entry from the given sequence number
+	// on, in order, until fn returns an error.
+	ScanEntries(ctx context.Context, fromSequence int64, fn func(*domain.AuditEntry) error) error
+}
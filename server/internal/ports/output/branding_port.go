@@ -0,0 +1,13 @@
+package output
+
+import (
+	"context"
+	"veritas/core/domain"
+)
+
+type BrandingOutputPort interface {
+	// GetBranding returns the branding with the given ID, or nil when none
+	// was saved.
+	GetBranding(ctx context.Context, id string) (*domain.Branding, error)
+	SaveBranding(ctx context.Context, branding *domain.Branding) error
+}
@@ -0,0 +1,12 @@
+package output
+
+import "context"
+
+// BreachedPasswordOutputPort looks up passwords known from data breaches by
+// k-anonymity: only the first five hex digits of a password's SHA-1 hash are
+// disclosed, and the matching hashes are compared by the caller.
+type BreachedPasswordOutputPort interface {
+	// FindHashSuffixes returns the remaining 35 uppercase hex digits of every
+	// breached SHA-1 hash starting with prefix.
+	FindHashSuffixes(ctx context.Context, prefix string) ([]string, error)
+}
@@ -0,0 +1,10 @@
+package output
+
+import (
+	"context"
+	"veritas/core/domain"
+)
+
+type EmailSender interface {
+	SendEmail(ctx context.Context, message *domain.EmailMessage) error
+}
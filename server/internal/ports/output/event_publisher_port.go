@@ -0,0 +1,10 @@
+package output
+
+import (
+	"context"
+	"veritas/core/domain"
+)
+
+type EventPublisher interface {
+	Publish(ctx context.Context, event *domain.Event) error
+}
@@ -0,0 +1,21 @@
+package output
+
+import (
+	"context"
+	"veritas/core/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// InvitationOutputPort stores the invitations of the organization the
+// context is scoped to.
+type InvitationOutputPort interface {
+	CreateInvitation(ctx context.Context, invitation *domain.Invitation) (primitive.ObjectID, error)
+	GetInvitation(ctx context.Context, id primitive.ObjectID) (*domain.Invitation, error)
+	// ConsumeInvitation atomically removes and returns the invitation.
+	ConsumeInvitation(ctx context.Context, id primitive.ObjectID) (*domain.Invitation, error)
+	DeleteInvitation(ctx context.Context, id primitive.ObjectID) error
+	DeleteInvitationsByEmail(ctx context.Context, email string) error
+	// GetPendingInvitations returns the invitations that have not expired.
+	GetPendingInvitations(ctx context.Context) ([]*domain.Invitation, error)
+}
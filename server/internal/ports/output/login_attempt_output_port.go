@@ -0,0 +1,17 @@
+package output
+
+import (
+	"context"
+	"time"
+	"veritas/core/domain"
+)
+
+type LoginAttemptOutputPort interface {
+	GetAttempt(ctx context.Context, key string) (*domain.LoginAttempt, error)
+	// RecordFailure atomically counts a failure for key. Failures older than
+	// window no longer count.
+	RecordFailure(ctx context.Context, key string, window time.Duration) (*domain.LoginAttempt, error)
+	// Lock locks key until the given time and clears its failures.
+	Lock(ctx context.Context, key string, until time.Time) error
+	DeleteAttempt(ctx context.Context, key string) error
+}
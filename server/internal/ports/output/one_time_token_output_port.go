@@ -0,0 +1,20 @@
+package output
+
+import (
+	"context"
+	"veritas/core/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type OneTimeTokenOutputPort interface {
+	CreateToken(ctx context.Context, token *domain.OneTimeToken) (primitive.ObjectID, error)
+	// ConsumeToken atomically removes and returns the token with the given hash.
+	ConsumeToken(ctx context.Context, tokenHash string, purpose string) (*domain.OneTimeToken, error)
+	GetTokenByHash(ctx context.Context, tokenHash string, purpose string) (*domain.OneTimeToken, error)
+	GetTokenByUser(ctx context.Context, userID primitive.ObjectID, purpose string) (*domain.OneTimeToken, error)
+	GetTokenByBinding(ctx context.Context, bindingHash string, purpose string) (*domain.OneTimeToken, error)
+	// IncrementAttempts records a verification attempt and returns the new count.
+	IncrementAttempts(ctx context.Context, id primitive.ObjectID) (int, error)
+	DeleteTokensByUser(ctx context.Context, userID primitive.ObjectID, purpose string) error
+}
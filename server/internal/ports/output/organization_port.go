@@ -0,0 +1,20 @@
+package output
+
+import (
+	"context"
+	"veritas/core/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OrganizationOutputPort stores the organizations themselves. Unlike the
+// tenant-owned records, they are looked up across tenants. The getters
+// return nil when no organization matches.
+type OrganizationOutputPort interface {
+	CreateOrganization(ctx context.Context, organization *domain.Organization) (primitive.ObjectID, error)
+	GetOrganization(ctx context.Context, id primitive.ObjectID) (*domain.Organization, error)
+	GetOrganizationBySlug(ctx context.Context, slug string) (*domain.Organization, error)
+	GetOrganizationByDomain(ctx context.Context, host string) (*domain.Organization, error)
+	UpdateOrganization(ctx context.Context, id primitive.ObjectID, organization *domain.Organization) error
+	GetAllOrganizations(ctx context.Context) ([]*domain.Organization, error)
+}
@@ -0,0 +1,13 @@
+package output
+
+import (
+	"context"
+	"time"
+	"veritas/core/domain"
+)
+
+type RateLimitOutputPort interface {
+	// Hit records a hit for key in the current fixed window of the given
+	// length and returns the counter including it.
+	Hit(ctx context.Context, key string, window time.Duration) (*domain.RateLimitCounter, error)
+}
@@ -0,0 +1,21 @@
+package output
+
+import (
+	"context"
+	"time"
+	"veritas/core/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type SessionOutputPort interface {
+	CreateSession(ctx context.Context, session *domain.Session) (primitive.ObjectID, error)
+	GetSession(ctx context.Context, id primitive.ObjectID) (*domain.Session, error)
+	// GetSessionsByUser returns the sessions of a user, most recently used
+	// first.
+	GetSessionsByUser(ctx context.Context, userID primitive.ObjectID) ([]*domain.Session, error)
+	// TouchSession records a request made with the session.
+	TouchSession(ctx context.Context, id primitive.ObjectID, lastSeenAt time.Time, ip string) error
+	DeleteSession(ctx context.Context, id primitive.ObjectID) error
+	DeleteSessionsByUser(ctx context.Context, userID primitive.ObjectID) (int64, error)
+}
@@ -0,0 +1,10 @@
+package output
+
+import (
+	"context"
+	"veritas/core/domain"
+)
+
+type SMSSender interface {
+	SendSMS(ctx context.Context, message *domain.SMSMessage) error
+}
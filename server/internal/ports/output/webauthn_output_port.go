@@ -0,0 +1,23 @@
+package output
+
+import (
+	"context"
+	"veritas/core/domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type WebAuthnCredentialOutputPort interface {
+	CreateCredential(ctx context.Context, credential *domain.WebAuthnCredential) (primitive.ObjectID, error)
+	GetCredential(ctx context.Context, id primitive.ObjectID) (*domain.WebAuthnCredential, error)
+	GetCredentialByCredentialID(ctx context.Context, credentialID []byte) (*domain.WebAuthnCredential, error)
+	GetCredentialsByUser(ctx context.Context, userID primitive.ObjectID) ([]*domain.WebAuthnCredential, error)
+	UpdateCredential(ctx context.Context, id primitive.ObjectID, credential *domain.WebAuthnCredential) error
+	DeleteCredential(ctx context.Context, id primitive.ObjectID) error
+}
+
+type WebAuthnChallengeOutputPort interface {
+	CreateChallenge(ctx context.Context, challenge *domain.WebAuthnChallenge) (primitive.ObjectID, error)
+	// ConsumeChallenge atomically removes and returns the pending challenge.
+	ConsumeChallenge(ctx context.Context, challenge string, ceremony string) (*domain.WebAuthnChallenge, error)
+}
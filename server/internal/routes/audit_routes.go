@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"veritas/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupAuditRoutes sets up the audit log routes.
+func SetupAuditRoutes(router *gin.Engine, handler *handlers.AuditHandler, authMiddleware gin.HandlerFunc, adminMiddleware gin.HandlerFunc) {
+	auditRoutes := router.Group("/audit")
+	auditRoutes.Use(authMiddleware, adminMiddleware)
+	{
+		auditRoutes.GET("", handler.ListAuditEntries)
+	}
+}
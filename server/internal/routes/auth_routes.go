@@ -11,6 +11,20 @@ func SetupAuthRoutes(router *gin.Engine, handler *handlers.AuthHandler) {
 	authRoutes := router.Group("/auth")
 	{
 		authRoutes.POST("/login", handler.Login)
+		authRoutes.POST("/login/mfa", handler.LoginMFA)
+		authRoutes.POST("/login/sms", handler.SendSMSLoginCode)
+		authRoutes.POST("/webauthn/begin", handler.BeginWebAuthnLogin)
+		authRoutes.POST("/webauthn/finish", handler.FinishWebAuthnLogin)
 		authRoutes.POST("/signup", handler.SignUp)
+		authRoutes.GET("/verify-email", handler.VerifyEmail)
+		authRoutes.POST("/verify-email", handler.VerifyEmail)
+		authRoutes.POST("/verify-email/resend", handler.ResendVerification)
+		authRoutes.POST("/password/forgot", handler.ForgotPassword)
+		authRoutes.POST("/password/reset", handler.ResetPassword)
+		authRoutes.POST("/magic-link", handler.StartMagicLink)
+		authRoutes.GET("/magic-link/verify", handler.VerifyMagicLink)
+		authRoutes.POST("/magic-link/verify", handler.VerifyMagicLink)
+		authRoutes.POST("/otp", handler.StartEmailOTP)
+		authRoutes.POST("/otp/verify", handler.VerifyEmailOTP)
 	}
 }
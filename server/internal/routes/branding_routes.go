@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"veritas/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupBrandingRoutes sets up the routes that manage the branding of the
+// hosted pages.
+func SetupBrandingRoutes(router *gin.Engine, handler *handlers.BrandingHandler, authMiddleware gin.HandlerFunc, adminMiddleware gin.HandlerFunc) {
+	brandingRoutes := router.Group("/branding")
+	brandingRoutes.Use(authMiddleware)
+	{
+		brandingRoutes.GET("", handler.GetBranding)
+		brandingRoutes.PUT("", adminMiddleware, handler.UpdateBranding)
+	}
+}
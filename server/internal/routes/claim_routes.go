@@ -2,20 +2,19 @@ package routes
 
 import (
 	"veritas/internal/handlers"
-	"veritas/internal/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
 // SetupClaimRoutes sets up the claim routes.
-func SetupClaimRoutes(router *gin.Engine, handler *handlers.ClaimHandler) {
+func SetupClaimRoutes(router *gin.Engine, handler *handlers.ClaimHandler, authMiddleware gin.HandlerFunc, adminMiddleware gin.HandlerFunc) {
 	claimRoutes := router.Group("/claims")
-	claimRoutes.Use(middleware.AuthMiddleware())
+	claimRoutes.Use(authMiddleware)
 	{
-		claimRoutes.POST("", handler.CreateClaim)
+		claimRoutes.POST("", adminMiddleware, handler.CreateClaim)
 		claimRoutes.GET("", handler.GetAllClaims)
 		claimRoutes.GET("/:id", handler.GetClaim)
-		claimRoutes.PUT("/:id", handler.UpdateClaim)
-		claimRoutes.DELETE("/:id", handler.DeleteClaim)
+		claimRoutes.PUT("/:id", adminMiddleware, handler.UpdateClaim)
+		claimRoutes.DELETE("/:id", adminMiddleware, handler.DeleteClaim)
 	}
 }
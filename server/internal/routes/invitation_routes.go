@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"veritas/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupInvitationRoutes sets up the invitation routes: their management by
+// organization admins, and the accept endpoints opened from invitation
+// links.
+func SetupInvitationRoutes(router *gin.Engine, handler *handlers.InvitationHandler, authMiddleware gin.HandlerFunc) {
+	invitationRoutes := router.Group("/orgs/:id/invitations")
+	invitationRoutes.Use(authMiddleware)
+	{
+		invitationRoutes.POST("", handler.CreateInvitation)
+		invitationRoutes.GET("", handler.GetInvitations)
+		invitationRoutes.DELETE("/:invitationId", handler.RevokeInvitation)
+	}
+
+	acceptRoutes := router.Group("/auth/invitations")
+	{
+		acceptRoutes.GET("/accept", handler.ReadInvitation)
+		acceptRoutes.POST("/accept", handler.AcceptInvitation)
+	}
+}
@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"veritas/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupMFARoutes sets up the MFA enrollment and administration routes.
+func SetupMFARoutes(router *gin.Engine, handler *handlers.MFAHandler, authMiddleware gin.HandlerFunc, adminMiddleware gin.HandlerFunc) {
+	meRoutes := router.Group("/me/mfa")
+	meRoutes.Use(authMiddleware)
+	{
+		meRoutes.POST("/totp", handler.EnrollTOTP)
+		meRoutes.POST("/totp/confirm", handler.ConfirmTOTP)
+	}
+
+	phoneRoutes := router.Group("/me/phone")
+	phoneRoutes.Use(authMiddleware)
+	{
+		phoneRoutes.PUT("", handler.SetPhone)
+		phoneRoutes.POST("/verify", handler.VerifyPhone)
+		phoneRoutes.DELETE("", handler.RemovePhone)
+	}
+
+	adminRoutes := router.Group("/users")
+	adminRoutes.Use(authMiddleware, adminMiddleware)
+	{
+		adminRoutes.DELETE("/:id/mfa", handler.ResetMFA)
+	}
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"veritas/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupOrganizationRoutes sets up the organization routes.
+func SetupOrganizationRoutes(router *gin.Engine, handler *handlers.OrganizationHandler, authMiddleware gin.HandlerFunc) {
+	organizationRoutes := router.Group("/orgs")
+	organizationRoutes.Use(authMiddleware)
+	{
+		organizationRoutes.POST("", handler.CreateOrganization)
+		organizationRoutes.GET("", handler.GetAllOrganizations)
+		organizationRoutes.GET("/current", handler.GetCurrentOrganization)
+		organizationRoutes.GET("/:id", handler.GetOrganization)
+		organizationRoutes.PUT("/:id", handler.UpdateOrganization)
+		organizationRoutes.POST("/:id/admins", handler.AddOrganizationAdmin)
+		organizationRoutes.DELETE("/:id/admins/:userId", handler.RemoveOrganizationAdmin)
+	}
+}
@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"veritas/internal/handlers"
+	"veritas/internal/middleware"
+	"veritas/internal/web"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupPageRoutes sets up the hosted pages under /ui. Their paths mirror the
+// links in the emails sent by the server, so that setting APP_BASE_URL to
+// the /ui prefix opens them from those links.
+func SetupPageRoutes(router *gin.Engine, handler *handlers.PageHandler, authMiddleware gin.HandlerFunc) {
+	pageRoutes := router.Group("/ui")
+	pageRoutes.Use(middleware.SecureHeaders())
+	{
+		pageRoutes.StaticFS("/static", web.Static())
+		pageRoutes.GET("/auth/login", handler.LoginPage)
+		pageRoutes.POST("/auth/login", handler.Login)
+		pageRoutes.GET("/auth/login/mfa", handler.MFAPage)
+		pageRoutes.POST("/auth/login/mfa", handler.MFA)
+		pageRoutes.POST("/auth/login/sms", handler.SendSMSCode)
+		pageRoutes.GET("/auth/signup", handler.SignUpPage)
+		pageRoutes.POST("/auth/signup", handler.SignUp)
+		pageRoutes.GET("/auth/verify-email", handler.VerifyEmail)
+		pageRoutes.GET("/auth/password/forgot", handler.ForgotPasswordPage)
+		pageRoutes.POST("/auth/password/forgot", handler.ForgotPassword)
+		pageRoutes.GET("/auth/password/reset", handler.ResetPasswordPage)
+		pageRoutes.POST("/auth/password/reset", handler.ResetPassword)
+		pageRoutes.GET("/auth/invitations/accept", handler.InvitationPage)
+		pageRoutes.POST("/auth/invitations/accept", handler.AcceptInvitation)
+	}
+
+	accountRoutes := pageRoutes.Group("/account")
+	accountRoutes.Use(handler.RequireLogin, authMiddleware)
+	{
+		accountRoutes.GET("", handler.AccountPage)
+		accountRoutes.POST("/sessions/:id/revoke", handler.RevokeSession)
+		accountRoutes.POST("/logout", handler.Logout)
+	}
+}
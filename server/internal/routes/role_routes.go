@@ -2,20 +2,19 @@ package routes
 
 import (
 	"veritas/internal/handlers"
-	"veritas/internal/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
 // SetupRoleRoutes sets up the role routes.
-func SetupRoleRoutes(router *gin.Engine, handler *handlers.RoleHandler) {
+func SetupRoleRoutes(router *gin.Engine, handler *handlers.RoleHandler, authMiddleware gin.HandlerFunc, adminMiddleware gin.HandlerFunc) {
 	roleRoutes := router.Group("/roles")
-	roleRoutes.Use(middleware.AuthMiddleware())
+	roleRoutes.Use(authMiddleware)
 	{
-		roleRoutes.POST("", handler.CreateRole)
+		roleRoutes.POST("", adminMiddleware, handler.CreateRole)
 		roleRoutes.GET("", handler.GetAllRoles)
 		roleRoutes.GET("/:id", handler.GetRole)
-		roleRoutes.PUT("/:id", handler.UpdateRole)
-		roleRoutes.DELETE("/:id", handler.DeleteRole)
+		roleRoutes.PUT("/:id", adminMiddleware, handler.UpdateRole)
+		roleRoutes.DELETE("/:id", adminMiddleware, handler.DeleteRole)
 	}
 }
@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"veritas/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupSessionRoutes sets up the session listing and sign-out routes.
+func SetupSessionRoutes(router *gin.Engine, handler *handlers.SessionHandler, authMiddleware gin.HandlerFunc, adminMiddleware gin.HandlerFunc) {
+	authRoutes := router.Group("/auth")
+	authRoutes.Use(authMiddleware)
+	{
+		authRoutes.POST("/logout", handler.Logout)
+	}
+
+	meRoutes := router.Group("/me/sessions")
+	meRoutes.Use(authMiddleware)
+	{
+		meRoutes.GET("", handler.GetMySessions)
+		meRoutes.DELETE("/:id", handler.DeleteMySession)
+	}
+
+	adminRoutes := router.Group("/users")
+	adminRoutes.Use(authMiddleware, adminMiddleware)
+	{
+		adminRoutes.GET("/:id/sessions", handler.GetUserSessions)
+		adminRoutes.DELETE("/:id/sessions", handler.DeleteUserSessions)
+		adminRoutes.DELETE("/:id/sessions/:sessionId", handler.DeleteUserSession)
+	}
+}
@@ -2,19 +2,34 @@ package routes
 
 import (
 	"veritas/internal/handlers"
-	"veritas/internal/middleware"
 
 	"github.com/gin-gonic/gin"
 )
 
 // SetupUserRoutes sets up the user routes.
-func SetupUserRoutes(router *gin.Engine, handler *handlers.UserHandler) {
+func SetupUserRoutes(router *gin.Engine, handler *handlers.UserHandler, authMiddleware gin.HandlerFunc, adminMiddleware gin.HandlerFunc) {
 	userRoutes := router.Group("/users")
-	userRoutes.Use(middleware.AuthMiddleware())
+	userRoutes.Use(authMiddleware)
 	{
 		userRoutes.GET("", handler.GetAllUsers)
 		userRoutes.GET("/:id", handler.GetUser)
-		userRoutes.PUT("/:id", handler.UpdateUser)
-		userRoutes.DELETE("/:id", handler.DeleteUser)
+	}
+
+	adminRoutes := router.Group("/users")
+	adminRoutes.Use(authMiddleware, adminMiddleware)
+	{
+		adminRoutes.PUT("/:id", handler.UpdateUser)
+		adminRoutes.DELETE("/:id", handler.DeleteUser)
+		adminRoutes.POST("/:id/unlock", handler.UnlockUser)
+		adminRoutes.GET("/:id/status", handler.GetUserStatus)
+		adminRoutes.PUT("/:id/status", handler.SetUserStatus)
+		adminRoutes.POST("/:id/suspend", handler.SuspendUser)
+		adminRoutes.POST("/:id/reactivate", handler.ReactivateUser)
+	}
+
+	meRoutes := router.Group("/me")
+	meRoutes.Use(authMiddleware)
+	{
+		meRoutes.PUT("/password", handler.ChangePassword)
 	}
 }
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"veritas/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupWebAuthnRoutes sets up the passkey management routes.
+func SetupWebAuthnRoutes(router *gin.Engine, handler *handlers.WebAuthnHandler, authMiddleware gin.HandlerFunc) {
+	credentialRoutes := router.Group("/me/credentials")
+	credentialRoutes.Use(authMiddleware)
+	{
+		credentialRoutes.GET("", handler.GetCredentials)
+		credentialRoutes.POST("/webauthn/begin", handler.BeginRegistration)
+		credentialRoutes.POST("/webauthn/finish", handler.FinishRegistration)
+		credentialRoutes.PATCH("/:id", handler.UpdateCredential)
+		credentialRoutes.DELETE("/:id", handler.DeleteCredential)
+	}
+}
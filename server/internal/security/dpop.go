@@ -0,0 +1,178 @@
+package security
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"veritas/config"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const (
+	// DPoPHeader is the request header carrying a DPoP proof (RFC 9449).
+	DPoPHeader = "DPoP"
+
+	dpopProofType     = "dpop+jwt"
+	dpopProofLifetime = 5 * time.Minute
+	dpopClockSkew     = 30 * time.Second
+)
+
+// dpopSigningMethods are the asymmetric algorithms accepted for DPoP proofs.
+var dpopSigningMethods = []string{"ES256", "ES384", "ES512", "RS256", "RS384", "RS512", "PS256", "PS384", "PS512"}
+
+var dpopReplayCache = newReplayCache()
+
+// dpopClaims are the claims of a DPoP proof JWT. Time checks are done by
+// VerifyDPoPProof itself so that iat can be validated with clock skew.
+type dpopClaims struct {
+	JTI string `json:"jti"`
+	HTM string `json:"htm"`
+	HTU string `json:"htu"`
+	IAT int64  `json:"iat"`
+	ATH string `json:"ath,omitempty"`
+}
+
+func (dpopClaims) Valid() error {
+	return nil
+}
+
+// VerifyDPoPProof validates the DPoP proof sent with the request and returns
+// the JWK SHA-256 thumbprint of the key that signed it. When accessToken is
+// not empty the proof must also carry a matching ath claim.
+func VerifyDPoPProof(r *http.Request, accessToken string) (string, error) {
+	proofs := r.Header.Values(DPoPHeader)
+	if len(proofs) != 1 || proofs[0] == "" {
+		return "", fmt.Errorf("exactly one DPoP proof is required")
+	}
+
+	var jwk map[string]interface{}
+	claims := &dpopClaims{}
+	parser := &jwt.Parser{ValidMethods: dpopSigningMethods}
+	_, err := parser.ParseWithClaims(proofs[0], claims, func(token *jwt.Token) (interface{}, error) {
+		if typ, _ := token.Header["typ"].(string); typ != dpopProofType {
+			return nil, fmt.Errorf("unexpected proof type")
+		}
+		header, ok := token.Header["jwk"].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("missing jwk header")
+		}
+		jwk = header
+		return ParsePublicJWK(jwk)
+	})
+	if err != nil {
+		return "", fmt.Errorf("invalid DPoP proof: %w", err)
+	}
+
+	now := time.Now()
+	issuedAt := time.Unix(claims.IAT, 0)
+	if claims.IAT == 0 || issuedAt.After(now.Add(dpopClockSkew)) || issuedAt.Before(now.Add(-dpopProofLifetime)) {
+		return "", fmt.Errorf("DPoP proof is expired or not yet valid")
+	}
+	if claims.HTM != r.Method {
+		return "", fmt.Errorf("DPoP proof htm does not match request method")
+	}
+	if !sameHTU(claims.HTU, requestHTU(r)) {
+		return "", fmt.Errorf("DPoP proof htu does not match request URI")
+	}
+	if accessToken != "" {
+		sum := sha256.Sum256([]byte(accessToken))
+		if claims.ATH != base64.RawURLEncoding.EncodeToString(sum[:]) {
+			return "", fmt.Errorf("DPoP proof ath does not match access token")
+		}
+	}
+	if claims.JTI == "" || !dpopReplayCache.add(claims.JTI, issuedAt.Add(dpopProofLifetime+dpopClockSkew)) {
+		return "", fmt.Errorf("DPoP proof has already been used")
+	}
+
+	return JWKThumbprint(jwk)
+}
+
+// requestHTU rebuilds the URI a client would have signed for this request,
+// without query and fragment. X-Forwarded-Proto is only believed when the
+// request comes from one of the trusted proxies, so that clients cannot
+// present a proof made for another scheme.
+func requestHTU(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	} else if proto := r.Header.Get("X-Forwarded-Proto"); (proto == "http" || proto == "https") && fromTrustedProxy(r) {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host + r.URL.Path
+}
+
+// fromTrustedProxy reports whether the peer of r is one of TRUSTED_PROXIES.
+func fromTrustedProxy(r *http.Request) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, proxy := range config.GetTrustedProxies() {
+		if _, network, err := net.ParseCIDR(proxy); err == nil {
+			if network.Contains(ip) {
+				return true
+			}
+		} else if ip.Equal(net.ParseIP(proxy)) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameHTU compares scheme and host case-insensitively and the path exactly,
+// ignoring any query or fragment on the claimed URI.
+func sameHTU(claimed, actual string) bool {
+	c, err := url.Parse(claimed)
+	if err != nil {
+		return false
+	}
+	a, err := url.Parse(actual)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(c.Scheme, a.Scheme) && strings.EqualFold(c.Host, a.Host) && c.Path == a.Path
+}
+
+// replayCache remembers identifiers until they expire.
+type replayCache struct {
+	mu      sync.Mutex
+	entries map[string]time.Time
+	sweepAt time.Time
+}
+
+func newReplayCache() *replayCache {
+	return &replayCache{entries: make(map[string]time.Time)}
+}
+
+// add records id and reports whether it had not been seen before.
+func (c *replayCache) add(id string, expiresAt time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if now.After(c.sweepAt) {
+		for key, exp := range c.entries {
+			if now.After(exp) {
+				delete(c.entries, key)
+			}
+		}
+		c.sweepAt = now.Add(time.Minute)
+	}
+
+	if exp, ok := c.entries[id]; ok && now.Before(exp) {
+		return false
+	}
+	c.entries[id] = expiresAt
+	return true
+}
@@ -0,0 +1,121 @@
+package security_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"veritas/internal/security"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/suite"
+)
+
+type DPoPTestSuite struct {
+	suite.Suite
+	key *ecdsa.PrivateKey
+	jwk map[string]interface{}
+}
+
+func (s *DPoPTestSuite) SetupTest() {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	s.key = key
+	s.jwk = map[string]interface{}{
+		"kty": "EC",
+		"crv": "P-256",
+		"x":   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+		"y":   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+	}
+}
+
+func (s *DPoPTestSuite) proof(method, htu, accessToken string) string {
+	jti := make([]byte, 16)
+	_, err := rand.Read(jti)
+	s.Require().NoError(err)
+
+	claims := jwt.MapClaims{
+		"jti": base64.RawURLEncoding.EncodeToString(jti),
+		"htm": method,
+		"htu": htu,
+		"iat": time.Now().Unix(),
+	}
+	if accessToken != "" {
+		sum := sha256.Sum256([]byte(accessToken))
+		claims["ath"] = base64.RawURLEncoding.EncodeToString(sum[:])
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["typ"] = "dpop+jwt"
+	token.Header["jwk"] = s.jwk
+	signed, err := token.SignedString(s.key)
+	s.Require().NoError(err)
+	return signed
+}
+
+func (s *DPoPTestSuite) TestVerifyDPoPProof() {
+	expectedJKT, err := security.JWKThumbprint(s.jwk)
+	s.Require().NoError(err)
+
+	// Test case 1: Valid proof returns the key thumbprint
+	req := httptest.NewRequest("POST", "http://example.com/auth/login?x=1", nil)
+	proof := s.proof("POST", "http://example.com/auth/login", "")
+	req.Header.Set(security.DPoPHeader, proof)
+	jkt, err := security.VerifyDPoPProof(req, "")
+	s.NoError(err)
+	s.Equal(expectedJKT, jkt)
+
+	// Test case 2: Replayed proof is rejected
+	_, err = security.VerifyDPoPProof(req, "")
+	s.Error(err)
+
+	// Test case 3: Method mismatch
+	req = httptest.NewRequest("GET", "http://example.com/auth/login", nil)
+	req.Header.Set(security.DPoPHeader, s.proof("POST", "http://example.com/auth/login", ""))
+	_, err = security.VerifyDPoPProof(req, "")
+	s.Error(err)
+
+	// Test case 4: Access token hash must match
+	req = httptest.NewRequest("GET", "http://example.com/users", nil)
+	req.Header.Set(security.DPoPHeader, s.proof("GET", "http://example.com/users", "token-a"))
+	_, err = security.VerifyDPoPProof(req, "token-b")
+	s.Error(err)
+
+	req = httptest.NewRequest("GET", "http://example.com/users", nil)
+	req.Header.Set(security.DPoPHeader, s.proof("GET", "http://example.com/users", "token-a"))
+	_, err = security.VerifyDPoPProof(req, "token-a")
+	s.NoError(err)
+
+	// Test case 5: X-Forwarded-Proto is only believed from trusted proxies
+	req = httptest.NewRequest("POST", "http://example.com/auth/login", nil)
+	req.Header.Set("X-Forwarded-Proto", "https")
+	req.Header.Set(security.DPoPHeader, s.proof("POST", "https://example.com/auth/login", ""))
+	_, err = security.VerifyDPoPProof(req, "")
+	s.Error(err)
+
+	s.T().Setenv("TRUSTED_PROXIES", "192.0.2.0/24")
+	req.Header.Set(security.DPoPHeader, s.proof("POST", "https://example.com/auth/login", ""))
+	_, err = security.VerifyDPoPProof(req, "")
+	s.NoError(err)
+}
+
+func (s *DPoPTestSuite) TestJWKThumbprint() {
+	// RFC 7638 section 3.1 example key
+	jwk := map[string]interface{}{
+		"kty": "RSA",
+		"n":   "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+		"e":   "AQAB",
+		"alg": "RS256",
+		"kid": "2011-04-29",
+	}
+	thumbprint, err := security.JWKThumbprint(jwk)
+	s.NoError(err)
+	s.Equal("NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", thumbprint)
+}
+
+func TestDPoPSuite(t *testing.T) {
+	suite.Run(t, new(DPoPTestSuite))
+}
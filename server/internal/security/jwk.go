@@ -0,0 +1,107 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// ParsePublicJWK converts a public JSON Web Key into an *ecdsa.PublicKey or
+// *rsa.PublicKey. Keys containing private material are rejected.
+func ParsePublicJWK(jwk map[string]interface{}) (crypto.PublicKey, error) {
+	if _, ok := jwk["d"]; ok {
+		return nil, fmt.Errorf("jwk must not contain private key material")
+	}
+
+	switch jwkString(jwk, "kty") {
+	case "EC":
+		var curve elliptic.Curve
+		switch jwkString(jwk, "crv") {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported jwk curve")
+		}
+		x, err := jwkInt(jwk, "x")
+		if err != nil {
+			return nil, err
+		}
+		y, err := jwkInt(jwk, "y")
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("jwk point is not on curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "RSA":
+		n, err := jwkInt(jwk, "n")
+		if err != nil {
+			return nil, err
+		}
+		e, err := jwkInt(jwk, "e")
+		if err != nil {
+			return nil, err
+		}
+		if n.BitLen() < 2048 || !e.IsInt64() {
+			return nil, fmt.Errorf("unsupported rsa jwk")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	default:
+		return nil, fmt.Errorf("unsupported jwk key type")
+	}
+}
+
+// JWKThumbprint computes the base64url encoded SHA-256 thumbprint of a public
+// key as defined in RFC 7638.
+func JWKThumbprint(jwk map[string]interface{}) (string, error) {
+	var members []string
+	switch jwkString(jwk, "kty") {
+	case "EC":
+		members = []string{"crv", "kty", "x", "y"}
+	case "RSA":
+		members = []string{"e", "kty", "n"}
+	default:
+		return "", fmt.Errorf("unsupported jwk key type")
+	}
+
+	// encoding/json sorts map keys, which yields the required member order.
+	required := make(map[string]string, len(members))
+	for _, member := range members {
+		value := jwkString(jwk, member)
+		if value == "" {
+			return "", fmt.Errorf("jwk is missing %q", member)
+		}
+		required[member] = value
+	}
+	canonical, err := json.Marshal(required)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(canonical)
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+func jwkString(jwk map[string]interface{}, member string) string {
+	value, _ := jwk[member].(string)
+	return value
+}
+
+func jwkInt(jwk map[string]interface{}, member string) (*big.Int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(jwkString(jwk, member))
+	if err != nil || len(raw) == 0 {
+		return nil, fmt.Errorf("invalid jwk member %q", member)
+	}
+	return new(big.Int).SetBytes(raw), nil
+}
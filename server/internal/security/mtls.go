@@ -0,0 +1,43 @@
+package security
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+)
+
+// CertificateThumbprintClaim is the cnf member binding a token to a TLS
+// client certificate (RFC 8705).
+const CertificateThumbprintClaim = "x5t#S256"
+
+// CertificateThumbprint returns the base64url encoded SHA-256 hash of the
+// DER encoding of cert.
+func CertificateThumbprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// VerifiedClientCertificate returns the leaf certificate the client
+// authenticated with during the TLS handshake, or nil when the request did
+// not come over mutual TLS with a certificate chaining to a trusted CA.
+func VerifiedClientCertificate(r *http.Request) *x509.Certificate {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return r.TLS.VerifiedChains[0][0]
+}
+
+// VerifyCertificateBinding checks that the request was made with the client
+// certificate whose thumbprint a token was bound to.
+func VerifyCertificateBinding(r *http.Request, thumbprint string) error {
+	cert := VerifiedClientCertificate(r)
+	if cert == nil {
+		return fmt.Errorf("client certificate is required")
+	}
+	if CertificateThumbprint(cert) != thumbprint {
+		return fmt.Errorf("client certificate does not match token binding")
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package security_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"veritas/internal/security"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MTLSTestSuite struct {
+	suite.Suite
+	cert  *x509.Certificate
+	other *x509.Certificate
+}
+
+func (s *MTLSTestSuite) SetupTest() {
+	s.cert = s.certificate("client")
+	s.other = s.certificate("other-client")
+}
+
+func (s *MTLSTestSuite) certificate(commonName string) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	s.Require().NoError(err)
+	cert, err := x509.ParseCertificate(der)
+	s.Require().NoError(err)
+	return cert
+}
+
+// request returns a request made over mutual TLS with cert, or over plain
+// TLS when cert is nil.
+func (s *MTLSTestSuite) request(cert *x509.Certificate) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "https://id.example.com/me", nil)
+	if cert != nil {
+		r.TLS.PeerCertificates = []*x509.Certificate{cert}
+		r.TLS.VerifiedChains = [][]*x509.Certificate{{cert}}
+	}
+	return r
+}
+
+// Test case 1: The certificate the token is bound to is accepted
+func (s *MTLSTestSuite) TestMatchingCertificate() {
+	r := s.request(s.cert)
+	s.Equal(s.cert, security.VerifiedClientCertificate(r))
+	s.NoError(security.VerifyCertificateBinding(r, security.CertificateThumbprint(s.cert)))
+}
+
+// Test case 2: Another trusted certificate is rejected
+func (s *MTLSTestSuite) TestMismatchedCertificate() {
+	r := s.request(s.other)
+	s.Equal(s.other, security.VerifiedClientCertificate(r))
+	s.EqualError(security.VerifyCertificateBinding(r, security.CertificateThumbprint(s.cert)), "client certificate does not match token binding")
+}
+
+// Test case 3: Requests without a verified certificate are rejected
+func (s *MTLSTestSuite) TestNoCertificate() {
+	thumbprint := security.CertificateThumbprint(s.cert)
+
+	r := s.request(nil)
+	s.Nil(security.VerifiedClientCertificate(r))
+	s.EqualError(security.VerifyCertificateBinding(r, thumbprint), "client certificate is required")
+
+	// A certificate that did not chain to a trusted CA is not verified.
+	r.TLS.PeerCertificates = []*x509.Certificate{s.cert}
+	s.Nil(security.VerifiedClientCertificate(r))
+	s.Error(security.VerifyCertificateBinding(r, thumbprint))
+
+	r = httptest.NewRequest(http.MethodGet, "http://id.example.com/me", nil)
+	s.Nil(r.TLS)
+	s.Error(security.VerifyCertificateBinding(r, thumbprint))
+
+	r.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{}}}
+	s.Nil(security.VerifiedClientCertificate(r))
+}
+
+func TestMTLSSuite(t *testing.T) {
+	suite.Run(t, new(MTLSTestSuite))
+}
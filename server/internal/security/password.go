@@ -0,0 +1,52 @@
+package security
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var ErrPasswordPolicy = errors.New("password does not meet the policy")
+
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return string(hash), nil
+}
+
+// IsPasswordHash reports whether a stored password is a bcrypt hash rather
+// than a legacy plaintext value.
+func IsPasswordHash(stored string) bool {
+	return strings.HasPrefix(stored, "$2")
+}
+
+// CheckPassword compares a password with the stored value. Accounts created
+// before passwords were hashed still hold plaintext, which is compared in
+// constant time so it can be rehashed after a successful login.
+func CheckPassword(stored, password string) bool {
+	if !IsPasswordHash(stored) {
+		return stored != "" && subtle.ConstantTimeCompare([]byte(stored), []byte(password)) == 1
+	}
+	return bcrypt.CompareHashAndPassword([]byte(stored), []byte(password)) == nil
+}
+
+var (
+	dummyHashOnce sync.Once
+	dummyHash     []byte
+)
+
+// SimulatePasswordCheck spends the same time as checking a password against
+// a bcrypt hash. It is used when there is no account to check, so response
+// times do not reveal which email addresses are registered.
+func SimulatePasswordCheck(password string) {
+	dummyHashOnce.Do(func() {
+		dummyHash, _ = bcrypt.GenerateFromPassword([]byte("veritas-dummy-password"), bcrypt.DefaultCost)
+	})
+	bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+}
@@ -0,0 +1,152 @@
+package security
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// bcrypt ignores everything past 72 bytes.
+	maxPasswordBytes = 72
+	// Shorter parts of names and addresses are too common to reject.
+	minPersonalInfoLength = 3
+)
+
+// Codes of the rules a password can break.
+const (
+	PasswordTooShort      = "too_short"
+	PasswordTooLong       = "too_long"
+	PasswordMissingUpper  = "missing_uppercase"
+	PasswordMissingLower  = "missing_lowercase"
+	PasswordMissingDigit  = "missing_digit"
+	PasswordMissingSymbol = "missing_symbol"
+	PasswordTooWeak       = "too_weak"
+	PasswordPersonalInfo  = "contains_personal_info"
+	PasswordBreached      = "breached"
+	PasswordRecentlyUsed  = "recently_used"
+)
+
+// PasswordPolicy describes which passwords are accepted. MinStrength is a
+// PasswordStrength score from 0 to 4. HistorySize is how many of the user's
+// most recent passwords, the current one included, may not be reused.
+type PasswordPolicy struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	MinStrength   int
+	HistorySize   int
+}
+
+// DefaultPasswordPolicy asks for length and strength rather than character
+// classes, which mostly lead to predictable substitutions.
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{MinLength: 8, MinStrength: 2, HistorySize: 5}
+}
+
+type PasswordViolation struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// PasswordPolicyError lists every rule a rejected password breaks. It
+// matches ErrPasswordPolicy with errors.Is.
+type PasswordPolicyError struct {
+	Violations []PasswordViolation
+}
+
+func (e *PasswordPolicyError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		messages[i] = violation.Message
+	}
+	return ErrPasswordPolicy.Error() + ": " + strings.Join(messages, "; ")
+}
+
+func (e *PasswordPolicyError) Unwrap() error {
+	return ErrPasswordPolicy
+}
+
+// Check returns the rules the password breaks that can be decided from the
+// password alone. personal holds the user's name, email address and other
+// values the password should not be built from.
+func (p PasswordPolicy) Check(password string, personal ...string) []PasswordViolation {
+	var violations []PasswordViolation
+	add := func(code, message string) {
+		violations = append(violations, PasswordViolation{Code: code, Message: message})
+	}
+
+	// An over-long password is rejected outright, before the work of scoring
+	// input of any size.
+	if len(password) > maxPasswordBytes {
+		add(PasswordTooLong, fmt.Sprintf("must be at most %d bytes", maxPasswordBytes))
+		return violations
+	}
+	if utf8.RuneCountInString(password) < p.MinLength {
+		add(PasswordTooShort, fmt.Sprintf("must be at least %d characters", p.MinLength))
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case !unicode.IsLetter(r):
+			symbol = true
+		}
+	}
+	if p.RequireUpper && !upper {
+		add(PasswordMissingUpper, "must contain an uppercase letter")
+	}
+	if p.RequireLower && !lower {
+		add(PasswordMissingLower, "must contain a lowercase letter")
+	}
+	if p.RequireDigit && !digit {
+		add(PasswordMissingDigit, "must contain a digit")
+	}
+	if p.RequireSymbol && !symbol {
+		add(PasswordMissingSymbol, "must contain a symbol")
+	}
+
+	words := personalWords(personal)
+	lowered := strings.ToLower(password)
+	for _, word := range words {
+		if strings.Contains(lowered, word) {
+			add(PasswordPersonalInfo, "must not contain your name or email address")
+			break
+		}
+	}
+
+	if PasswordStrength(password, words...) < p.MinStrength {
+		add(PasswordTooWeak, "is too easy to guess")
+	}
+	return violations
+}
+
+// personalWords splits names and email addresses into the lowercase words
+// long enough to be worth rejecting.
+func personalWords(values []string) []string {
+	var words []string
+	for _, value := range values {
+		value = strings.ToLower(value)
+		if local, _, ok := strings.Cut(value, "@"); ok {
+			words = append(words, local)
+			value = local
+		}
+		for _, field := range strings.FieldsFunc(value, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			if utf8.RuneCountInString(field) >= minPersonalInfoLength {
+				words = append(words, field)
+			}
+		}
+	}
+	return words
+}
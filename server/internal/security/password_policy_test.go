@@ -0,0 +1,54 @@
+package security_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"veritas/internal/security"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func violationCodes(violations []security.PasswordViolation) []string {
+	codes := make([]string, len(violations))
+	for i, violation := range violations {
+		codes[i] = violation.Code
+	}
+	return codes
+}
+
+func TestPasswordPolicyCheck(t *testing.T) {
+	policy := security.DefaultPasswordPolicy()
+
+	assert.Empty(t, policy.Check("lantern-orbit-42", "Jane Doe", "jane@example.com"))
+	assert.Equal(t, []string{security.PasswordTooShort, security.PasswordTooWeak}, violationCodes(policy.Check("abc")))
+	assert.Contains(t, violationCodes(policy.Check("password123")), security.PasswordTooWeak)
+	assert.Equal(t, []string{security.PasswordTooLong}, violationCodes(policy.Check(strings.Repeat("lantern-orbit-42", 500))))
+	assert.Contains(t, violationCodes(policy.Check("zebra-janedoe-77", "Jane Doe", "janedoe@example.com")), security.PasswordPersonalInfo)
+
+	policy.RequireUpper = true
+	policy.RequireDigit = true
+	policy.RequireSymbol = true
+	assert.Equal(t, []string{security.PasswordMissingUpper, security.PasswordMissingDigit, security.PasswordMissingSymbol},
+		violationCodes(policy.Check("lanternorbitcanyon")))
+	assert.Empty(t, policy.Check("Lantern-orbit-42"))
+}
+
+func TestPasswordPolicyError(t *testing.T) {
+	err := error(&security.PasswordPolicyError{Violations: []security.PasswordViolation{{Code: security.PasswordTooShort, Message: "must be at least 8 characters"}}})
+	assert.True(t, errors.Is(err, security.ErrPasswordPolicy))
+	assert.Equal(t, "password does not meet the policy: must be at least 8 characters", err.Error())
+}
+
+func TestPasswordStrength(t *testing.T) {
+	weak := []string{"password", "P@ssw0rd!", "qwertyuiop", "aaaaaaaaaaaa", "abcdef123456", "summer2024!"}
+	for _, password := range weak {
+		assert.Less(t, security.PasswordStrength(password), 2, password)
+	}
+	strong := []string{"correct-horse-battery", "kX9#mQ2$vL", "lantern-orbit-42"}
+	for _, password := range strong {
+		assert.GreaterOrEqual(t, security.PasswordStrength(password), 3, password)
+	}
+	assert.Less(t, security.PasswordStrength("janedoe1985", "janedoe"), security.PasswordStrength("janedoe1985"))
+	assert.Equal(t, 4, security.PasswordStrength(strings.Repeat("kX9#mQ2$vL", 1000)))
+}
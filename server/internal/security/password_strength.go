@@ -0,0 +1,195 @@
+package security
+
+import (
+	_ "embed"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// commonPasswordList holds frequently used passwords and words, most common
+// first.
+//
+//go:embed common_passwords.txt
+var commonPasswordList string
+
+var commonPasswords = rankWords(strings.Fields(commonPasswordList))
+
+var keyboardRows = []string{"`1234567890-=", "qwertyuiop[]\\", "asdfghjkl;'", "zxcvbnm,./"}
+
+var leetSubstitutions = strings.NewReplacer("4", "a", "@", "a", "8", "b", "3", "e", "6", "g", "1", "i", "!", "i", "0", "o", "5", "s", "$", "s", "7", "t", "2", "z")
+
+const (
+	// Guesses per character when nothing better describes a part of the
+	// password.
+	bruteforceCardinality = 10
+	minSingleGuesses      = 10
+	minMultiGuesses       = 50
+	// Years from 1900 to 2099 are guessed before other four digit numbers.
+	yearSpace = 200
+	// Longest part the password is split into, which bounds the work of
+	// scoring to linear in its length.
+	maxSegmentLength = 32
+)
+
+// Guess counts separating the strength scores.
+var strengthThresholds = []float64{1e3, 1e6, 1e8, 1e10}
+
+// PasswordStrength scores how hard a password is to guess from 0 (trivial)
+// to 4 (very strong), in the manner of zxcvbn. The password is split into
+// the parts that are cheapest to guess: common words, userInputs, keyboard
+// runs, sequences, repeats and single characters. The score follows from
+// the product of the guesses needed for each part.
+func PasswordStrength(password string, userInputs ...string) int {
+	runes := []rune(password)
+	dictionary := commonPasswords
+	if len(userInputs) > 0 {
+		dictionary = make(map[string]int, len(commonPasswords)+len(userInputs))
+		for word, rank := range commonPasswords {
+			dictionary[word] = rank
+		}
+		for _, word := range userInputs {
+			dictionary[strings.ToLower(word)] = 1
+		}
+	}
+
+	// guesses[i] is the fewest guesses needed for the first i runes.
+	guesses := make([]float64, len(runes)+1)
+	guesses[0] = 1
+	for end := 1; end <= len(runes); end++ {
+		guesses[end] = math.Inf(1)
+		for start := max(0, end-maxSegmentLength); start < end; start++ {
+			total := guesses[start] * segmentGuesses(runes[start:end], dictionary)
+			if total < guesses[end] {
+				guesses[end] = total
+			}
+		}
+	}
+
+	score := 0
+	for _, threshold := range strengthThresholds {
+		if guesses[len(runes)] >= threshold {
+			score++
+		}
+	}
+	return score
+}
+
+func segmentGuesses(segment []rune, dictionary map[string]int) float64 {
+	if len(segment) == 1 {
+		return bruteforceCardinality
+	}
+
+	best := math.Pow(bruteforceCardinality, float64(len(segment)))
+	consider := func(guesses float64) {
+		guesses = math.Max(guesses, minMultiGuesses)
+		if guesses < best {
+			best = guesses
+		}
+	}
+
+	word := string(segment)
+	lowered := strings.ToLower(word)
+	variations := 1.0
+	if lowered != word {
+		variations = 2
+	}
+	if rank, ok := dictionary[lowered]; ok {
+		consider(float64(rank) * variations)
+	}
+	if unleeted := leetSubstitutions.Replace(lowered); unleeted != lowered {
+		if rank, ok := dictionary[unleeted]; ok {
+			consider(float64(rank) * variations * 2)
+		}
+	}
+
+	if len(segment) >= 3 {
+		if isRepeat(segment) {
+			consider(runeCardinality(segment[0]) * float64(len(segment)))
+		}
+		if descending, ok := isSequence(segment); ok {
+			base := runeCardinality(segment[0])
+			switch unicode.ToLower(segment[0]) {
+			case 'a', 'z', '0', '1', '9':
+				base = 4
+			}
+			if descending {
+				base *= 2
+			}
+			consider(base * float64(len(segment)))
+		}
+		if isKeyboardRun(lowered) {
+			consider(100 * float64(len(segment)))
+		}
+	}
+	if isRecentYear(word) {
+		consider(yearSpace)
+	}
+	return math.Max(best, minSingleGuesses)
+}
+
+func isRepeat(segment []rune) bool {
+	for _, r := range segment[1:] {
+		if r != segment[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// isSequence reports whether the runes step by one, such as "abc" or "987".
+func isSequence(segment []rune) (descending bool, ok bool) {
+	step := segment[1] - segment[0]
+	if step != 1 && step != -1 {
+		return false, false
+	}
+	for i := 2; i < len(segment); i++ {
+		if segment[i]-segment[i-1] != step {
+			return false, false
+		}
+	}
+	return step == -1, true
+}
+
+func isKeyboardRun(word string) bool {
+	for _, row := range keyboardRows {
+		if strings.Contains(row, word) || strings.Contains(reverse(row), word) {
+			return true
+		}
+	}
+	return false
+}
+
+func isRecentYear(word string) bool {
+	return len(word) == 4 && (strings.HasPrefix(word, "19") || strings.HasPrefix(word, "20")) &&
+		strings.IndexFunc(word, func(r rune) bool { return r < '0' || r > '9' }) == -1
+}
+
+func runeCardinality(r rune) float64 {
+	switch {
+	case unicode.IsDigit(r):
+		return 10
+	case unicode.IsLetter(r):
+		return 26
+	default:
+		return 33
+	}
+}
+
+func reverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
+
+func rankWords(words []string) map[string]int {
+	ranks := make(map[string]int, len(words))
+	for i, word := range words {
+		if _, ok := ranks[word]; !ok {
+			ranks[word] = i + 1
+		}
+	}
+	return ranks
+}
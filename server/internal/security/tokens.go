@@ -0,0 +1,76 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"time"
+	"veritas/config"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// Token uses distinguish the JWTs signed with the shared secret so that one
+// kind can never be accepted in place of another.
+const (
+	TokenUseAccess       = "access"
+	TokenUseMFAChallenge = "mfa_challenge"
+	TokenUseVerifyEmail  = "verify_email"
+	TokenUseInvitation   = "invitation"
+)
+
+// SignToken signs claims for the given use and lifetime.
+func SignToken(use string, claims jwt.MapClaims, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims["token_use"] = use
+	claims["iat"] = now.Unix()
+	claims["exp"] = now.Add(ttl).Unix()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(config.GetJWTSecret())
+}
+
+// ParseToken verifies the signature and expiry of a token and checks that it
+// was issued for the expected use.
+func ParseToken(tokenString, use string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	parser := &jwt.Parser{ValidMethods: []string{jwt.SigningMethodHS256.Alg()}}
+	token, err := parser.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return config.GetJWTSecret(), nil
+	})
+	if err != nil || !token.Valid {
+		return nil, fmt.Errorf("invalid token")
+	}
+	if tokenUse, _ := claims["token_use"].(string); tokenUse != use {
+		return nil, fmt.Errorf("invalid token")
+	}
+	return claims, nil
+}
+
+// IssuedBefore reports whether a token was issued at or before t, which
+// revokes tokens issued in the same second as the revocation.
+func IssuedBefore(claims map[string]interface{}, t time.Time) bool {
+	if t.IsZero() {
+		return false
+	}
+	iat, ok := claims["iat"].(float64)
+	if !ok {
+		return true
+	}
+	return int64(iat) <= t.Unix()
+}
+
+// CSRFToken derives the synchronizer token of a cookie session from its ID,
+// so it needs no storage and stops working when the session ends.
+func CSRFToken(sessionID string) string {
+	mac := hmac.New(sha256.New, config.GetJWTSecret())
+	mac.Write([]byte("csrf:" + sessionID))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyCSRFToken reports in constant time whether token belongs to the
+// session.
+func VerifyCSRFToken(sessionID, token string) bool {
+	return token != "" && hmac.Equal([]byte(token), []byte(CSRFToken(sessionID)))
+}
@@ -0,0 +1,46 @@
+package security_test
+
+import (
+	"testing"
+	"time"
+	"veritas/internal/security"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// RFC 6238 appendix B SHA-1 secret "12345678901234567890", base32 encoded.
+const rfcTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode(t *testing.T) {
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1234567890:  "005924",
+		20000000000: "353130",
+	}
+	for unix, expected := range vectors {
+		code, err := security.TOTPCode(rfcTOTPSecret, time.Unix(unix, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, code)
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+
+	// Test case 1: Current and adjacent periods are accepted
+	step, ok := security.ValidateTOTP(rfcTOTPSecret, "081804", now)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1111111109/30), step)
+
+	_, ok = security.ValidateTOTP(rfcTOTPSecret, "081804", now.Add(30*time.Second))
+	assert.True(t, ok)
+
+	// Test case 2: Codes outside the skew window are rejected
+	_, ok = security.ValidateTOTP(rfcTOTPSecret, "081804", now.Add(90*time.Second))
+	assert.False(t, ok)
+
+	// Test case 3: Malformed codes are rejected
+	_, ok = security.ValidateTOTP(rfcTOTPSecret, "81804", now)
+	assert.False(t, ok)
+}
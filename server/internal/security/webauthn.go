@@ -0,0 +1,463 @@
+package security
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// COSE algorithm identifiers accepted for credentials, in order of preference.
+const (
+	COSEAlgES256 = -7
+	COSEAlgEdDSA = -8
+	COSEAlgRS256 = -257
+)
+
+var WebAuthnAlgorithms = []int{COSEAlgES256, COSEAlgEdDSA, COSEAlgRS256}
+
+const (
+	authDataFlagUserPresent    = 0x01
+	authDataFlagUserVerified   = 0x04
+	authDataFlagBackupEligible = 0x08
+	authDataFlagBackupState    = 0x10
+	authDataFlagAttestedData   = 0x40
+	authDataFlagExtensionData  = 0x80
+)
+
+// WebAuthnConfig describes the relying party that ceremonies are verified for.
+type WebAuthnConfig struct {
+	RPID    string
+	RPName  string
+	Origins []string
+}
+
+// Base64URL is binary data carried as unpadded base64url in JSON, as in the
+// WebAuthn JSON serialization of options and responses.
+type Base64URL []byte
+
+func (b Base64URL) MarshalJSON() ([]byte, error) {
+	return json.Marshal(base64.RawURLEncoding.EncodeToString(b))
+}
+
+func (b *Base64URL) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+	if err != nil {
+		return fmt.Errorf("invalid base64url value: %w", err)
+	}
+	*b = decoded
+	return nil
+}
+
+type RelyingPartyEntity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type UserEntity struct {
+	ID          Base64URL `json:"id"`
+	Name        string    `json:"name"`
+	DisplayName string    `json:"displayName"`
+}
+
+type CredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int    `json:"alg"`
+}
+
+type CredentialDescriptor struct {
+	Type       string    `json:"type"`
+	ID         Base64URL `json:"id"`
+	Transports []string  `json:"transports,omitempty"`
+}
+
+type AuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// CredentialCreationOptions is the JSON form of
+// PublicKeyCredentialCreationOptions passed to navigator.credentials.create.
+type CredentialCreationOptions struct {
+	RP                     RelyingPartyEntity     `json:"rp"`
+	User                   UserEntity             `json:"user"`
+	Challenge              Base64URL              `json:"challenge"`
+	PubKeyCredParams       []CredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                  `json:"timeout"`
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials,omitempty"`
+	AuthenticatorSelection AuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                 `json:"attestation"`
+}
+
+// CredentialRequestOptions is the JSON form of
+// PublicKeyCredentialRequestOptions passed to navigator.credentials.get.
+type CredentialRequestOptions struct {
+	Challenge        Base64URL              `json:"challenge"`
+	Timeout          int64                  `json:"timeout"`
+	RPID             string                 `json:"rpId"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials,omitempty"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+// AttestedCredential is a credential whose registration has been verified.
+type AttestedCredential struct {
+	ID             []byte
+	PublicKey      []byte // COSE_Key
+	AAGUID         []byte
+	SignCount      uint32
+	UserVerified   bool
+	BackupEligible bool
+	BackupState    bool
+}
+
+// AssertionResult is the outcome of a verified authentication ceremony.
+type AssertionResult struct {
+	SignCount    uint32
+	UserVerified bool
+	BackupState  bool
+}
+
+type collectedClientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin"`
+}
+
+type authenticatorData struct {
+	rpIDHash     []byte
+	flags        byte
+	signCount    uint32
+	aaguid       []byte
+	credentialID []byte
+	publicKey    []byte
+}
+
+type attestationObject struct {
+	Fmt      string                     `cbor:"fmt"`
+	AttStmt  map[string]cbor.RawMessage `cbor:"attStmt"`
+	AuthData []byte                     `cbor:"authData"`
+}
+
+// ClientDataChallenge extracts the challenge from clientDataJSON so the
+// stored ceremony it belongs to can be looked up before verification.
+func ClientDataChallenge(clientDataJSON []byte) (string, error) {
+	var clientData collectedClientData
+	if err := json.Unmarshal(clientDataJSON, &clientData); err != nil {
+		return "", fmt.Errorf("malformed client data: %w", err)
+	}
+	if clientData.Challenge == "" {
+		return "", fmt.Errorf("client data has no challenge")
+	}
+	return clientData.Challenge, nil
+}
+
+// VerifyRegistration checks a navigator.credentials.create response against
+// the challenge that was issued and returns the new credential.
+func (cfg WebAuthnConfig) VerifyRegistration(clientDataJSON, attestationObj []byte, challenge string, requireUV bool) (*AttestedCredential, error) {
+	if err := cfg.verifyClientData(clientDataJSON, "webauthn.create", challenge); err != nil {
+		return nil, err
+	}
+
+	var attestation attestationObject
+	if err := cbor.Unmarshal(attestationObj, &attestation); err != nil {
+		return nil, fmt.Errorf("malformed attestation object: %w", err)
+	}
+
+	authData, err := parseAuthenticatorData(attestation.AuthData)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.verifyAuthenticatorData(authData, requireUV); err != nil {
+		return nil, err
+	}
+	if authData.flags&authDataFlagAttestedData == 0 {
+		return nil, fmt.Errorf("attested credential data is missing")
+	}
+
+	alg, publicKey, err := parseCOSEKey(authData.publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(append([]byte{}, attestation.AuthData...), clientDataHash[:]...)
+	if err := verifyAttestationStatement(attestation, signed, alg, publicKey); err != nil {
+		return nil, err
+	}
+
+	return &AttestedCredential{
+		ID:             authData.credentialID,
+		PublicKey:      authData.publicKey,
+		AAGUID:         authData.aaguid,
+		SignCount:      authData.signCount,
+		UserVerified:   authData.flags&authDataFlagUserVerified != 0,
+		BackupEligible: authData.flags&authDataFlagBackupEligible != 0,
+		BackupState:    authData.flags&authDataFlagBackupState != 0,
+	}, nil
+}
+
+// VerifyAssertion checks a navigator.credentials.get response signed by the
+// credential with the given COSE public key.
+func (cfg WebAuthnConfig) VerifyAssertion(clientDataJSON, rawAuthData, signature, publicKey []byte, challenge string, storedSignCount uint32, requireUV bool) (*AssertionResult, error) {
+	if err := cfg.verifyClientData(clientDataJSON, "webauthn.get", challenge); err != nil {
+		return nil, err
+	}
+
+	authData, err := parseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.verifyAuthenticatorData(authData, requireUV); err != nil {
+		return nil, err
+	}
+
+	alg, key, err := parseCOSEKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(append([]byte{}, rawAuthData...), clientDataHash[:]...)
+	if err := verifySignature(alg, key, signed, signature); err != nil {
+		return nil, err
+	}
+
+	// A counter that does not increase suggests a cloned authenticator.
+	// Authenticators that do not implement counters always report zero.
+	if (authData.signCount != 0 || storedSignCount != 0) && authData.signCount <= storedSignCount {
+		return nil, fmt.Errorf("signature counter did not increase")
+	}
+
+	return &AssertionResult{
+		SignCount:    authData.signCount,
+		UserVerified: authData.flags&authDataFlagUserVerified != 0,
+		BackupState:  authData.flags&authDataFlagBackupState != 0,
+	}, nil
+}
+
+func (cfg WebAuthnConfig) verifyClientData(clientDataJSON []byte, ceremony, challenge string) error {
+	var clientData collectedClientData
+	if err := json.Unmarshal(clientDataJSON, &clientData); err != nil {
+		return fmt.Errorf("malformed client data: %w", err)
+	}
+	if clientData.Type != ceremony {
+		return fmt.Errorf("unexpected client data type %q", clientData.Type)
+	}
+	if subtle.ConstantTimeCompare([]byte(clientData.Challenge), []byte(challenge)) != 1 {
+		return fmt.Errorf("challenge mismatch")
+	}
+	if clientData.CrossOrigin {
+		return fmt.Errorf("cross-origin ceremonies are not allowed")
+	}
+	for _, origin := range cfg.Origins {
+		if clientData.Origin == origin {
+			return nil
+		}
+	}
+	return fmt.Errorf("unexpected origin %q", clientData.Origin)
+}
+
+func (cfg WebAuthnConfig) verifyAuthenticatorData(authData *authenticatorData, requireUV bool) error {
+	rpIDHash := sha256.Sum256([]byte(cfg.RPID))
+	if !bytes.Equal(authData.rpIDHash, rpIDHash[:]) {
+		return fmt.Errorf("rp id hash mismatch")
+	}
+	if authData.flags&authDataFlagUserPresent == 0 {
+		return fmt.Errorf("user presence is required")
+	}
+	if requireUV && authData.flags&authDataFlagUserVerified == 0 {
+		return fmt.Errorf("user verification is required")
+	}
+	if authData.flags&authDataFlagBackupEligible == 0 && authData.flags&authDataFlagBackupState != 0 {
+		return fmt.Errorf("invalid backup flags")
+	}
+	return nil
+}
+
+func parseAuthenticatorData(raw []byte) (*authenticatorData, error) {
+	if len(raw) < 37 {
+		return nil, fmt.Errorf("authenticator data is too short")
+	}
+
+	authData := &authenticatorData{
+		rpIDHash:  raw[:32],
+		flags:     raw[32],
+		signCount: binary.BigEndian.Uint32(raw[33:37]),
+	}
+	rest := raw[37:]
+
+	if authData.flags&authDataFlagAttestedData != 0 {
+		if len(rest) < 18 {
+			return nil, fmt.Errorf("attested credential data is too short")
+		}
+		authData.aaguid = rest[:16]
+		idLength := int(binary.BigEndian.Uint16(rest[16:18]))
+		rest = rest[18:]
+		if idLength > 1023 || len(rest) < idLength {
+			return nil, fmt.Errorf("invalid credential id length")
+		}
+		authData.credentialID = rest[:idLength]
+		rest = rest[idLength:]
+
+		var publicKey cbor.RawMessage
+		remaining, err := cbor.UnmarshalFirst(rest, &publicKey)
+		if err != nil {
+			return nil, fmt.Errorf("malformed credential public key: %w", err)
+		}
+		authData.publicKey = publicKey
+		rest = remaining
+	}
+
+	if authData.flags&authDataFlagExtensionData != 0 {
+		var extensions cbor.RawMessage
+		remaining, err := cbor.UnmarshalFirst(rest, &extensions)
+		if err != nil {
+			return nil, fmt.Errorf("malformed extension data: %w", err)
+		}
+		rest = remaining
+	}
+
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("unexpected trailing authenticator data")
+	}
+	return authData, nil
+}
+
+// verifyAttestationStatement supports the "none" format and "packed" self and
+// basic attestation. Attestation certificates are not chained to trust
+// anchors; registrations request attestation "none".
+func verifyAttestationStatement(attestation attestationObject, signed []byte, credentialAlg int, credentialKey crypto.PublicKey) error {
+	switch attestation.Fmt {
+	case "none":
+		if len(attestation.AttStmt) != 0 {
+			return fmt.Errorf("none attestation must have an empty statement")
+		}
+		return nil
+	case "packed":
+		var alg int
+		var sig []byte
+		if err := cbor.Unmarshal(attestation.AttStmt["alg"], &alg); err != nil {
+			return fmt.Errorf("packed attestation is missing alg")
+		}
+		if err := cbor.Unmarshal(attestation.AttStmt["sig"], &sig); err != nil {
+			return fmt.Errorf("packed attestation is missing sig")
+		}
+
+		rawChain, ok := attestation.AttStmt["x5c"]
+		if !ok {
+			if alg != credentialAlg {
+				return fmt.Errorf("self attestation algorithm mismatch")
+			}
+			return verifySignature(alg, credentialKey, signed, sig)
+		}
+
+		var chain [][]byte
+		if err := cbor.Unmarshal(rawChain, &chain); err != nil || len(chain) == 0 {
+			return fmt.Errorf("malformed attestation certificate chain")
+		}
+		cert, err := x509.ParseCertificate(chain[0])
+		if err != nil {
+			return fmt.Errorf("malformed attestation certificate: %w", err)
+		}
+		return verifySignature(alg, cert.PublicKey, signed, sig)
+	default:
+		return fmt.Errorf("unsupported attestation format %q", attestation.Fmt)
+	}
+}
+
+// parseCOSEKey decodes a COSE_Key into its algorithm and public key.
+func parseCOSEKey(raw []byte) (int, crypto.PublicKey, error) {
+	var key map[int]cbor.RawMessage
+	if err := cbor.Unmarshal(raw, &key); err != nil {
+		return 0, nil, fmt.Errorf("malformed cose key: %w", err)
+	}
+
+	var kty, alg int
+	if err := cbor.Unmarshal(key[1], &kty); err != nil {
+		return 0, nil, fmt.Errorf("cose key has no kty")
+	}
+	if err := cbor.Unmarshal(key[3], &alg); err != nil {
+		return 0, nil, fmt.Errorf("cose key has no alg")
+	}
+
+	switch {
+	case kty == 2 && alg == COSEAlgES256:
+		var crv int
+		var x, y []byte
+		if cbor.Unmarshal(key[-1], &crv) != nil || cbor.Unmarshal(key[-2], &x) != nil || cbor.Unmarshal(key[-3], &y) != nil {
+			return 0, nil, fmt.Errorf("malformed ec2 cose key")
+		}
+		if crv != 1 {
+			return 0, nil, fmt.Errorf("unsupported ec2 curve")
+		}
+		pub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+			return 0, nil, fmt.Errorf("ec2 point is not on curve")
+		}
+		return alg, pub, nil
+	case kty == 1 && alg == COSEAlgEdDSA:
+		var crv int
+		var x []byte
+		if cbor.Unmarshal(key[-1], &crv) != nil || cbor.Unmarshal(key[-2], &x) != nil {
+			return 0, nil, fmt.Errorf("malformed okp cose key")
+		}
+		if crv != 6 || len(x) != ed25519.PublicKeySize {
+			return 0, nil, fmt.Errorf("unsupported okp curve")
+		}
+		return alg, ed25519.PublicKey(x), nil
+	case kty == 3 && alg == COSEAlgRS256:
+		var n, e []byte
+		if cbor.Unmarshal(key[-1], &n) != nil || cbor.Unmarshal(key[-2], &e) != nil {
+			return 0, nil, fmt.Errorf("malformed rsa cose key")
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() {
+			return 0, nil, fmt.Errorf("unsupported rsa exponent")
+		}
+		return alg, &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	default:
+		return 0, nil, fmt.Errorf("unsupported cose key type %d with alg %d", kty, alg)
+	}
+}
+
+func verifySignature(alg int, key crypto.PublicKey, signed, signature []byte) error {
+	switch alg {
+	case COSEAlgES256:
+		pub, ok := key.(*ecdsa.PublicKey)
+		digest := sha256.Sum256(signed)
+		if !ok || !ecdsa.VerifyASN1(pub, digest[:], signature) {
+			return fmt.Errorf("invalid signature")
+		}
+	case COSEAlgEdDSA:
+		pub, ok := key.(ed25519.PublicKey)
+		if !ok || !ed25519.Verify(pub, signed, signature) {
+			return fmt.Errorf("invalid signature")
+		}
+	case COSEAlgRS256:
+		pub, ok := key.(*rsa.PublicKey)
+		digest := sha256.Sum256(signed)
+		if !ok || rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], signature) != nil {
+			return fmt.Errorf("invalid signature")
+		}
+	default:
+		return fmt.Errorf("unsupported signature algorithm %d", alg)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package security_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+	"veritas/internal/security"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	testRPID   = "example.com"
+	testOrigin = "https://example.com"
+)
+
+// softwareAuthenticator is a minimal ES256 authenticator producing the same
+// structures a browser hands to the relying party.
+type softwareAuthenticator struct {
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+	signCount    uint32
+	flags        byte
+}
+
+func newSoftwareAuthenticator() (*softwareAuthenticator, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	credentialID := make([]byte, 16)
+	if _, err := rand.Read(credentialID); err != nil {
+		return nil, err
+	}
+	// User present and user verified.
+	return &softwareAuthenticator{key: key, credentialID: credentialID, flags: 0x05}, nil
+}
+
+// coseKey encodes the public key canonically so that repeated calls return
+// the same bytes.
+func (a *softwareAuthenticator) coseKey() []byte {
+	encoder, _ := cbor.CanonicalEncOptions().EncMode()
+	key, _ := encoder.Marshal(map[int]interface{}{
+		1:  2,
+		3:  security.COSEAlgES256,
+		-1: 1,
+		-2: a.key.X.FillBytes(make([]byte, 32)),
+		-3: a.key.Y.FillBytes(make([]byte, 32)),
+	})
+	return key
+}
+
+func (a *softwareAuthenticator) authData(attested bool) []byte {
+	rpIDHash := sha256.Sum256([]byte(testRPID))
+	data := append([]byte{}, rpIDHash[:]...)
+	flags := a.flags
+	if attested {
+		flags |= 0x40
+	}
+	data = append(data, flags)
+	data = binary.BigEndian.AppendUint32(data, a.signCount)
+	if attested {
+		data = append(data, make([]byte, 16)...) // zero AAGUID
+		data = binary.BigEndian.AppendUint16(data, uint16(len(a.credentialID)))
+		data = append(data, a.credentialID...)
+		data = append(data, a.coseKey()...)
+	}
+	return data
+}
+
+func clientData(ceremony, challenge, origin string) []byte {
+	data, _ := json.Marshal(map[string]interface{}{
+		"type":      ceremony,
+		"challenge": challenge,
+		"origin":    origin,
+	})
+	return data
+}
+
+func (a *softwareAuthenticator) sign(authData, clientDataJSON []byte) []byte {
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+	sig, _ := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	return sig
+}
+
+func (a *softwareAuthenticator) register(challenge, origin string, packed bool) ([]byte, []byte) {
+	clientDataJSON := clientData("webauthn.create", challenge, origin)
+	authData := a.authData(true)
+
+	object := map[string]interface{}{
+		"fmt":      "none",
+		"attStmt":  map[string]interface{}{},
+		"authData": authData,
+	}
+	if packed {
+		object["fmt"] = "packed"
+		object["attStmt"] = map[string]interface{}{
+			"alg": security.COSEAlgES256,
+			"sig": a.sign(authData, clientDataJSON),
+		}
+	}
+	attestationObject, _ := cbor.Marshal(object)
+	return clientDataJSON, attestationObject
+}
+
+func (a *softwareAuthenticator) assert(challenge, origin string) ([]byte, []byte, []byte) {
+	a.signCount++
+	clientDataJSON := clientData("webauthn.get", challenge, origin)
+	authData := a.authData(false)
+	return clientDataJSON, authData, a.sign(authData, clientDataJSON)
+}
+
+type WebAuthnTestSuite struct {
+	suite.Suite
+	config        security.WebAuthnConfig
+	authenticator *softwareAuthenticator
+	challenge     string
+}
+
+func (s *WebAuthnTestSuite) SetupTest() {
+	s.config = security.WebAuthnConfig{RPID: testRPID, RPName: "Example", Origins: []string{testOrigin}}
+	authenticator, err := newSoftwareAuthenticator()
+	s.Require().NoError(err)
+	s.authenticator = authenticator
+	s.challenge = base64.RawURLEncoding.EncodeToString([]byte("a random challenge of 32 bytes.."))
+}
+
+func (s *WebAuthnTestSuite) TestVerifyRegistration() {
+	// Test case 1: None attestation
+	clientDataJSON, attestationObject := s.authenticator.register(s.challenge, testOrigin, false)
+	challenge, err := security.ClientDataChallenge(clientDataJSON)
+	s.NoError(err)
+	s.Equal(s.challenge, challenge)
+
+	credential, err := s.config.VerifyRegistration(clientDataJSON, attestationObject, s.challenge, true)
+	s.NoError(err)
+	s.Equal(s.authenticator.credentialID, credential.ID)
+	s.Equal(s.authenticator.coseKey(), credential.PublicKey)
+	s.True(credential.UserVerified)
+
+	// Test case 2: Packed self attestation
+	clientDataJSON, attestationObject = s.authenticator.register(s.challenge, testOrigin, true)
+	_, err = s.config.VerifyRegistration(clientDataJSON, attestationObject, s.challenge, true)
+	s.NoError(err)
+
+	// Test case 3: Challenge mismatch
+	clientDataJSON, attestationObject = s.authenticator.register("another-challenge", testOrigin, false)
+	_, err = s.config.VerifyRegistration(clientDataJSON, attestationObject, s.challenge, false)
+	s.Error(err)
+
+	// Test case 4: Unexpected origin
+	clientDataJSON, attestationObject = s.authenticator.register(s.challenge, "https://evil.example", false)
+	_, err = s.config.VerifyRegistration(clientDataJSON, attestationObject, s.challenge, false)
+	s.Error(err)
+
+	// Test case 5: User verification required but not performed
+	s.authenticator.flags = 0x01
+	clientDataJSON, attestationObject = s.authenticator.register(s.challenge, testOrigin, false)
+	_, err = s.config.VerifyRegistration(clientDataJSON, attestationObject, s.challenge, true)
+	s.Error(err)
+}
+
+func (s *WebAuthnTestSuite) TestVerifyAssertion() {
+	publicKey := s.authenticator.coseKey()
+
+	// Test case 1: Valid assertion returns the new counter
+	clientDataJSON, authData, signature := s.authenticator.assert(s.challenge, testOrigin)
+	result, err := s.config.VerifyAssertion(clientDataJSON, authData, signature, publicKey, s.challenge, 0, true)
+	s.NoError(err)
+	s.Equal(uint32(1), result.SignCount)
+	s.True(result.UserVerified)
+
+	// Test case 2: Counter that did not increase is rejected
+	_, err = s.config.VerifyAssertion(clientDataJSON, authData, signature, publicKey, s.challenge, 1, true)
+	s.Error(err)
+
+	// Test case 3: Tampered signature
+	clientDataJSON, authData, signature = s.authenticator.assert(s.challenge, testOrigin)
+	signature[len(signature)-1] ^= 0xff
+	_, err = s.config.VerifyAssertion(clientDataJSON, authData, signature, publicKey, s.challenge, 1, true)
+	s.Error(err)
+
+	// Test case 4: Registration response replayed as an assertion
+	clientDataJSON, _ = s.authenticator.register(s.challenge, testOrigin, false)
+	_, authData, _ = s.authenticator.assert(s.challenge, testOrigin)
+	signature = s.authenticator.sign(authData, clientDataJSON)
+	_, err = s.config.VerifyAssertion(clientDataJSON, authData, signature, publicKey, s.challenge, 0, false)
+	s.Error(err)
+
+	// Test case 5: Key from another authenticator
+	other, err := newSoftwareAuthenticator()
+	s.Require().NoError(err)
+	clientDataJSON, authData, signature = other.assert(s.challenge, testOrigin)
+	_, err = s.config.VerifyAssertion(clientDataJSON, authData, signature, publicKey, s.challenge, 0, false)
+	s.Error(err)
+}
+
+func TestWebAuthnSuite(t *testing.T) {
+	suite.Run(t, new(WebAuthnTestSuite))
+}
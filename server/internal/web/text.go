@@ -0,0 +1,76 @@
+package web
+
+// defaultText is the copy of the hosted pages. Branding can override any of
+// it by key.
+var defaultText = map[string]string{
+	"common.name":     "Name",
+	"common.email":    "Email",
+	"common.password": "Password",
+	"common.code":     "Code",
+	"common.footer":   "",
+	"common.continue": "Continue to sign in",
+
+	"login.title":    "Sign in",
+	"login.subtitle": "Welcome back. Sign in to continue.",
+	"login.submit":   "Sign in",
+	"login.forgot":   "Forgot your password?",
+	"login.signup":   "Create an account",
+
+	"mfa.title":         "Verify it's you",
+	"mfa.subtitle":      "Enter a code from one of your second factors.",
+	"mfa.method":        "Verify with",
+	"mfa.totp":          "Authenticator app",
+	"mfa.sms":           "Text message",
+	"mfa.recovery_code": "Recovery code",
+	"mfa.submit":        "Verify",
+	"mfa.send_sms":      "Text me a code",
+	"mfa.sms_sent":      "We sent a code to your phone.",
+
+	"signup.title":    "Create your account",
+	"signup.subtitle": "It only takes a minute.",
+	"signup.submit":   "Create account",
+	"signup.login":    "Already have an account? Sign in",
+	"signup.done":     "Check your email and open the link we sent to confirm your address.",
+
+	"forgot.title":    "Reset your password",
+	"forgot.subtitle": "Enter your email address and we will send you a link to choose a new password.",
+	"forgot.submit":   "Send reset link",
+	"forgot.done":     "If the address is registered, a reset link is on its way.",
+
+	"reset.title":    "Choose a new password",
+	"reset.confirm":  "Confirm password",
+	"reset.mismatch": "The passwords do not match.",
+	"reset.submit":   "Set password",
+	"reset.done":     "Your password has been changed. Sign in with your new password.",
+	"reset.invalid":  "This reset link is invalid or has expired.",
+
+	"verify.title":   "Email verification",
+	"verify.done":    "Your email address is verified.",
+	"verify.invalid": "This verification link is invalid or has expired.",
+
+	"invitation.title":    "Accept your invitation",
+	"invitation.subtitle": "You have been invited to join",
+	"invitation.existing": "You already have an account with this address. Accepting adds the invitation to it.",
+	"invitation.submit":   "Accept invitation",
+	"invitation.done":     "You have joined. Sign in to continue.",
+	"invitation.invalid":  "This invitation is invalid, has expired or has already been used.",
+
+	"account.title":        "Your account",
+	"account.signed_in_as": "Signed in as",
+	"account.sessions":     "Signed-in devices",
+	"account.current":      "This device",
+	"account.revoke":       "Sign out",
+	"account.signout":      "Sign out of this device",
+
+	"error.title":       "Something went wrong",
+	"error.generic":     "Something went wrong. Please try again.",
+	"error.csrf":        "The form has expired. Reload the page and try again.",
+	"error.credentials": "Incorrect email or password.",
+	"error.code":        "That code is not valid.",
+	"error.throttled":   "Too many attempts. Please wait a moment and try again.",
+	"error.inactive":    "This account cannot sign in.",
+	"error.unverified":  "Confirm your email address before signing in.",
+	"error.email_taken": "An account with this email address already exists.",
+	"error.passkey":     "This account needs a passkey as its second factor. Sign in from an application that supports passkeys.",
+	"error.challenge":   "Your sign-in has expired. Please sign in again.",
+}
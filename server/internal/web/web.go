@@ -0,0 +1,150 @@
+// Package web renders the hosted login, signup and account pages from
+// templates and assets embedded in the binary.
+package web
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"veritas/core/domain"
+)
+
+var (
+	//go:embed templates/*.html
+	templateFiles embed.FS
+
+	//go:embed static
+	staticFiles embed.FS
+)
+
+// Page is the data the hosted pages are rendered with. Only the fields a
+// page uses need to be set.
+type Page struct {
+	Branding   *domain.Branding
+	Text       map[string]string
+	Nonce      string
+	CSRFToken  string
+	Tenant     string // organization named in the links of the page, if any
+	ReturnTo   string
+	Error      string
+	Notice     string
+	Violations []string
+
+	// Form values shown again after a failed submission.
+	Name  string
+	Email string
+
+	// Title and Message of the message page.
+	Title   string
+	Message string
+
+	// Second factors offered by the MFA page, and the one preselected.
+	Methods []string
+	Method  string
+
+	// Token of the password reset and invitation pages.
+	Token string
+
+	// Organization of the invitation page, and whether the invitee already
+	// has an account in it.
+	Organization string
+	HasAccount   bool
+
+	// User and sessions of the account page.
+	User      *domain.User
+	Sessions  []*domain.Session
+	SessionID string
+}
+
+// NewPage returns a page themed with branding, whose copy overrides the
+// default text.
+func NewPage(branding *domain.Branding) *Page {
+	text := make(map[string]string, len(defaultText)+len(branding.Copy))
+	for key, value := range defaultText {
+		text[key] = value
+	}
+	for key, value := range branding.Copy {
+		text[key] = value
+	}
+	return &Page{Branding: branding, Text: text}
+}
+
+// T returns the text of the page with the given key.
+func (p *Page) T(key string) string {
+	return p.Text[key]
+}
+
+// URL returns the path of another hosted page, naming the page's
+// organization.
+func (p *Page) URL(path string) string {
+	return TenantURL(path, p.Tenant)
+}
+
+// TenantURL adds the tenant query parameter naming an organization to path,
+// unless tenant is empty.
+func TenantURL(path, tenant string) string {
+	if tenant == "" {
+		return path
+	}
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return path + separator + "tenant=" + url.QueryEscape(tenant)
+}
+
+// HasMethod reports whether the MFA page offers method.
+func (p *Page) HasMethod(method string) bool {
+	return slices.Contains(p.Methods, method)
+}
+
+// Renderer executes the page templates, each within the shared layout.
+type Renderer struct {
+	pages map[string]*template.Template
+}
+
+func NewRenderer() (*Renderer, error) {
+	files, err := fs.Glob(templateFiles, "templates/*.html")
+	if err != nil {
+		return nil, err
+	}
+
+	pages := map[string]*template.Template{}
+	for _, file := range files {
+		name := strings.TrimSuffix(path.Base(file), ".html")
+		if name == "layout" {
+			continue
+		}
+		page, err := template.ParseFS(templateFiles, "templates/layout.html", file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse page %s: %w", name, err)
+		}
+		pages[name] = page
+	}
+	return &Renderer{pages: pages}, nil
+}
+
+// Render writes the named page to w.
+func (r *Renderer) Render(w io.Writer, name string, page *Page) error {
+	tmpl, ok := r.pages[name]
+	if !ok {
+		return fmt.Errorf("unknown page %q", name)
+	}
+	return tmpl.ExecuteTemplate(w, "layout", page)
+}
+
+// Static returns the stylesheet and other assets of the pages.
+func Static() http.FileSystem {
+	assets, err := fs.Sub(staticFiles, "static")
+	if err != nil {
+		panic(err)
+	}
+	return http.FS(assets)
+}